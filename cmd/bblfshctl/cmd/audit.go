@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	AuditCommandDescription = "List the recent control requests from the audit log"
+	AuditCommandHelp        = AuditCommandDescription + "\n\n" +
+		"The daemon writes every request to the control server to its audit \n" +
+		"log, with the identity of the caller, the parameters of the request, \n" +
+		"the digest of the driver image it acted on and its outcome. This \n" +
+		"command prints the most recent entries, from the oldest to the most \n" +
+		"recent. Using `--json` the entries are printed as JSON lines."
+)
+
+type AuditCommand struct {
+	Limit    int    `short:"n" long:"limit" description:"maximal number of entries to list, 100 by default"`
+	RPC      string `long:"rpc" description:"list only the requests to this control RPC, like InstallDriver"`
+	Language string `short:"l" long:"language" description:"list only the requests for the drivers of this language"`
+	JSON     bool   `long:"json" description:"print the entries as JSON lines"`
+
+	ControlCommand
+}
+
+func (c *AuditCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	r, err := c.srv.AuditLog(context.Background(), &protocol.AuditLogRequest{
+		Limit:    c.Limit,
+		RPC:      c.RPC,
+		Language: c.Language,
+	})
+	if err != nil {
+		return err
+	} else if len(r.Errors) != 0 {
+		printErrors(r.Errors)
+		return fmt.Errorf("audit log query failed: %v", r.Errors)
+	}
+
+	if c.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range r.Entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	auditLogToText(r.Entries)
+	return nil
+}
+
+func auditLogToText(entries []*protocol.AuditEntry) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "Caller", "RPC", "Request", "Digest", "Outcome", "Elapsed", "Error"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, e := range entries {
+		table.Append([]string{
+			e.Time.Local().Format(time.RFC3339), e.Caller, e.RPC, auditRequest(e),
+			shortDigest(e.Digest), string(e.Outcome),
+			e.Elapsed.Round(time.Millisecond).String(), e.Error,
+		})
+	}
+
+	table.Render()
+}
+
+// auditRequest returns the parameters of the request of an audit entry.
+func auditRequest(e *protocol.AuditEntry) string {
+	var params []string
+	for _, p := range []struct{ name, value string }{
+		{"language", e.Language},
+		{"image", e.Reference},
+		{"version", e.Version},
+	} {
+		if p.value != "" {
+			params = append(params, p.name+"="+p.value)
+		}
+	}
+	if e.Update {
+		params = append(params, "update")
+	}
+	if e.DryRun {
+		params = append(params, "dry-run")
+	}
+	return strings.Join(params, " ")
+}
+
+func shortDigest(d string) string {
+	if len(d) > 12 {
+		return d[:12]
+	}
+	return d
+}
@@ -2,19 +2,67 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"time"
 
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	sdk "gopkg.in/bblfsh/sdk.v1/protocol"
 )
 
+// TLSOptions are the options of the TLS connections to bblfshd. TLS is used
+// unless connecting to a unix socket.
+type TLSOptions struct {
+	NoTLS bool   `long:"no-tls" description:"connect without TLS; TLS is used unless connecting to a unix socket"`
+	CA    string `long:"tls-ca" description:"CA certificates the server certificate is verified against, instead of the system ones"`
+	Cert  string `long:"tls-cert" description:"client certificate, for servers requiring one"`
+	Key   string `long:"tls-key" description:"private key of the client certificate"`
+}
+
+// credentials returns the transport credentials to connect to the address, or
+// nil if TLS is not used.
+func (o TLSOptions) credentials(network, address string) (credentials.TransportCredentials, error) {
+	if o.NoTLS || network == "unix" {
+		return nil, nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	c := &tls.Config{ServerName: host}
+
+	if o.CA != "" {
+		data, err := ioutil.ReadFile(o.CA)
+		if err != nil {
+			return nil, err
+		}
+		c.RootCAs = x509.NewCertPool()
+		if !c.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CA)
+		}
+	}
+
+	if o.Cert != "" || o.Key != "" {
+		cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, err
+		}
+		c.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(c), nil
+}
+
 type ControlCommand struct {
 	Network string `long:"ctl-network" default:"unix" description:"control server network type"`
 	Address string `long:"ctl-address" default:"/var/run/bblfshctl.sock" description:"control server address to connect"`
+	TLSOptions
 
 	conn *grpc.ClientConn
 	srv  protocol.ProtocolServiceClient
@@ -22,7 +70,7 @@ type ControlCommand struct {
 
 func (c *ControlCommand) Execute(args []string) error {
 	var err error
-	c.conn, err = dialGRPC(c.Network, c.Address)
+	c.conn, err = dialGRPC(c.Network, c.Address, c.TLSOptions)
 	c.srv = protocol.NewProtocolServiceClient(c.conn)
 	return err
 }
@@ -30,6 +78,7 @@ func (c *ControlCommand) Execute(args []string) error {
 type UserCommand struct {
 	Network string `long:"endpoint" default:"tcp" description:"server network type"`
 	Address string `long:"address" default:"localhost:9432" description:"server address to connect"`
+	TLSOptions
 
 	conn *grpc.ClientConn
 	srv  sdk.ProtocolServiceClient
@@ -37,19 +86,28 @@ type UserCommand struct {
 
 func (c *UserCommand) Execute(args []string) error {
 	var err error
-	c.conn, err = dialGRPC(c.Network, c.Address)
+	c.conn, err = dialGRPC(c.Network, c.Address, c.TLSOptions)
 	c.srv = sdk.NewProtocolServiceClient(c.conn)
 	return err
 }
 
-func dialGRPC(network, address string) (*grpc.ClientConn, error) {
+func dialGRPC(network, address string, tlsOpts TLSOptions) (*grpc.ClientConn, error) {
+	creds, err := tlsOpts.credentials(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load TLS certificates: %s", err)
+	}
+	security := grpc.WithInsecure()
+	if creds != nil {
+		security = grpc.WithTransportCredentials(creds)
+	}
+
 	conn, err := grpc.Dial(address,
 		grpc.WithDialer(func(addr string, t time.Duration) (net.Conn, error) {
 			return net.DialTimeout(network, address, t)
 		}),
 		grpc.WithBlock(),
 		grpc.WithTimeout(5*time.Second),
-		grpc.WithInsecure(),
+		security,
 	)
 	if err == context.DeadlineExceeded {
 		return nil, fmt.Errorf("failed to connect to %s (%s): timeout", address, network)
@@ -1,7 +1,7 @@
 package cmd
 
 const (
-	DriverCommandDescription = "Manage drivers: install, remove and list"
+	DriverCommandDescription = "Manage drivers: install, remove, list, export, import, select the current version, gc and reconcile"
 	DriverCommandHelp        = DriverCommandDescription
 )
 
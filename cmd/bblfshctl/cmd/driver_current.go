@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+)
+
+const (
+	DriverCurrentCommandDescription = "Sets the current version of the driver for a language"
+	DriverCurrentCommandHelp        = DriverCurrentCommandDescription + "\n\n" +
+		"The current version is used by the parse requests not asking for a \n" +
+		"specific version of the driver. The installed versions are listed \n" +
+		"by `driver list`."
+)
+
+type DriverCurrentCommand struct {
+	Args struct {
+		Language string `positional-arg-name:"language" description:"language supported by the driver"`
+		Version  string `positional-arg-name:"version" description:"version of the driver"`
+	} `positional-args:"yes" required:"yes"`
+
+	DriverCommand
+}
+
+func (c *DriverCurrentCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	r, err := c.srv.SetCurrentDriver(context.Background(), &protocol.SetCurrentDriverRequest{
+		Language: c.Args.Language,
+		Version:  c.Args.Version,
+	})
+	if err != nil {
+		return err
+	} else if len(r.Errors) != 0 {
+		for _, e := range r.Errors {
+			fmt.Fprintf(os.Stderr, "Error, %s\n", e)
+		}
+		return fmt.Errorf("driver current failed: %v", r.Errors)
+	}
+
+	fmt.Printf("Driver %s version %s is now the current one\n", c.Args.Language, c.Args.Version)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+)
+
+const (
+	DriverExportCommandDescription = "Exports an installed driver to a bundle file"
+	DriverExportCommandHelp        = DriverExportCommandDescription + "\n\n" +
+		"The bundle holds the image of the driver, and can be installed with \n" +
+		"`driver import` on daemons without access to the image registry. \n" +
+		"The current version of the driver is exported, unless `--version` \n" +
+		"is given.\n\n" +
+		"The bundle is sent by the daemon, and written to a local file."
+)
+
+type DriverExportCommand struct {
+	Args struct {
+		Language string `positional-arg-name:"language" description:"language supported by the driver"`
+		File     string `positional-arg-name:"file" description:"path of the bundle file to write"`
+	} `positional-args:"yes" required:"yes"`
+
+	Version string `long:"version" description:"version of the driver to export"`
+
+	DriverCommand
+}
+
+func (c *DriverExportCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	stream, err := c.srv.ExportDriver(context.Background(), &protocol.ExportDriverRequest{
+		Language: c.Args.Language,
+		Version:  c.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the bundle is written next to the file and renamed once complete, so
+	// a failed export doesn't leave a truncated bundle
+	f, err := ioutil.TempFile(filepath.Dir(c.Args.File), ".bundle")
+	if err != nil {
+		return err
+	}
+
+	err = receiveBundle(stream, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.Args.File)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("driver export failed: %v", err)
+	}
+
+	fmt.Printf("Driver %s exported to %s\n", c.Args.Language, c.Args.File)
+	return nil
+}
+
+// receiveBundle writes the chunks of a bundle sent by the daemon to w.
+func receiveBundle(stream protocol.ProtocolService_ExportDriverClient, w io.Writer) error {
+	for {
+		c, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(c.Data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/docker/go-units"
+)
+
+const (
+	DriverGCCommandDescription = "Removes the unused files of the driver storage"
+	DriverGCCommandHelp        = DriverGCCommandDescription + "\n\n" +
+		"The layers of the driver images are shared between the installed \n" +
+		"drivers, and are kept when a driver is removed. This command removes \n" +
+		"the layers not used by any installed driver, and the temporary \n" +
+		"files left behind by interrupted installations."
+)
+
+type DriverGCCommand struct {
+	DriverCommand
+}
+
+func (c *DriverGCCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	r, err := c.srv.GarbageCollect(context.Background(), &protocol.GarbageCollectRequest{})
+	if err != nil {
+		return err
+	} else if len(r.Errors) != 0 {
+		for _, e := range r.Errors {
+			fmt.Fprintf(os.Stderr, "Error, %s\n", e)
+		}
+		return fmt.Errorf("driver gc failed: %v", r.Errors)
+	}
+
+	fmt.Printf("Removed %d unused layers and %d temporary directories, %s released\n",
+		r.Layers, r.Temporary, units.HumanSize(float64(r.Size)))
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+)
+
+const (
+	DriverImportCommandDescription = "Installs a driver from a bundle file"
+	DriverImportCommandHelp        = DriverImportCommandDescription + "\n\n" +
+		"The bundle is a file written by `driver export`. The driver is \n" +
+		"installed as the image it was exported from, without access to \n" +
+		"the image registry. Using `--update` the installed image is made \n" +
+		"the current version.\n\n" +
+		"The bundle is read from a local file, and sent to the daemon."
+)
+
+type DriverImportCommand struct {
+	Args struct {
+		File string `positional-arg-name:"file" description:"path of the bundle file to install"`
+	} `positional-args:"yes" required:"yes"`
+
+	Update bool `long:"update" description:"replace the image of the same version if any, and make it the current version"`
+
+	DriverCommand
+}
+
+// bundleChunkSize is the size of the chunks of the bundle sent to the daemon.
+const bundleChunkSize = 1 << 20
+
+func (c *DriverImportCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	f, err := os.Open(c.Args.File)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+	stream, err := c.srv.ImportDriver(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if err := sendBundle(stream, c.Update, f); err != nil {
+		return err
+	}
+
+	r, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	} else if len(r.Errors) != 0 {
+		for _, e := range r.Errors {
+			fmt.Fprintf(os.Stderr, "Error, %s\n", e)
+		}
+		return fmt.Errorf("driver import failed: %v", r.Errors)
+	}
+
+	fmt.Printf("Driver imported from %s\n", c.Args.File)
+	return nil
+}
+
+// sendBundle sends the update flag, followed by the content of the bundle
+// read from r in chunks. The daemon ends the stream early if the import
+// fails, and its error is returned by CloseAndRecv.
+func sendBundle(stream protocol.ProtocolService_ImportDriverClient, update bool, r io.Reader) error {
+	err := stream.Send(&protocol.ImportDriverRequest{Update: update})
+	buf := make([]byte, bundleChunkSize)
+	for err == nil {
+		n, rerr := io.ReadFull(r, buf)
+		if n > 0 {
+			err = stream.Send(&protocol.ImportDriverRequest{Data: buf[:n]})
+		}
+
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		} else if rerr != nil {
+			return rerr
+		}
+	}
+
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,8 +17,6 @@ import (
 
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/sdk/v3/driver/manifest/discovery"
-
-	"github.com/briandowns/spinner"
 )
 
 var (
@@ -26,8 +25,11 @@ var (
 	DefaultTransport = "docker://"
 
 	SupportedTransports = map[string]bool{
-		"docker":        true,
-		"docker-daemon": true,
+		"docker":         true,
+		"docker-daemon":  true,
+		"docker-archive": true,
+		"oci":            true,
+		"dir":            true,
 	}
 )
 
@@ -102,8 +104,16 @@ const (
 		"daemon. Using `--recommended` will only install the recommended, \n" +
 		"more developed. Using `language` and `image` positional arguments \n" +
 		"one single driver can be installed or updated.\n\n" +
+		"Several versions of a driver can be installed side by side, the \n" +
+		"first one installed for a language is used by default. Using \n" +
+		"`--update` the installed image is made the current version.\n\n" +
 		"Image reference format should be `[transport]name[:tag]`.\n" +
-		"Defaults are 'docker://' for transport and 'latest' for tag."
+		"Defaults are 'docker://' for transport and 'latest' for tag. \n" +
+		"Supported transports are 'docker://', 'docker-daemon:', \n" +
+		"'docker-archive:', 'oci:' and 'dir:'.\n\n" +
+		"Using `--username` and `--password`, or `--password-stdin`, the \n" +
+		"images are pulled with the given credentials, instead of the \n" +
+		"ones configured in the daemon."
 )
 
 type DriverInstallCommand struct {
@@ -112,11 +122,15 @@ type DriverInstallCommand struct {
 		ImageReference string `positional-arg-name:"image" description:"driver's image reference"`
 	} `positional-args:"yes"`
 
-	Update      bool `long:"update" description:"replace the current image for the language if any"`
+	Update      bool `long:"update" description:"replace the image of the same version if any, and make it the current version"`
 	All         bool `long:"all" description:"installs all the official drivers"`
 	Recommended bool `long:"recommended" description:"install the recommended official drivers"`
 	Force       bool `short:"f" long:"force" description:"ignore already installed errors"`
 
+	Username      string `long:"username" description:"username to authenticate to the registry of the images"`
+	Password      string `long:"password" description:"password to authenticate to the registry of the images"`
+	PasswordStdin bool   `long:"password-stdin" description:"read the password from the standard input"`
+
 	DriverCommand
 }
 
@@ -125,6 +139,14 @@ func (c *DriverInstallCommand) Execute(args []string) error {
 		return err
 	}
 
+	if c.PasswordStdin {
+		password, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		c.Password = strings.TrimRight(string(password), "\r\n")
+	}
+
 	if err := c.ControlCommand.Execute(nil); err != nil {
 		return err
 	}
@@ -166,6 +188,10 @@ func (c *DriverInstallCommand) Validate() error {
 		return fmt.Errorf("error --all and --recommended are exclusive")
 	}
 
+	if c.Password != "" && c.PasswordStdin {
+		return fmt.Errorf("error --password and --password-stdin are exclusive")
+	}
+
 	return nil
 }
 
@@ -174,25 +200,29 @@ type driverRef struct {
 	Ref  string
 }
 
+// installEvent is an event of the installation of a driver sent by the
+// workers of installDrivers.
+type installEvent struct {
+	Ref      string
+	Progress *protocol.InstallProgress
+	Err      error
+	Done     bool
+}
+
 func (c *DriverInstallCommand) installDrivers(refs []driverRef) error {
 	if len(refs) == 0 {
 		return nil
 	} else if len(refs) == 1 {
 		return c.installSingleDriver(refs[0])
 	}
-	refNum := len(refs)
 	ctx := context.Background()
 	const workers = 3
-	type resp struct {
-		Ref string
-		Err error
-	}
 	var (
-		wg   sync.WaitGroup
-		last error
-		errs []error
-		jobs = make(chan driverRef)
-		out  = make(chan resp, len(refs))
+		wg     sync.WaitGroup
+		last   error
+		errs   []error
+		jobs   = make(chan driverRef)
+		events = make(chan installEvent)
 	)
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
@@ -200,101 +230,68 @@ func (c *DriverInstallCommand) installDrivers(refs []driverRef) error {
 			defer wg.Done()
 
 			for ref := range jobs {
-				err := c.installDriver(ctx, ref)
-				out <- resp{
-					Ref: ref.Ref, Err: err,
-				}
+				key := ref.Ref
+				err := c.installDriver(ctx, ref, func(p *protocol.InstallProgress) {
+					events <- installEvent{Ref: key, Progress: p}
+				})
+				events <- installEvent{Ref: key, Err: err, Done: true}
 			}
 		}()
 	}
 
-	mref := make(map[string]driverRef, len(refs))
-	for _, ref := range refs {
-		mref[ref.Ref] = ref
-	}
-	status := make(map[string]string, len(refs))
+	go func() {
+		for _, ref := range refs {
+			jobs <- ref
+		}
+		close(jobs)
+	}()
+
+	view := newInstallView(refs)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
 
-	spin := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	clist := make([][3]string, 0, len(mref))
 	todo := len(refs)
 	done := 0
 
-	accept := func(r resp) {
+	accept := func(e installEvent) {
+		s := view.get(e.Ref)
+		if !e.Done {
+			s.update(e.Progress)
+			return
+		}
+
 		todo--
-		str := " + "
-		if r.Err != nil {
-			if daemon.ErrAlreadyInstalled.Is(r.Err) && c.Force {
+		s.done = true
+		if e.Err != nil {
+			if daemon.ErrAlreadyInstalled.Is(e.Err) && c.Force {
 				done++
-			} else {
-				last = r.Err
-				errs = append(errs, r.Err)
-				str = "ERR"
+				return
 			}
+			last = e.Err
+			errs = append(errs, e.Err)
+			s.err = e.Err
 		} else {
 			done++
 		}
-		status[r.Ref] = str
 	}
 
-	draining := false
-	first := true
-install:
-	for todo >= 0 {
-		clist = clist[:0]
-		for _, ref := range mref {
-			clist = append(clist, [3]string{ref.Lang, status[ref.Ref], ref.Ref})
-		}
-		sort.Slice(clist, func(i, j int) bool {
-			return clist[i][0] < clist[j][0]
-		})
-
-		if first {
-			first = false
-		} else {
-			fmt.Print(fmt.Sprintf("\033[%dA", refNum+3)) //delete previous N lines in terminal
-			fmt.Print("\033[J")
-		}
-
-		if todo == 0 {
-			fmt.Printf("\nInstalled %d/%d drivers:\n", done, len(clist))
-		} else {
-			fmt.Printf("\nInstalling %d/%d drivers:\n", todo, len(clist))
-		}
-		for _, cols := range clist {
-			fmt.Printf("%12s %3s %s\n", cols[0], cols[1], cols[2])
-		}
-		if todo == 0 {
-			break
-		}
-		fmt.Print("Please wait  ")
-		spin.Start()
-
-		if !draining {
-			if len(refs) != 0 {
-				cur := refs[0]
-				// send jobs and receive responses
-				select {
-				case jobs <- cur:
-					refs = refs[1:]
-					status[cur.Ref] = "..."
-				case r := <-out:
-					accept(r)
-				}
-				spin.Stop()
-				fmt.Println()
-				continue install
+	fmt.Println()
+	for todo > 0 {
+		view.draw(os.Stdout, fmt.Sprintf("Installing %d/%d drivers:", todo, len(refs)))
+
+		// receive the events until the next redraw
+		for wait := true; wait && todo > 0; {
+			select {
+			case e := <-events:
+				accept(e)
+			case <-ticker.C:
+				wait = false
 			}
-			// no jobs left
-			close(jobs)
-			draining = true
 		}
-
-		// drain responses
-		r := <-out
-		spin.Stop()
-		fmt.Println()
-		accept(r)
 	}
+	view.draw(os.Stdout, fmt.Sprintf("Installed %d/%d drivers:", done, len(refs)))
+	wg.Wait()
+
 	fmt.Println()
 	if len(errs) != 0 {
 		for _, err := range errs {
@@ -306,21 +303,55 @@ install:
 	return last
 }
 
-func (c *DriverInstallCommand) installDriver(ctx context.Context, ref driverRef) error {
+// installDriver installs a driver, calling progress with the events of the
+// installation sent by the daemon. If the daemon doesn't stream them, the
+// driver is installed without progress.
+func (c *DriverInstallCommand) installDriver(ctx context.Context, ref driverRef, progress func(*protocol.InstallProgress)) error {
 	ref.Ref = c.getImageReference(ref.Ref)
-	r, err := c.srv.InstallDriver(ctx, &protocol.InstallDriverRequest{
+	req := &protocol.InstallDriverRequest{
 		Language:       ref.Lang,
 		ImageReference: ref.Ref,
 		Update:         c.Update,
-	})
+		Username:       c.Username,
+		Password:       c.Password,
+	}
+
+	err := c.installDriverStream(ctx, req, progress)
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+		err = c.installDriverUnary(ctx, req)
+	}
+
 	if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
 		return daemon.ErrAlreadyInstalled.New(ref.Lang, ref.Ref)
 	} else if ok && st.Code() == codes.Unauthenticated {
 		return daemon.ErrUnauthorized.New(ref.Lang, ref.Ref)
-	} else if err == nil && len(r.Errors) == 0 {
-		return nil
 	}
-	if err == nil {
+	return err
+}
+
+func (c *DriverInstallCommand) installDriverStream(ctx context.Context, req *protocol.InstallDriverRequest, progress func(*protocol.InstallProgress)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.srv.InstallDriverStream(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		progress(p)
+	}
+}
+
+func (c *DriverInstallCommand) installDriverUnary(ctx context.Context, req *protocol.InstallDriverRequest) error {
+	r, err := c.srv.InstallDriver(ctx, req)
+	if err == nil && len(r.Errors) != 0 {
 		err = fmt.Errorf("%v", r.Errors)
 	}
 	return err
@@ -331,13 +362,46 @@ func (c *DriverInstallCommand) installSingleDriver(ref driverRef) error {
 	if ref.Lang != "" {
 		ltext = fmt.Sprintf("%s language ", ref.Lang)
 	}
-	fmt.Printf("Installing %sdriver from %q... ", ltext, ref.Ref)
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond) // Build our new spinner
-	s.Start()
+	header := fmt.Sprintf("Installing %sdriver from %q...", ltext, ref.Ref)
+
+	view := newInstallView([]driverRef{ref})
+	s := view.states[0]
+
+	events := make(chan *protocol.InstallProgress)
+	result := make(chan error, 1)
+	go func() {
+		result <- c.installDriver(context.Background(), ref, func(p *protocol.InstallProgress) {
+			events <- p
+		})
+	}()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
 
-	err := c.installDriver(context.Background(), ref)
+	var err error
+install:
+	for {
+		view.draw(os.Stdout, header)
+
+		// receive the events until the next redraw
+		for wait := true; wait; {
+			select {
+			case p := <-events:
+				s.update(p)
+			case err = <-result:
+				break install
+			case <-ticker.C:
+				wait = false
+			}
+		}
+	}
+
+	s.done = true
+	if err != nil && !(daemon.ErrAlreadyInstalled.Is(err) && c.Force) {
+		s.err = err
+	}
+	view.draw(os.Stdout, header)
 
-	s.Stop()
 	if err == nil {
 		fmt.Println("Done")
 		return nil
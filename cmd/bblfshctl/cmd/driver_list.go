@@ -52,23 +52,57 @@ func printErrors(errors []string) {
 
 func driverStatusToText(r *protocol.DriverStatesResponse) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Language", "Image", "Version", "Status", "Created", "Go", "Native"})
+	table.SetHeader([]string{"Language", "Image", "Version", "Current", "Status", "Created", "Go", "Native", "Update"})
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 
+	var updateErrors []string
 	for _, s := range r.State {
 		var native []string
 		for _, v := range s.NativeVersion {
 			native = append(native, fmt.Sprintf("%s", v))
 		}
 
-		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
-			s.Language, s.Reference, s.Version,
+		var current string
+		if s.Current {
+			current = "*"
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
+			s.Language, s.Reference, s.Version, current,
 			s.Status, units.HumanDuration(time.Since(s.Build)),
-			s.GoVersion, strings.Join(native, ","),
+			s.GoVersion, strings.Join(native, ","), updateStatus(s),
 		)
 		table.Append(strings.Split(line, "\t"))
+
+		if s.UpdateError != "" {
+			updateErrors = append(updateErrors, fmt.Sprintf("%s (%s): %s", s.Language, s.Reference, s.UpdateError))
+		}
 	}
 
 	table.Render()
+	if len(updateErrors) != 0 {
+		fmt.Println("Update errors:")
+		for _, err := range updateErrors {
+			fmt.Printf("\t- %s\n", err)
+		}
+	}
 	fmt.Printf("Response time %s\n", r.Elapsed)
 }
+
+// updateStatus returns the result of the last update check of the driver, and
+// its update policy.
+func updateStatus(s *protocol.DriverImageState) string {
+	var st string
+	switch {
+	case s.UpdatePolicy == "":
+		return "-"
+	case s.UpdateError != "":
+		st = "error"
+	case s.UpdateAvailable:
+		st = "available"
+	default:
+		st = "up to date"
+	}
+
+	return fmt.Sprintf("%s (%s)", st, s.UpdatePolicy)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	DriverReconcileCommandDescription = "Reconciles the installed drivers with the drivers configuration"
+	DriverReconcileCommandHelp        = DriverReconcileCommandDescription + "\n\n" +
+		"The daemon installs, upgrades and removes drivers until the installed \n" +
+		"ones match the file passed to its `--drivers-config` flag, as it does \n" +
+		"at boot and on reload. Using `--dry-run` the changes are only listed."
+)
+
+type DriverReconcileCommand struct {
+	DryRun bool `long:"dry-run" description:"list the changes without applying them"`
+
+	DriverCommand
+}
+
+func (c *DriverReconcileCommand) Execute(args []string) error {
+	if err := c.ControlCommand.Execute(nil); err != nil {
+		return err
+	}
+
+	r, err := c.srv.ReconcileDrivers(context.Background(), &protocol.ReconcileDriversRequest{
+		DryRun: c.DryRun,
+	})
+	if err != nil {
+		return err
+	} else if len(r.Errors) != 0 {
+		for _, e := range r.Errors {
+			fmt.Fprintf(os.Stderr, "Error, %s\n", e)
+		}
+		return fmt.Errorf("driver reconcile failed: %v", r.Errors)
+	}
+
+	if len(r.Changes) == 0 {
+		fmt.Println("The installed drivers match the drivers configuration")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Language", "Action", "Image", "Version", "Error"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	var failed int
+	for _, ch := range r.Changes {
+		if ch.Error != "" {
+			failed++
+		}
+		table.Append([]string{ch.Language, string(ch.Action), ch.Reference, ch.Version, ch.Error})
+	}
+
+	table.Render()
+	if failed != 0 {
+		return fmt.Errorf("%d of %d changes failed", failed, len(r.Changes))
+	}
+	return nil
+}
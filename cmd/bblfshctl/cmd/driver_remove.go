@@ -10,12 +10,15 @@ import (
 
 const (
 	DriverRemoveCommandDescription = "Removes the driver for the specified language"
-	DriverRemoveCommandHelp        = DriverRemoveCommandDescription
+	DriverRemoveCommandHelp        = DriverRemoveCommandDescription + "\n\n" +
+		"All the installed versions of the driver are removed, unless a \n" +
+		"`version` is given."
 )
 
 type DriverRemoveCommand struct {
 	Args struct {
 		Language string `positional-arg-name:"language" description:"language supported by the driver"`
+		Version  string `positional-arg-name:"version" description:"version of the driver to remove (optional)"`
 	} `positional-args:"yes"`
 
 	All bool `long:"all" description:"removes all the installed drivers"`
@@ -40,14 +43,21 @@ func (c *DriverRemoveCommand) Execute(args []string) error {
 			return err
 		}
 
-		langs = make([]string, len(r.State))
-		for i, s := range r.State {
-			langs[i] = s.Language
+		langs = langs[:0]
+		seen := make(map[string]bool, len(r.State))
+		for _, s := range r.State {
+			if !seen[s.Language] {
+				seen[s.Language] = true
+				langs = append(langs, s.Language)
+			}
 		}
 	}
 
 	for _, lang := range langs {
-		r, err := c.srv.RemoveDriver(ctx, &protocol.RemoveDriverRequest{Language: lang})
+		r, err := c.srv.RemoveDriver(ctx, &protocol.RemoveDriverRequest{
+			Language: lang,
+			Version:  c.Args.Version,
+		})
 		if err != nil {
 			return err
 		} else if len(r.Errors) != 0 {
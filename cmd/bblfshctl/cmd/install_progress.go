@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/briandowns/spinner"
+	"github.com/docker/go-units"
+)
+
+// progressBarWidth is the number of characters of the progress bars, without
+// the brackets.
+const progressBarWidth = 30
+
+// spinnerFrames are the frames of the spinner shown by the installations
+// without a known size, like the ones resolving or validating a driver.
+var spinnerFrames = spinner.CharSets[9]
+
+// installState is the progress of the installation of a driver, built from
+// the events sent by the daemon.
+type installState struct {
+	ref    driverRef
+	stage  protocol.InstallStage
+	layers map[string]*protocol.InstallProgress
+	err    error
+	done   bool
+}
+
+func newInstallState(ref driverRef) *installState {
+	return &installState{ref: ref, layers: make(map[string]*protocol.InstallProgress)}
+}
+
+// update applies an event of the installation to the state.
+func (s *installState) update(p *protocol.InstallProgress) {
+	s.stage = p.Stage
+	if p.Stage == protocol.InstallDownloading && p.Layer != "" {
+		s.layers[p.Layer] = p
+	}
+}
+
+// size returns the bytes downloaded of the layers, and the size of all of
+// them, or -1 if it's not known.
+func (s *installState) size() (current, total int64) {
+	for _, l := range s.layers {
+		current += l.Current
+		if l.Total < 0 || total < 0 {
+			total = -1
+		} else {
+			total += l.Total
+		}
+	}
+
+	return current, total
+}
+
+// render writes a line with the state of the installation. frame is the frame
+// of the spinner for the stages without progress.
+func (s *installState) render(w io.Writer, frame int) {
+	var status string
+	switch {
+	case s.done && s.err != nil:
+		status = "ERR"
+	case s.done:
+		status = " + "
+	case s.stage == "":
+		status = "   "
+	default:
+		status = " " + spinnerFrames[frame%len(spinnerFrames)] + " "
+	}
+
+	fmt.Fprintf(w, "%12s %3s %-11s %s %s\n", s.ref.Lang, status, s.stage, s.progress(), s.ref.Ref)
+}
+
+// progress returns the progress bar of the download of the layers.
+func (s *installState) progress() string {
+	current, total := s.size()
+	switch {
+	case len(s.layers) == 0:
+		return strings.Repeat(" ", progressBarWidth+2) + strings.Repeat(" ", 24)
+	case total <= 0:
+		return fmt.Sprintf("[%s] %23s", strings.Repeat("?", progressBarWidth), units.HumanSize(float64(current)))
+	}
+
+	filled := int(int64(progressBarWidth) * current / total)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	return fmt.Sprintf("[%s] %4d%% %8s/%-8s", bar, 100*current/total,
+		units.HumanSize(float64(current)), units.HumanSize(float64(total)))
+}
+
+// installView renders the state of several installations, redrawing the
+// lines written by the previous call.
+type installView struct {
+	states []*installState
+	frame  int
+	lines  int
+}
+
+func newInstallView(refs []driverRef) *installView {
+	v := &installView{states: make([]*installState, 0, len(refs))}
+	for _, ref := range refs {
+		v.states = append(v.states, newInstallState(ref))
+	}
+
+	sort.SliceStable(v.states, func(i, j int) bool {
+		return v.states[i].ref.Lang < v.states[j].ref.Lang
+	})
+
+	return v
+}
+
+// get returns the state of the installation of the given image reference.
+func (v *installView) get(ref string) *installState {
+	for _, s := range v.states {
+		if s.ref.Ref == ref {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// draw writes the header followed by a line per installation, replacing the
+// ones of the previous call.
+func (v *installView) draw(w io.Writer, header string) {
+	if v.lines != 0 {
+		fmt.Fprintf(w, "\033[%dA\033[J", v.lines) // delete previous lines in terminal
+	}
+
+	fmt.Fprintln(w, header)
+	for _, s := range v.states {
+		s.render(w, v.frame)
+	}
+
+	v.frame++
+	v.lines = len(v.states) + 1
+}
@@ -42,7 +42,7 @@ func (c *InstancesCommand) Execute(args []string) error {
 
 func instancesStatusToText(r *protocol.DriverInstanceStatesResponse) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Instance ID", "Driver", "Status", "Created", "PIDs"})
+	table.SetHeader([]string{"Instance ID", "Driver", "Status", "Created", "Health", "PIDs"})
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 
 	for _, s := range r.State {
@@ -51,10 +51,11 @@ func instancesStatusToText(r *protocol.DriverInstanceStatesResponse) {
 			pids = append(pids, fmt.Sprintf("%d", pid))
 		}
 
-		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
 			s.ID[:10], s.Image,
 			s.Status,
 			units.HumanDuration(time.Since(s.Created)),
+			healthToText(s),
 			strings.Join(pids, ","),
 		)
 
@@ -64,3 +65,14 @@ func instancesStatusToText(r *protocol.DriverInstanceStatesResponse) {
 	table.Render()
 	fmt.Printf("Response time %s\n", r.Elapsed)
 }
+
+func healthToText(s *protocol.DriverInstanceState) string {
+	switch {
+	case s.LastHealthCheck.IsZero():
+		return "-"
+	case s.HealthFailures == 0:
+		return "ok"
+	default:
+		return fmt.Sprintf("failing (%d): %s", s.HealthFailures, s.HealthError)
+	}
+}
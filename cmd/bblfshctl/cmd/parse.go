@@ -10,7 +10,10 @@ import (
 
 const (
 	ParseCommandDescription = "Parse a file and prints the UAST or AST"
-	ParseCommandHelp        = ParseCommandDescription
+	ParseCommandHelp        = ParseCommandDescription + "\n\n" +
+		"The language is detected from the file when not given. Using \n" +
+		"`--driver-version` the file is parsed by that version of the \n" +
+		"driver instead of the current one."
 )
 
 type ParseCommand struct {
@@ -18,7 +21,9 @@ type ParseCommand struct {
 		File string `positional-arg-name:"filename" description:"file to parse"`
 	} `positional-args:"yes"`
 
-	Native bool `long:"native" description:"if native, the native AST will be returned"`
+	Native   bool   `long:"native" description:"if native, the native AST will be returned"`
+	Language string `short:"l" long:"language" description:"language of the file"`
+	Version  string `long:"driver-version" description:"version of the driver to use"`
 	UserCommand
 }
 
@@ -40,6 +45,11 @@ func (c *ParseCommand) Execute(args []string) error {
 	if c.Native {
 		req = req.Mode(bblfsh.Native)
 	}
+	if c.Version != "" {
+		req = req.Language(c.Language + "@" + c.Version)
+	} else if c.Language != "" {
+		req = req.Language(c.Language)
+	}
 
 	ast, _, err := req.UAST()
 	if err != nil {
@@ -15,7 +15,9 @@ const (
 	StatusCommandDescription = "List all the pools of driver instances running"
 	StatusCommandHelp        = StatusCommandDescription + "\n\n" +
 		"The drivers are started on-demand based on the load of the server, \n" +
-		"this driver instances are organized in pools by language.\n\n" +
+		"this driver instances are organized in pools by language. Pools \n" +
+		"running a version of the driver other than the current one are \n" +
+		"listed as `language@version`.\n\n" +
 		"This command prints a list of the pools running on the daemon, with \n" +
 		"the number of requests success and failed, the number of instances \n" +
 		"current and desired, the number of request waiting to be handle and \n" +
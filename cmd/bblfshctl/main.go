@@ -31,6 +31,11 @@ func main() {
 		&cmd.ParseCommand{},
 	)
 
+	parser.AddCommand("audit",
+		cmd.AuditCommandDescription, cmd.AuditCommandHelp,
+		&cmd.AuditCommand{},
+	)
+
 	c, _ := parser.AddCommand("driver",
 		cmd.DriverCommandDescription, cmd.DriverCommandHelp,
 		// go-flags won't propagate DriverCommand flags to sub-commands,
@@ -54,6 +59,31 @@ func main() {
 		&cmd.DriverRemoveCommand{},
 	)
 
+	c.AddCommand("current",
+		cmd.DriverCurrentCommandDescription, cmd.DriverCurrentCommandHelp,
+		&cmd.DriverCurrentCommand{},
+	)
+
+	c.AddCommand("export",
+		cmd.DriverExportCommandDescription, cmd.DriverExportCommandHelp,
+		&cmd.DriverExportCommand{},
+	)
+
+	c.AddCommand("import",
+		cmd.DriverImportCommandDescription, cmd.DriverImportCommandHelp,
+		&cmd.DriverImportCommand{},
+	)
+
+	c.AddCommand("gc",
+		cmd.DriverGCCommandDescription, cmd.DriverGCCommandHelp,
+		&cmd.DriverGCCommand{},
+	)
+
+	c.AddCommand("reconcile",
+		cmd.DriverReconcileCommandDescription, cmd.DriverReconcileCommandHelp,
+		&cmd.DriverReconcileCommand{},
+	)
+
 	if _, err := parser.Parse(); err != nil {
 		if flagsErr, ok := err.(*flags.Error); ok && flagsErr.Type == flags.ErrHelp {
 			os.Exit(0)
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	pversion "github.com/prometheus/common/version"
 	jaegercfg "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -42,11 +45,29 @@ var (
 	transport      *string
 	maxMessageSize *int
 
+	registryAuth struct {
+		authFile *string
+		helper   *string
+	}
+
+	imagePolicy struct {
+		requireDigest *bool
+		policy        *string
+		keyring       *string
+	}
+
 	ctl struct {
 		network *string
 		address *string
 	}
 
+	tlsCfg struct {
+		cert          *string
+		key           *string
+		clientCA      *string
+		ctlClientCert *bool
+	}
+
 	logcfg struct {
 		level  *string
 		format *string
@@ -60,7 +81,26 @@ var (
 	metrics struct {
 		address *string
 	}
-	cmd *flag.FlagSet
+	parseCache struct {
+		enabled  *bool
+		size     *int
+		diskSize *int
+	}
+	auditLog struct {
+		path    *string
+		maxSize *int
+		backups *int
+	}
+	scalingConfig   *string
+	resourcesConfig *string
+	updateConfig    *string
+	driversConfig   *string
+	rateLimits      *string
+	ctlPolicy       *string
+	prewarm         *string
+	gracePeriod     *time.Duration
+	statsTrailer    *bool
+	cmd             *flag.FlagSet
 
 	usrListener net.Listener
 	ctlListener net.Listener
@@ -75,12 +115,22 @@ func init() {
 	network = cmd.String("network", "tcp", "network type: tcp, tcp4, tcp6, unix or unixpacket.")
 	address = cmd.String("address", "0.0.0.0:9432", "address to listen.")
 	storage = cmd.String("storage", "/var/lib/bblfshd", "path where all the runtime information is stored.")
-	transport = cmd.String("transport", "docker", "default transport to fetch driver images: docker or docker-daemon)")
+	transport = cmd.String("transport", "docker", "default transport to fetch driver images: docker, docker-daemon, docker-archive, oci or dir")
 	maxMessageSize = cmdutil.FlagMaxGRPCMsgSizeMB(cmd)
+	registryAuth.authFile = cmd.String("registry-auth-file", "", "docker config.json or containers auth.json file with the credentials of the image registries.")
+	registryAuth.helper = cmd.String("registry-credential-helper", "", "docker credential helper program printing the credentials of the image registries.")
+	imagePolicy.requireDigest = cmd.Bool("require-digest", false, "only install driver images referenced by the digest of their manifest.")
+	imagePolicy.policy = cmd.String("image-policy", "", "containers-policy.json file the signatures of the driver images are checked against.")
+	imagePolicy.keyring = cmd.String("trusted-keyring", "", "GPG keyring with the keys trusted to sign driver images; ignored if --image-policy is set.")
 
 	ctl.network = cmd.String("ctl-network", "unix", "control server network type: tcp, tcp4, tcp6, unix or unixpacket.")
 	ctl.address = cmd.String("ctl-address", "/var/run/bblfshctl.sock", "control server address to listen.")
 
+	tlsCfg.cert = cmd.String("tls-cert", "", "TLS certificate of the user and control servers, which serve TLS if set; reloaded when the file changes.")
+	tlsCfg.key = cmd.String("tls-key", "", "private key of the TLS certificate; reloaded when the file changes.")
+	tlsCfg.clientCA = cmd.String("tls-client-ca", "", "CA certificates the client certificates are verified against; reloaded when the file changes.")
+	tlsCfg.ctlClientCert = cmd.Bool("ctl-require-client-cert", false, "require a client certificate verified by --tls-client-ca to connect to the control server.")
+
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
 		logLevel = "info"
@@ -92,6 +142,21 @@ func init() {
 	pprof.enabled = cmd.Bool("profiler", false, "run profiler http endpoint (pprof).")
 	pprof.address = cmd.String("profiler-address", ":6060", "profiler address to listen on.")
 	metrics.address = cmd.String("metrics-address", ":2112", "metrics address to listen on.")
+	parseCache.enabled = cmd.Bool("cache", false, "cache the parse responses in memory and in the storage path.")
+	parseCache.size = cmd.Int("cache-size", 256, "maximal size of the parse responses cached in memory, in MB.")
+	parseCache.diskSize = cmd.Int("cache-disk-size", 4096, "maximal size of the parse responses cached on disk, in MB; 0 disables the disk cache.")
+	auditLog.path = cmd.String("audit-log", "", "file of the audit log of the control requests, audit.log in the storage path by default.")
+	auditLog.maxSize = cmd.Int("audit-log-max-size", 100, "size at which the audit log is rotated, in MB.")
+	auditLog.backups = cmd.Int("audit-log-backups", 5, "number of rotated audit log files kept.")
+	scalingConfig = cmd.String("scaling-config", "", "YAML file with the scaling policies of the drivers of each language; reloaded on SIGHUP.")
+	resourcesConfig = cmd.String("resources-config", "", "YAML file with the resource limits of the driver containers of each language; reloaded on SIGHUP.")
+	updateConfig = cmd.String("update-config", "", "YAML file with the update policies of the installed drivers, checked in the background if set; reloaded on SIGHUP.")
+	driversConfig = cmd.String("drivers-config", "", "YAML file with the image reference of the driver of each language, installed at boot; reloaded on SIGHUP.")
+	rateLimits = cmd.String("rate-limits-config", "", "YAML file with the rate limits of the clients of the user API, globally and per language; reloaded on SIGHUP.")
+	ctlPolicy = cmd.String("ctl-policy-config", "", "YAML file with the control RPCs allowed to each user, group or client certificate; reloaded on SIGHUP.")
+	prewarm = cmd.String("prewarm", "", "start the drivers at boot: all, or a comma-separated list of languages.")
+	statsTrailer = cmd.Bool("stats-trailer", false, "send the queue, spawn and driver times of each parse request, and its container, in the gRPC trailer.")
+	gracePeriod = cmd.Duration("shutdown-grace-period", 30*time.Second, "time to wait for the requests in flight when stopping.")
 	cmd.Parse(os.Args[1:])
 
 	buildLogger()
@@ -116,7 +181,7 @@ func installRecommended(d *daemon.Daemon) error {
 		}
 		image := driverImage(dr.Language)
 		log.Infof("installing driver for %s (%s)", dr.Language, image)
-		err = d.InstallDriver(dr.Language, image, false)
+		err = d.InstallDriver(dr.Language, image, false, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -135,14 +200,6 @@ func main() {
 			}
 		}()
 	}
-	if *metrics.address != "" {
-		log.Infof("running metrics on %s", *metrics.address)
-		go func() {
-			if err := http.ListenAndServe(*metrics.address, promhttp.Handler()); err != nil {
-				log.Errorf(err, "cannot start metrics")
-			}
-		}()
-	}
 
 	if os.Getenv("JAEGER_AGENT_HOST") != "" {
 		c, err := jaegercfg.FromEnv()
@@ -176,7 +233,82 @@ func main() {
 			os.Exit(1)
 		}
 	}
-	d := daemon.NewDaemon(version, parsedBuild, r, grpcOpts...)
+	limiter := daemon.NewRateLimiter()
+	if *rateLimits != "" {
+		log.Infof("loading rate limits configuration from %s", *rateLimits)
+		if err := limiter.LoadConfig(*rateLimits); err != nil {
+			log.Errorf(err, "error loading rate limits configuration")
+			os.Exit(1)
+		}
+		grpcOpts = append(grpcOpts, limiter.ServerOptions()...)
+	}
+	authz := daemon.NewAuthorizer()
+	if *ctlPolicy != "" {
+		log.Infof("loading control policy from %s", *ctlPolicy)
+		if err := authz.LoadConfig(*ctlPolicy); err != nil {
+			log.Errorf(err, "error loading control policy")
+			os.Exit(1)
+		}
+	}
+	usrTLS, ctlOpts := buildTLS()
+	grpcOpts = append(grpcOpts, usrTLS...)
+	if *ctl.network == "unix" {
+		// identify the users of the control socket
+		ctlOpts = append(ctlOpts, grpc.Creds(daemon.PeerCredentials()))
+	}
+	ctlOpts = append(ctlOpts, authz.ServerOptions()...)
+	d := daemon.NewDaemonWithOptions(version, parsedBuild, r, grpcOpts, ctlOpts)
+	if *prewarm != "" || *driversConfig != "" {
+		// not ready until the drivers are installed and running
+		d.SetReady(false)
+	}
+	if *parseCache.enabled {
+		d.Cache = buildCache()
+	}
+	d.StatsTrailer = *statsTrailer
+	d.Audit = buildAuditLog()
+	authz.AuditTo(d)
+	if *scalingConfig != "" {
+		log.Infof("loading scaling configuration from %s", *scalingConfig)
+		if err := d.LoadScalingConfig(*scalingConfig); err != nil {
+			log.Errorf(err, "error loading scaling configuration")
+			os.Exit(1)
+		}
+	}
+	if *resourcesConfig != "" {
+		log.Infof("loading resources configuration from %s", *resourcesConfig)
+		if err := d.LoadResourcesConfig(*resourcesConfig); err != nil {
+			log.Errorf(err, "error loading resources configuration")
+			os.Exit(1)
+		}
+	}
+	if *updateConfig != "" {
+		log.Infof("loading update configuration from %s", *updateConfig)
+		if err := d.LoadUpdateConfig(*updateConfig); err != nil {
+			log.Errorf(err, "error loading update configuration")
+			os.Exit(1)
+		}
+	}
+	if *driversConfig != "" {
+		log.Infof("loading drivers configuration from %s", *driversConfig)
+		if err := d.LoadDriversConfig(*driversConfig); err != nil {
+			log.Errorf(err, "error loading drivers configuration")
+			os.Exit(1)
+		}
+	}
+	if *metrics.address != "" {
+		log.Infof("running metrics on %s", *metrics.address)
+		go func() {
+			health := d.HealthHandler()
+			mux := http.NewServeMux()
+			mux.Handle("/", promhttp.Handler())
+			mux.Handle("/healthz", health)
+			mux.Handle("/readyz", health)
+			if err := http.ListenAndServe(*metrics.address, mux); err != nil {
+				log.Errorf(err, "cannot start metrics")
+			}
+		}()
+	}
 	if args := cmd.Args(); len(args) == 2 && args[0] == "install" && args[1] == "recommended" {
 		err := installRecommended(d)
 		if err != nil {
@@ -186,6 +318,13 @@ func main() {
 		return
 	}
 
+	if *prewarm != "" || *driversConfig != "" {
+		go startDrivers(d)
+	}
+	if *updateConfig != "" {
+		go d.WatchUpdates(context.Background())
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 	go func() {
@@ -197,9 +336,57 @@ func main() {
 		listenControl(d)
 	}()
 	handleGracefullyShutdown(d)
+	handleReload(d, limiter, authz)
 	wg.Wait()
 }
 
+// startDrivers installs the drivers of the drivers configuration and prewarms
+// them, and sets the daemon as ready afterwards.
+func startDrivers(d *daemon.Daemon) {
+	if *driversConfig != "" {
+		reconcileDrivers(d)
+	}
+	if *prewarm != "" {
+		prewarmDrivers(d)
+	}
+	d.SetReady(true)
+}
+
+func reconcileDrivers(d *daemon.Daemon) {
+	log.Infof("reconciling drivers with %s", *driversConfig)
+	changes, err := d.ReconcileDrivers(context.Background(), false)
+	if err != nil {
+		log.Errorf(err, "error reconciling drivers")
+		return
+	}
+
+	var failed int
+	for _, ch := range changes {
+		if ch.Error != "" {
+			failed++
+		}
+	}
+	log.Infof("drivers reconciled: %d changes, %d failed", len(changes), failed)
+}
+
+func prewarmDrivers(d *daemon.Daemon) {
+	var languages []string
+	if *prewarm != "all" {
+		for _, lang := range strings.Split(*prewarm, ",") {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				languages = append(languages, lang)
+			}
+		}
+	}
+
+	log.Infof("prewarming drivers: %s", *prewarm)
+	if err := d.Prewarm(context.Background(), languages); err != nil {
+		log.Errorf(err, "error prewarming drivers")
+	} else {
+		log.Infof("drivers prewarmed")
+	}
+}
+
 func listenUser(d *daemon.Daemon) {
 	var err error
 	usrListener, err = net.Listen(*network, *address)
@@ -267,6 +454,25 @@ func buildRuntime() *runtime.Runtime {
 	log.Infof("initializing runtime at %s", *storage)
 
 	r := runtime.NewRuntime(*storage)
+	r.ImagePolicy = &runtime.ImagePolicy{
+		RequireDigest: *imagePolicy.requireDigest,
+		PolicyPath:    *imagePolicy.policy,
+		Keyring:       *imagePolicy.keyring,
+	}
+	if err := r.ImagePolicy.Validate(); err != nil {
+		log.Errorf(err, "error loading image policy")
+		os.Exit(1)
+	}
+
+	r.RegistryAuth = &runtime.RegistryAuth{
+		AuthFile: *registryAuth.authFile,
+		Helper:   *registryAuth.helper,
+	}
+	if err := r.RegistryAuth.Validate(); err != nil {
+		log.Errorf(err, "error loading registry credentials")
+		os.Exit(1)
+	}
+
 	if err := r.Init(); err != nil {
 		log.Errorf(err, "error initializing runtime")
 		os.Exit(1)
@@ -275,24 +481,151 @@ func buildRuntime() *runtime.Runtime {
 	return r
 }
 
+// buildTLS returns the options serving TLS on the user and control servers,
+// if a certificate is set. The control server doesn't use TLS on a unix socket.
+func buildTLS() (usrOpts, ctlOpts []grpc.ServerOption) {
+	if *tlsCfg.cert == "" && *tlsCfg.key == "" {
+		if *tlsCfg.clientCA != "" || *tlsCfg.ctlClientCert {
+			log.Errorf(daemon.ErrInvalidTLSConfig.New("client certificates require --tls-cert and --tls-key"),
+				"error loading TLS certificates")
+			os.Exit(1)
+		}
+		return nil, nil
+	}
+
+	files := daemon.TLSFiles{Cert: *tlsCfg.cert, Key: *tlsCfg.key, ClientCA: *tlsCfg.clientCA}
+	certs, err := daemon.NewCertReloader(files, false)
+	if err != nil {
+		log.Errorf(err, "error loading TLS certificates")
+		os.Exit(1)
+	}
+	usrOpts = []grpc.ServerOption{certs.ServerOption()}
+
+	if *ctl.network == "unix" {
+		if *tlsCfg.ctlClientCert {
+			log.Errorf(daemon.ErrInvalidTLSConfig.New("client certificates cannot be required on a unix socket"),
+				"error loading TLS certificates")
+			os.Exit(1)
+		}
+		log.Infof("control server listening on a unix socket, without TLS")
+		return usrOpts, nil
+	}
+	if *tlsCfg.ctlClientCert {
+		certs, err = daemon.NewCertReloader(files, true)
+		if err != nil {
+			log.Errorf(err, "error loading TLS certificates")
+			os.Exit(1)
+		}
+	}
+	return usrOpts, []grpc.ServerOption{certs.ServerOption()}
+}
+
+func buildCache() *daemon.ParseCache {
+	const mb = 1024 * 1024
+	path := filepath.Join(*storage, "cache")
+	log.Infof("initializing parse cache at %s", path)
+
+	c, err := daemon.NewParseCache(path, int64(*parseCache.size)*mb, int64(*parseCache.diskSize)*mb)
+	if err != nil {
+		log.Errorf(err, "error initializing parse cache")
+		os.Exit(1)
+	}
+
+	return c
+}
+
+func buildAuditLog() *daemon.AuditLog {
+	const mb = 1024 * 1024
+	path := *auditLog.path
+	if path == "" {
+		path = filepath.Join(*storage, "audit.log")
+	}
+	log.Infof("writing audit log to %s", path)
+
+	l, err := daemon.OpenAuditLog(path, int64(*auditLog.maxSize)*mb, *auditLog.backups)
+	if err != nil {
+		log.Errorf(err, "error opening audit log")
+		os.Exit(1)
+	}
+
+	return l
+}
+
 func handleGracefullyShutdown(d *daemon.Daemon) {
-	var gracefulStop = make(chan os.Signal)
+	var gracefulStop = make(chan os.Signal, 1)
 	signal.Notify(gracefulStop, syscall.SIGTERM)
 	signal.Notify(gracefulStop, syscall.SIGINT)
 	go waitForStop(gracefulStop, d)
 }
 
+func handleReload(d *daemon.Daemon, limiter *daemon.RateLimiter, authz *daemon.Authorizer) {
+	if *scalingConfig == "" && *resourcesConfig == "" && *updateConfig == "" && *driversConfig == "" &&
+		*rateLimits == "" && *ctlPolicy == "" {
+		return
+	}
+	var reload = make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go waitForReload(reload, d, limiter, authz)
+}
+
+func waitForReload(ch <-chan os.Signal, d *daemon.Daemon, limiter *daemon.RateLimiter, authz *daemon.Authorizer) {
+	for sig := range ch {
+		log.Infof("signal received %+v, reloading configuration", sig)
+		if *scalingConfig != "" {
+			if err := d.ReloadScalingConfig(); err != nil {
+				log.Errorf(err, "error reloading scaling configuration")
+			}
+		}
+		if *resourcesConfig != "" {
+			if err := d.ReloadResourcesConfig(); err != nil {
+				log.Errorf(err, "error reloading resources configuration")
+			}
+		}
+		if *updateConfig != "" {
+			if err := d.ReloadUpdateConfig(); err != nil {
+				log.Errorf(err, "error reloading update configuration")
+			}
+		}
+		if *rateLimits != "" {
+			if err := limiter.ReloadConfig(); err != nil {
+				log.Errorf(err, "error reloading rate limits configuration")
+			}
+		}
+		if *ctlPolicy != "" {
+			if err := authz.ReloadConfig(); err != nil {
+				log.Errorf(err, "error reloading control policy")
+			}
+		}
+		if *driversConfig != "" {
+			if err := d.ReloadDriversConfig(); err != nil {
+				log.Errorf(err, "error reloading drivers configuration")
+			} else {
+				reconcileDrivers(d)
+			}
+		}
+	}
+}
+
 func waitForStop(ch <-chan os.Signal, d *daemon.Daemon) {
 	sig := <-ch
 	log.Warningf("signal received %+v", sig)
-	log.Warningf("stopping server")
-	if err := d.Stop(); err != nil {
+	log.Warningf("stopping server, waiting up to %v for the requests in flight", *gracePeriod)
+
+	go func() {
+		sig := <-ch
+		log.Warningf("signal received %+v, exiting now", sig)
+		os.Exit(1)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *gracePeriod)
+	defer cancel()
+	if err := d.Shutdown(ctx); err != nil {
 		log.Errorf(err, "error stopping server")
 	}
 
-	for _, l := range []net.Listener{ctlListener, usrListener} {
-		if err := l.Close(); err != nil {
-			log.Errorf(err, "error closing listener")
+	if *ctl.network == "unix" {
+		if err := os.Remove(*ctl.address); err != nil && !os.IsNotExist(err) {
+			log.Errorf(err, "error removing control socket")
 		}
 	}
 
@@ -0,0 +1,196 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+)
+
+// DefaultAuditLogLimit is the number of entries returned when querying the
+// audit log without a limit.
+const DefaultAuditLogLimit = 100
+
+// AuditLog is an append-only log of the control requests, written as JSON
+// lines. The file is rotated when it grows over its maximal size, keeping a
+// number of previous files, named like the log with the suffixes .1, .2 and
+// so on, .1 being the most recent.
+type AuditLog struct {
+	path    string
+	maxSize int64
+	backups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// OpenAuditLog opens the audit log at path, creating it if it doesn't exist.
+// The log is rotated once it's larger than maxSize bytes, and the given number
+// of rotated files are kept.
+func OpenAuditLog(path string, maxSize int64, backups int) (*AuditLog, error) {
+	l := &AuditLog{path: path, maxSize: maxSize, backups: backups}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// open opens the current file of the log. Must be called with the lock held,
+// or before the log is shared.
+func (l *AuditLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	size := fi.Size()
+
+	// a line truncated by a crash is ended, so it doesn't corrupt the next
+	// entry
+	if size > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, size-1); err != nil {
+			f.Close()
+			return err
+		}
+		if last[0] != '\n' {
+			if _, err := f.Write([]byte{'\n'}); err != nil {
+				f.Close()
+				return err
+			}
+			size++
+		}
+	}
+
+	l.f, l.size = f, size
+	return nil
+}
+
+// Write appends an entry to the log, rotating it first if it's full.
+func (l *AuditLog) Write(e *protocol.AuditEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return os.ErrClosed
+	}
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(data)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.f.Write(data)
+	l.size += int64(n)
+	return err
+}
+
+// rotated returns the path of the i-th rotated file, or of the current file
+// if i is zero.
+func (l *AuditLog) rotated(i int) string {
+	if i == 0 {
+		return l.path
+	}
+	return fmt.Sprintf("%s.%d", l.path, i)
+}
+
+// rotate moves the current file to the first rotated one, shifting the
+// previous ones and dropping the oldest. Must be called with the lock held.
+func (l *AuditLog) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	l.f = nil
+
+	if l.backups < 1 {
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return l.open()
+	}
+	for i := l.backups; i > 0; i-- {
+		err := os.Rename(l.rotated(i-1), l.rotated(i))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return l.open()
+}
+
+// Recent returns the most recent entries for which match returns true, from
+// the oldest to the most recent. At most limit entries are returned, or
+// DefaultAuditLogLimit if it's zero. A nil match returns all the entries.
+func (l *AuditLog) Recent(limit int, match func(*protocol.AuditEntry) bool) ([]*protocol.AuditEntry, error) {
+	if limit <= 0 {
+		limit = DefaultAuditLogLimit
+	}
+
+	// the files are read while holding the lock, so they are not rotated
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []*protocol.AuditEntry
+	for i := 0; i <= l.backups && len(out) < limit; i++ {
+		entries, err := readAuditLog(l.rotated(i), match)
+		if os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(entries, out...)
+	}
+
+	if len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out, nil
+}
+
+// readAuditLog reads the entries of a file of the log matching the filter.
+func readAuditLog(path string, match func(*protocol.AuditEntry) bool) ([]*protocol.AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []*protocol.AuditEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		e := &protocol.AuditEntry{}
+		if err := json.Unmarshal(sc.Bytes(), e); err != nil {
+			// a line truncated by a crash doesn't hide the rest of the log
+			continue
+		}
+		if match == nil || match(e) {
+			out = append(out, e)
+		}
+	}
+	return out, sc.Err()
+}
+
+// Close closes the log. Entries can't be written after it's closed.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
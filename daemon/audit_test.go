@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func auditEntry(i int) *protocol.AuditEntry {
+	return &protocol.AuditEntry{
+		Time:     time.Date(2019, 1, 1, 0, 0, i, 0, time.UTC),
+		Caller:   "uid=0,gid=0,pid=42",
+		RPC:      "InstallDriver",
+		Language: fmt.Sprintf("lang%d", i%2),
+		Outcome:  protocol.AuditSucceeded,
+		Elapsed:  time.Second,
+	}
+}
+
+func auditSeconds(entries []*protocol.AuditEntry) []int {
+	var out []int
+	for _, e := range entries {
+		out = append(out, e.Time.Second())
+	}
+	return out
+}
+
+func TestAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	l, err := OpenAuditLog(path, 1024, 2)
+	require.NoError(err)
+
+	entries, err := l.Recent(0, nil)
+	require.NoError(err)
+	require.Empty(entries)
+
+	for i := 0; i < 30; i++ {
+		require.NoError(l.Write(auditEntry(i)))
+	}
+
+	// the log is rotated, and the oldest entries are dropped
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		fi, err := os.Stat(p)
+		require.NoError(err)
+		require.True(fi.Size() <= 1024, "%s: %d", p, fi.Size())
+		require.Equal(os.FileMode(0600), fi.Mode().Perm())
+	}
+	_, err = os.Stat(path + ".3")
+	require.True(os.IsNotExist(err))
+
+	entries, err = l.Recent(0, nil)
+	require.NoError(err)
+	require.True(len(entries) < 30)
+	require.Equal(auditEntry(29), entries[len(entries)-1])
+
+	// the most recent entries are returned across the rotated files
+	entries, err = l.Recent(8, func(e *protocol.AuditEntry) bool {
+		return e.Language == "lang1"
+	})
+	require.NoError(err)
+	require.Equal([]int{15, 17, 19, 21, 23, 25, 27, 29}, auditSeconds(entries))
+
+	// the log is appended to when opened again, and truncated lines are
+	// skipped
+	require.NoError(l.Close())
+	require.Error(l.Write(auditEntry(30)))
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(err)
+	_, err = f.WriteString(`{"time":"2019-01-01T00:00:30Z","cal`)
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	l, err = OpenAuditLog(path, 0, 0)
+	require.NoError(err)
+	defer l.Close()
+	require.NoError(l.Write(auditEntry(31)))
+
+	entries, err = l.Recent(2, nil)
+	require.NoError(err)
+	require.Equal([]int{29, 31}, auditSeconds(entries))
+}
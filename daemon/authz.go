@@ -0,0 +1,503 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-log.v1"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+)
+
+var (
+	// ErrInvalidControlPolicy is returned if the control policy file cannot be
+	// parsed or describes invalid rules.
+	ErrInvalidControlPolicy = errors.NewKind("invalid control policy %s: %s")
+	// ErrNoControlPolicy is returned when reloading the control policy if no
+	// policy file was loaded.
+	ErrNoControlPolicy = errors.NewKind("no control policy file was loaded")
+)
+
+// controlServicePrefix is the prefix of the full names of the methods of the
+// control service.
+const controlServicePrefix = "/github.com.bblfsh.server.daemon.protocol.ProtocolService/"
+
+// healthServicePrefix is the prefix of the full names of the methods of the
+// health service, which are allowed to everyone.
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// readOnlyRPCs are the control RPCs that don't change the state of bblfshd.
+var readOnlyRPCs = []string{
+	"DriverInstanceStates",
+	"DriverPoolStates",
+	"DriverStates",
+}
+
+// adminRPCs are the control RPCs that change the installed drivers or the
+// state of bblfshd, or read the audit log.
+var adminRPCs = []string{
+	"AuditLog",
+	"ExportDriver",
+	"GarbageCollect",
+	"ImportDriver",
+	"InstallDriver",
+	"InstallDriverStream",
+	"ReconcileDrivers",
+	"RemoveDriver",
+	"SetCurrentDriver",
+}
+
+// Role is a set of control RPCs granted to a caller.
+type Role string
+
+const (
+	// RoleNone grants no control RPCs.
+	RoleNone Role = "none"
+	// RoleReadOnly grants the RPCs listing the drivers and their instances.
+	RoleReadOnly Role = "read-only"
+	// RoleAdmin grants all the control RPCs.
+	RoleAdmin Role = "admin"
+)
+
+// rpcs returns the control RPCs granted by the role.
+func (r Role) rpcs() []string {
+	switch r {
+	case RoleReadOnly:
+		return readOnlyRPCs
+	case RoleAdmin:
+		return append(readOnlyRPCs[:len(readOnlyRPCs):len(readOnlyRPCs)], adminRPCs...)
+	}
+	return nil
+}
+
+func (r Role) validate() error {
+	switch r {
+	case "", RoleNone, RoleReadOnly, RoleAdmin:
+		return nil
+	}
+	return fmt.Errorf("unknown role %q", r)
+}
+
+// Caller is the identity of a client of the control server. Clients connected
+// to a unix socket are identified by the credentials of their process, and
+// clients connected with TLS by the subject of their certificate.
+type Caller struct {
+	// UID, GID and PID are the user, primary group and process IDs of the
+	// client, or -1 if it's not connected to a unix socket.
+	UID, GID, PID int
+	// Subject is the subject of the certificate of the client, if any.
+	Subject string
+}
+
+// String implements fmt.Stringer.
+func (c Caller) String() string {
+	switch {
+	case c.UID >= 0:
+		return fmt.Sprintf("uid=%d,gid=%d,pid=%d", c.UID, c.GID, c.PID)
+	case c.Subject != "":
+		return "tls:" + c.Subject
+	}
+	return "unknown"
+}
+
+// callerFrom returns the identity of the client of a gRPC request.
+func callerFrom(ctx context.Context) Caller {
+	c := Caller{UID: -1, GID: -1, PID: -1}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return c
+	}
+	switch info := p.AuthInfo.(type) {
+	case PeerCredInfo:
+		c.UID, c.GID, c.PID = info.UID, info.GID, info.PID
+	case credentials.TLSInfo:
+		if len(info.State.PeerCertificates) != 0 {
+			c.Subject = info.State.PeerCertificates[0].Subject.String()
+		}
+	}
+	return c
+}
+
+// PolicyRule grants control RPCs to the callers matching its identity fields.
+// A rule without identity fields matches no callers.
+type PolicyRule struct {
+	// UID matches the callers with this user ID.
+	UID *int `yaml:"uid"`
+	// GID matches the callers with this primary group ID.
+	GID *int `yaml:"gid"`
+	// Subject matches the callers with a certificate with this subject, like
+	// "CN=ops,O=example".
+	Subject string `yaml:"subject"`
+	// Role is the role granted to the matched callers.
+	Role Role `yaml:"role"`
+	// RPCs are the control RPCs granted to the matched callers, on top of
+	// the ones of the role.
+	RPCs []string `yaml:"rpcs"`
+}
+
+func (r PolicyRule) matches(c Caller) bool {
+	if r.UID == nil && r.GID == nil && r.Subject == "" {
+		return false
+	}
+	return (r.UID == nil || (c.UID >= 0 && *r.UID == c.UID)) &&
+		(r.GID == nil || (c.GID >= 0 && *r.GID == c.GID)) &&
+		(r.Subject == "" || r.Subject == c.Subject)
+}
+
+func (r PolicyRule) allows(rpc string) bool {
+	return contains(r.Role.rpcs(), rpc) || contains(r.RPCs, rpc)
+}
+
+// ControlPolicy is the authorization policy of the control server, loaded from
+// a YAML file:
+//
+//   default: read-only
+//   rules:
+//     - uid: 0
+//       role: admin
+//     - gid: 1001
+//       role: admin
+//     - subject: CN=ci
+//       role: read-only
+//       rpcs: [InstallDriver]
+//
+// The first rule matching the caller grants it its role and RPCs, and the
+// callers not matched by any rule get the default role.
+type ControlPolicy struct {
+	// Default is the role of the callers not matched by any rule. Defaults
+	// to none.
+	Default Role `yaml:"default"`
+	// Rules are the rules granting RPCs to the callers, in order.
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// DefaultControlPolicy returns the policy used if no policy file is set: root
+// and the user running bblfshd are admins, and other callers are read-only.
+func DefaultControlPolicy() *ControlPolicy {
+	root, self := 0, os.Getuid()
+	return &ControlPolicy{
+		Default: RoleReadOnly,
+		Rules: []PolicyRule{
+			{UID: &root, Role: RoleAdmin},
+			{UID: &self, Role: RoleAdmin},
+		},
+	}
+}
+
+// rule returns the rule matching the caller, or a rule with the default role.
+func (p *ControlPolicy) rule(c Caller) PolicyRule {
+	for _, r := range p.Rules {
+		if r.matches(c) {
+			return r
+		}
+	}
+	return PolicyRule{Role: p.Default}
+}
+
+// Allows returns true if the caller is allowed to call the control RPC.
+func (p *ControlPolicy) Allows(c Caller, rpc string) bool {
+	return p.rule(c).allows(rpc)
+}
+
+// LoadControlPolicy reads and validates the control policy file.
+func LoadControlPolicy(path string) (*ControlPolicy, error) {
+	var p ControlPolicy
+	if err := readConfig(path, &p, ErrInvalidControlPolicy); err != nil {
+		return nil, err
+	}
+
+	if err := p.Default.validate(); err != nil {
+		return nil, ErrInvalidControlPolicy.New(path, err)
+	}
+	known := RoleAdmin.rpcs()
+	for i, r := range p.Rules {
+		if r.UID == nil && r.GID == nil && r.Subject == "" {
+			return nil, ErrInvalidControlPolicy.New(path, fmt.Errorf("rule %d: no uid, gid or subject", i))
+		}
+		if err := r.Role.validate(); err != nil {
+			return nil, ErrInvalidControlPolicy.New(path, fmt.Errorf("rule %d: %s", i, err))
+		}
+		for _, rpc := range r.RPCs {
+			if !contains(known, rpc) {
+				return nil, ErrInvalidControlPolicy.New(path, fmt.Errorf("rule %d: unknown RPC %q", i, rpc))
+			}
+		}
+	}
+	return &p, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorizer enforces the control policy on the requests to the control
+// server, and writes them to the audit log. Its interceptors must be passed to
+// the gRPC server.
+type Authorizer struct {
+	mu     sync.Mutex
+	policy *ControlPolicy
+	file   configFile
+	daemon *Daemon
+}
+
+// NewAuthorizer creates a new authorizer with the default policy.
+func NewAuthorizer() *Authorizer {
+	return &Authorizer{policy: DefaultControlPolicy()}
+}
+
+// ServerOptions returns the gRPC server options installing the interceptors of
+// the authorizer.
+func (a *Authorizer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(a.StreamServerInterceptor()),
+	}
+}
+
+// LoadConfig loads the control policy file.
+func (a *Authorizer) LoadConfig(path string) error {
+	p, err := LoadControlPolicy(path)
+	if err != nil {
+		return err
+	}
+	a.SetPolicy(p)
+	a.file.set(path)
+	return nil
+}
+
+// ReloadConfig reads again the control policy file loaded by LoadConfig. The
+// previous policy is kept if the file is not valid.
+func (a *Authorizer) ReloadConfig() error {
+	return a.file.reload(ErrNoControlPolicy, a.LoadConfig)
+}
+
+// SetPolicy sets the control policy. A nil policy restores the default one.
+func (a *Authorizer) SetPolicy(p *ControlPolicy) {
+	if p == nil {
+		p = DefaultControlPolicy()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policy = p
+}
+
+// AuditTo writes the control requests to the audit log of the daemon, with
+// the digests of the images of its installed drivers. Until it's called, the
+// requests changing the drivers are only logged.
+func (a *Authorizer) AuditTo(d *Daemon) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.daemon = d
+}
+
+// UnaryServerInterceptor returns the interceptor authorizing the unary
+// requests.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		e, err := a.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		} else if e == nil {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+		a.audit(e, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor authorizing the streams.
+// The parameters of the requests of a stream are audited as the handler
+// receives them.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		e, err := a.authorize(ss.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		} else if e == nil {
+			return handler(srv, ss)
+		}
+		err = handler(srv, &auditedStream{ServerStream: ss, entry: e})
+		a.audit(e, err)
+		return err
+	}
+}
+
+// auditedStream records the parameters of the request of a stream in its
+// audit entry.
+type auditedStream struct {
+	grpc.ServerStream
+	entry *protocol.AuditEntry
+}
+
+// RecvMsg implements grpc.ServerStream.
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	setAuditRequest(s.entry, m)
+	return nil
+}
+
+// authorize checks if the caller of a request is allowed to call the method.
+// It returns the audit entry of the allowed requests, which must be passed to
+// audit once they complete, or nil for the health checks, which are allowed
+// to everyone and not audited. The denied requests are audited right away.
+func (a *Authorizer) authorize(ctx context.Context, method string, req interface{}) (*protocol.AuditEntry, error) {
+	if strings.HasPrefix(method, healthServicePrefix) {
+		return nil, nil
+	}
+
+	a.mu.Lock()
+	p, d := a.policy, a.daemon
+	a.mu.Unlock()
+
+	caller := callerFrom(ctx)
+	rpc := strings.TrimPrefix(method, controlServicePrefix)
+	e := &protocol.AuditEntry{Time: time.Now(), Caller: caller.String(), RPC: rpc}
+	setAuditRequest(e, req)
+
+	if !strings.HasPrefix(method, controlServicePrefix) || !p.Allows(caller, rpc) {
+		controlDenied.WithLabelValues(rpc).Add(1)
+		err := &ErrPermissionDenied{Caller: caller.String(), RPC: rpc}
+		log.With(log.Fields{"caller": e.Caller, "rpc": rpc}).Warningf("control request denied")
+		a.audit(e, err)
+		return nil, err
+	}
+
+	if rpc == "RemoveDriver" && d != nil {
+		// the image is gone once the request completes
+		e.Digest = d.driverDigest(e.Language, "", e.Version)
+	}
+	return e, nil
+}
+
+// audit writes the entry of a request to the audit log, with its outcome.
+func (a *Authorizer) audit(e *protocol.AuditEntry, err error) {
+	e.Elapsed = time.Since(e.Time)
+	switch err.(type) {
+	case nil:
+		e.Outcome = protocol.AuditSucceeded
+	case *ErrPermissionDenied:
+		e.Outcome = protocol.AuditDenied
+	default:
+		e.Outcome = protocol.AuditFailed
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	a.mu.Lock()
+	d := a.daemon
+	a.mu.Unlock()
+
+	if d == nil || d.Audit == nil {
+		if contains(adminRPCs, e.RPC) && e.Outcome != protocol.AuditDenied {
+			log.With(log.Fields{"caller": e.Caller, "rpc": e.RPC, "language": e.Language,
+				"elapsed": e.Elapsed}).Infof("control request %s", e.Outcome)
+		}
+		return
+	}
+
+	if e.Digest == "" && e.Outcome == protocol.AuditSucceeded && contains(adminRPCs, e.RPC) {
+		e.Digest = d.driverDigest(e.Language, e.Reference, e.Version)
+	}
+	if werr := d.Audit.Write(e); werr != nil {
+		log.Errorf(werr, "error writing audit log")
+	}
+}
+
+// setAuditRequest sets the parameters of a control request in its audit
+// entry. Registry credentials are never audited.
+func setAuditRequest(e *protocol.AuditEntry, req interface{}) {
+	switch r := req.(type) {
+	case *protocol.InstallDriverRequest:
+		e.Language, e.Reference, e.Update = r.Language, r.ImageReference, r.Update
+	case *protocol.RemoveDriverRequest:
+		e.Language, e.Version = r.Language, r.Version
+	case *protocol.SetCurrentDriverRequest:
+		e.Language, e.Version = r.Language, r.Version
+	case *protocol.ExportDriverRequest:
+		e.Language, e.Version = r.Language, r.Version
+	case *protocol.ImportDriverRequest:
+		// the flag is sent in the first message of the stream only
+		e.Update = e.Update || r.Update
+	case *protocol.ReconcileDriversRequest:
+		e.DryRun = r.DryRun
+	}
+}
+
+// PeerCredInfo is the gRPC auth info of the clients connected to a unix
+// socket, with the credentials of their process.
+type PeerCredInfo struct {
+	UID, GID, PID int
+}
+
+// AuthType implements credentials.AuthInfo.
+func (PeerCredInfo) AuthType() string { return "peercred" }
+
+// PeerCredentials returns the gRPC server credentials identifying the clients
+// connected to a unix socket by the credentials of their process, read with
+// SO_PEERCRED. The connections are not encrypted.
+func PeerCredentials() credentials.TransportCredentials {
+	return peerCreds{}
+}
+
+type peerCreds struct{}
+
+// ServerHandshake implements credentials.TransportCredentials.
+func (peerCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil, nil
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cred *syscall.Ucred
+	var cerr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, cerr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, nil, err
+	}
+	if cerr != nil {
+		return nil, nil, cerr
+	}
+	return conn, PeerCredInfo{UID: int(cred.Uid), GID: int(cred.Gid), PID: int(cred.Pid)}, nil
+}
+
+// ClientHandshake implements credentials.TransportCredentials.
+func (peerCreds) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+// Info implements credentials.TransportCredentials.
+func (peerCreds) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "peercred"}
+}
+
+// Clone implements credentials.TransportCredentials.
+func (c peerCreds) Clone() credentials.TransportCredentials { return c }
+
+// OverrideServerName implements credentials.TransportCredentials.
+func (peerCreds) OverrideServerName(string) error { return nil }
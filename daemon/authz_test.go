@@ -0,0 +1,239 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadControlPolicy(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-policy")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	p, err := LoadControlPolicy(writeConfig(t, dir, "policy.yml", `
+rules:
+  - uid: 1000
+    role: admin
+  - gid: 1001
+    role: read-only
+    rpcs: [InstallDriver]
+  - subject: CN=ci
+    role: read-only
+`))
+	require.NoError(err)
+
+	admin := Caller{UID: 1000, GID: 1000, PID: 1}
+	installer := Caller{UID: 1001, GID: 1001, PID: 1}
+	ci := Caller{UID: -1, GID: -1, PID: -1, Subject: "CN=ci"}
+	other := Caller{UID: 1002, GID: 1002, PID: 1}
+
+	require.True(p.Allows(admin, "RemoveDriver"))
+	require.True(p.Allows(installer, "InstallDriver"))
+	require.True(p.Allows(installer, "DriverStates"))
+	require.False(p.Allows(installer, "RemoveDriver"))
+	require.True(p.Allows(ci, "DriverPoolStates"))
+	require.False(p.Allows(ci, "InstallDriver"))
+	require.False(p.Allows(other, "DriverStates"), "default role is none")
+
+	for _, data := range []string{
+		"default: superuser",
+		"rules: [{role: admin}]",
+		"rules: [{uid: 0, role: root}]",
+		"rules: [{uid: 0, rpcs: [Parse]}]",
+		"rules: [{user: root, role: admin}]",
+	} {
+		_, err := LoadControlPolicy(writeConfig(t, dir, "policy.yml", data))
+		require.True(ErrInvalidControlPolicy.Is(err), "%q: %v", data, err)
+	}
+}
+
+func TestDefaultControlPolicy(t *testing.T) {
+	require := require.New(t)
+
+	p := DefaultControlPolicy()
+	require.True(p.Allows(Caller{UID: 0, GID: 0}, "InstallDriver"))
+	require.True(p.Allows(Caller{UID: os.Getuid(), GID: 0}, "InstallDriver"))
+
+	nobody := Caller{UID: 65534, GID: 65534}
+	require.True(p.Allows(nobody, "DriverStates"))
+	require.False(p.Allows(nobody, "InstallDriver"))
+	require.False(p.Allows(Caller{UID: -1, GID: -1}, "InstallDriver"))
+}
+
+func TestAuthorizerInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAuthorizer()
+	intercept := a.UnaryServerInterceptor()
+	var called int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called++
+		return "ok", nil
+	}
+	info := func(rpc string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: controlServicePrefix + rpc}
+	}
+
+	root := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: PeerCredInfo{UID: 0, GID: 0, PID: 42},
+	})
+	req := &protocol.InstallDriverRequest{Language: "go", ImageReference: "docker://bblfsh/go-driver"}
+	resp, err := intercept(root, req, info("InstallDriver"), handler)
+	require.NoError(err)
+	require.Equal("ok", resp)
+
+	nobody := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: PeerCredInfo{UID: 65534, GID: 65534, PID: 43},
+	})
+	_, err = intercept(nobody, req, info("InstallDriver"), handler)
+	require.Equal(codes.PermissionDenied, status.Code(err))
+	require.Equal(&ErrPermissionDenied{Caller: "uid=65534,gid=65534,pid=43", RPC: "InstallDriver"}, err)
+
+	_, err = intercept(nobody, &protocol.DriverStatesRequest{}, info("DriverStates"), handler)
+	require.NoError(err)
+
+	// the health checks are allowed to everyone
+	a.SetPolicy(&ControlPolicy{Default: RoleNone})
+	_, err = intercept(nobody, nil, &grpc.UnaryServerInfo{FullMethod: healthServicePrefix + "Check"}, handler)
+	require.NoError(err)
+	_, err = intercept(root, req, info("InstallDriver"), handler)
+	require.Error(err)
+	require.Equal(3, called)
+}
+
+// testServerStream is a server stream receiving a single request.
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *protocol.InstallDriverRequest
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	*m.(*protocol.InstallDriverRequest) = *s.req
+	return nil
+}
+
+func TestAuthorizerAudit(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	l, err := OpenAuditLog(filepath.Join(dir, "audit.log"), 0, 0)
+	require.NoError(err)
+	defer l.Close()
+
+	a := NewAuthorizer()
+	a.AuditTo(&Daemon{Audit: l})
+
+	root := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: PeerCredInfo{UID: 0, GID: 0, PID: 42},
+	})
+	nobody := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: PeerCredInfo{UID: 65534, GID: 65534, PID: 43},
+	})
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	failed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("no space left on device")
+	}
+	info := func(rpc string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: controlServicePrefix + rpc}
+	}
+
+	intercept := a.UnaryServerInterceptor()
+	_, err = intercept(root, &protocol.ReconcileDriversRequest{DryRun: true}, info("ReconcileDrivers"), ok)
+	require.NoError(err)
+	_, err = intercept(root, &protocol.GarbageCollectRequest{}, info("GarbageCollect"), failed)
+	require.Error(err)
+	_, err = intercept(nobody, &protocol.DriverStatesRequest{}, info("DriverStates"), ok)
+	require.NoError(err)
+	_, err = intercept(nobody, &protocol.RemoveDriverRequest{Language: "go", Version: "v2.7.0"}, info("RemoveDriver"), ok)
+	require.Error(err)
+	_, err = intercept(nobody, nil, &grpc.UnaryServerInfo{FullMethod: healthServicePrefix + "Check"}, ok)
+	require.NoError(err)
+
+	// the request of a stream is audited once received
+	stream := a.StreamServerInterceptor()
+	ss := &testServerStream{ctx: root, req: &protocol.InstallDriverRequest{
+		Language:       "go",
+		ImageReference: "docker://bblfsh/go-driver",
+		Password:       "secret",
+	}}
+	err = stream(nil, ss, &grpc.StreamServerInfo{FullMethod: controlServicePrefix + "InstallDriverStream"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			var req protocol.InstallDriverRequest
+			if err := ss.RecvMsg(&req); err != nil {
+				return err
+			}
+			return fmt.Errorf("image not found")
+		})
+	require.Error(err)
+
+	entries, err := l.Recent(0, nil)
+	require.NoError(err)
+	require.Len(entries, 5)
+
+	for i, exp := range []protocol.AuditEntry{
+		{Caller: "uid=0,gid=0,pid=42", RPC: "ReconcileDrivers", DryRun: true, Outcome: protocol.AuditSucceeded},
+		{Caller: "uid=0,gid=0,pid=42", RPC: "GarbageCollect", Outcome: protocol.AuditFailed, Error: "no space left on device"},
+		{Caller: "uid=65534,gid=65534,pid=43", RPC: "DriverStates", Outcome: protocol.AuditSucceeded},
+		{Caller: "uid=65534,gid=65534,pid=43", RPC: "RemoveDriver", Language: "go", Version: "v2.7.0",
+			Outcome: protocol.AuditDenied, Error: "caller uid=65534,gid=65534,pid=43 is not allowed to call RemoveDriver"},
+		{Caller: "uid=0,gid=0,pid=42", RPC: "InstallDriverStream", Language: "go", Reference: "docker://bblfsh/go-driver",
+			Outcome: protocol.AuditFailed, Error: "image not found"},
+	} {
+		e := *entries[i]
+		require.False(e.Time.IsZero())
+		require.True(e.Elapsed >= 0)
+		e.Time, e.Elapsed = time.Time{}, 0
+		require.Equal(exp, e)
+	}
+}
+
+func TestPeerCredentials(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-peercred")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("unix", filepath.Join(dir, "ctl.sock"))
+	require.NoError(err)
+	defer l.Close()
+
+	go func() {
+		conn, err := net.Dial("unix", l.Addr().String())
+		if err == nil {
+			defer conn.Close()
+			conn.Read(make([]byte, 1))
+		}
+	}()
+
+	conn, err := l.Accept()
+	require.NoError(err)
+	defer conn.Close()
+
+	_, info, err := PeerCredentials().ServerHandshake(conn)
+	require.NoError(err)
+	require.Equal(PeerCredInfo{UID: os.Getuid(), GID: os.Getgid(), PID: os.Getpid()}, info)
+}
@@ -0,0 +1,240 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/src-d/go-log.v1"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+)
+
+var _ protocol.BatchServiceServer = (*ServiceV2)(nil)
+
+// MaxBatchInFlight is the maximum number of files of a single ParseBatch
+// stream being parsed at the same time.
+//
+// Can be changed by setting BBLFSHD_MAX_BATCH_IN_FLIGHT.
+var MaxBatchInFlight = mustEnvInt("BBLFSHD_MAX_BATCH_IN_FLIGHT", 4*DefaultMaxInstancesPerDriver)
+
+// ParseBatch implements protocol.BatchServiceServer.
+//
+// Each file is routed to the pool of its language and parsed concurrently with
+// the rest of the stream. Responses are sent as soon as they are ready, tagged
+// with the ID of the request. The stream stops receiving new files while the
+// pool of the next file is overloaded, or while MaxBatchInFlight files of this
+// stream are being parsed. Files over the rate limits of the client fail with
+// a ResourceExhausted response, without failing the stream.
+func (s *ServiceV2) ParseBatch(stream protocol.BatchService_ParseBatchServer) error {
+	parseBatchCalls.Add(1)
+
+	sp, ctx := opentracing.StartSpanFromContext(stream.Context(), "bblfshd.v2.ParseBatch")
+	defer sp.Finish()
+
+	cls, err := requestClassFromMetadata(ctx, PriorityBatch)
+	if err != nil {
+		return err
+	}
+	ctx = withRequestClass(ctx, cls)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	b := &parseBatch{
+		s:    s,
+		out:  make(chan *protocol.ParseBatchResponse),
+		done: make(chan struct{}, 1),
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		var err error
+		for resp := range b.out {
+			if err != nil {
+				continue // drain the responses of the requests in flight
+			}
+			if err = stream.Send(resp); err != nil {
+				cancel()
+			}
+		}
+		sent <- err
+	}()
+
+	for {
+		var req *protocol.ParseBatchRequest
+		req, err = stream.Recv()
+		if err == io.EOF {
+			err = nil
+			break
+		} else if err != nil {
+			cancel()
+			break
+		}
+
+		if err = b.dispatch(ctx, req); err != nil {
+			break
+		}
+	}
+
+	b.wg.Wait()
+	close(b.out)
+	if serr := <-sent; err == nil {
+		err = serr
+	}
+	return err
+}
+
+// parseBatch tracks the requests of a single ParseBatch stream.
+type parseBatch struct {
+	s *ServiceV2
+
+	// wg tracks the goroutines parsing the requests in flight.
+	wg sync.WaitGroup
+	// out receives the responses. It is consumed by a single goroutine
+	// that sends them to the client.
+	out chan *protocol.ParseBatchResponse
+	// done is signalled each time a request in flight completes. The channel
+	// has a buffer and sends to this channel must be used with default.
+	done chan struct{}
+
+	inFlight atomicInt // requests being parsed or waiting for the response to be sent
+}
+
+// dispatch selects a pool for the request and parses it in a new goroutine.
+// It blocks until the stream is allowed to send a new request to the pool, or
+// a new response for the requests failing before reaching one. Errors related
+// to the request are sent as responses, an error is only returned if the
+// stream is cancelled.
+func (b *parseBatch) dispatch(ctx context.Context, req *protocol.ParseBatchRequest) error {
+	r := req.Request
+	if r == nil {
+		r = &protocol2.ParseRequest{}
+	}
+
+	parseCallsV2.Add(1)
+	parseContentSizeV2.Observe(float64(len(r.Content)))
+
+	if err := limitMessage(ctx, req); err != nil {
+		return b.replyNow(ctx, req.ID, r, nil, err, time.Now())
+	}
+
+	if r.Content == "" {
+		log.Debugf("empty request received, returning empty UAST")
+		return b.replyNow(ctx, req.ID, r, &protocol2.ParseResponse{}, nil, time.Now())
+	}
+
+	if !utf8.ValidString(r.Content) {
+		parseErrorsV2.Add(1)
+		err := ErrUnknownEncoding.New()
+		log.Debugf("parse v2 (%s): %s", r.Filename, err)
+		return b.replyNow(ctx, req.ID, r, nil, err, time.Now())
+	}
+
+	start := time.Now()
+	language, version, dp, err := b.s.selectPool(ctx, r.Language, r.Content, r.Filename)
+	if err != nil {
+		parseErrorsV2.Add(1)
+		log.Errorf(err, "error selecting pool")
+		return b.replyNow(ctx, req.ID, r, nil, err, start)
+	}
+
+	if err := b.wait(ctx, dp); err != nil {
+		return err
+	}
+
+	b.start()
+	go func() {
+		ctx, stats := withRequestStats(ctx)
+		resp, err := b.s.parse(ctx, language, version, dp, r)
+		b.reply(req.ID, r, resp, err, start, stats)
+	}()
+	return nil
+}
+
+// replyNow sends a response to a request that is not parsed, once the stream
+// is allowed to have a new request in flight. The responses wait for the
+// client like the parsed ones, so they are bounded by MaxBatchInFlight too.
+func (b *parseBatch) replyNow(ctx context.Context, id string, req *protocol2.ParseRequest, resp *protocol2.ParseResponse, err error, start time.Time) error {
+	if err := b.wait(ctx, nil); err != nil {
+		return err
+	}
+
+	b.start()
+	go b.reply(id, req, resp, err, start, nil)
+	return nil
+}
+
+// wait blocks while the stream has too many requests in flight or the pool is
+// overloaded. A nil pool is never overloaded, for the requests answered
+// without one. It never blocks if no requests of the stream are in flight, so
+// the stream is always able to make progress.
+func (b *parseBatch) wait(ctx context.Context, dp *DriverPool) error {
+	throttled := false
+	for {
+		n := b.inFlight.Value()
+		if n == 0 || (n < MaxBatchInFlight && (dp == nil || !dp.Overloaded())) {
+			return nil
+		}
+		if !throttled {
+			throttled = true
+			parseBatchThrottled.Add(1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.done:
+		}
+	}
+}
+
+func (b *parseBatch) start() {
+	b.wg.Add(1)
+	b.inFlight.Add(1)
+	parseBatchInFlight.Inc()
+}
+
+// reply sends the response of the request to the client and marks it as
+// completed.
+func (b *parseBatch) reply(id string, req *protocol2.ParseRequest, resp *protocol2.ParseResponse, err error, start time.Time, stats *RequestStats) {
+	defer b.wg.Done()
+
+	elapsed := time.Since(start)
+	parseLatencyV2.Observe(elapsed.Seconds())
+	b.s.logResponse(err, req.Filename, req.Language, req.Content, elapsed, stats)
+
+	out := &protocol.ParseBatchResponse{ID: id, Response: resp}
+	if err != nil {
+		out.Code = uint32(errorCode(err))
+		out.Error = err.Error()
+	}
+	b.out <- out
+
+	b.inFlight.Add(-1)
+	parseBatchInFlight.Dec()
+	select {
+	case b.done <- struct{}{}:
+	default:
+	}
+}
+
+// errorCode returns the gRPC status code that is used for the error.
+func errorCode(err error) codes.Code {
+	switch err {
+	case context.Canceled:
+		return codes.Canceled
+	case context.DeadlineExceeded:
+		return codes.DeadlineExceeded
+	}
+	return status.Code(err)
+}
@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+)
+
+func TestServiceV2ParseBatch(t *testing.T) {
+	require := require.New(t)
+
+	d, tmp := buildMockedDaemon(t)
+	defer os.RemoveAll(tmp)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(err)
+	go d.UserServer.Serve(lis)
+	defer func() {
+		err = d.Stop()
+		require.NoError(err)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithTimeout(2*time.Second),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := protocol.NewBatchServiceClient(conn).ParseBatch(ctx)
+	require.NoError(err)
+
+	const n = 50
+	go func() {
+		for i := 0; i < n; i++ {
+			err := stream.Send(&protocol.ParseBatchRequest{
+				ID: fmt.Sprint(i),
+				Request: &protocol2.ParseRequest{
+					Filename: fmt.Sprintf("foo%d.py", i),
+					Content:  fmt.Sprintf("import foo%d", i),
+				},
+			})
+			if err != nil {
+				return
+			}
+		}
+		stream.Send(&protocol.ParseBatchRequest{
+			ID:      "invalid",
+			Request: &protocol2.ParseRequest{Filename: "foo.py", Content: "\xff"},
+		})
+		stream.CloseSend()
+	}()
+
+	responses := make(map[string]*protocol.ParseBatchResponse)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.NotContains(responses, resp.ID)
+		responses[resp.ID] = resp
+	}
+	require.Len(responses, n+1)
+
+	for i := 0; i < n; i++ {
+		resp := responses[fmt.Sprint(i)]
+		require.NotNil(resp)
+		require.Equal(uint32(codes.OK), resp.Code, resp.Error)
+		require.Equal("python", resp.Response.Language)
+		require.Equal(fmt.Sprintf("import foo%d", i), string(resp.Response.Uast))
+	}
+
+	invalid := responses["invalid"]
+	require.NotEqual(uint32(codes.OK), invalid.Code)
+	require.NotEmpty(invalid.Error)
+	require.Nil(invalid.Response)
+}
+
+func TestParseBatchWait(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	b := &parseBatch{done: make(chan struct{}, 1)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a stream without requests in flight is never throttled
+	dp.requests.Set(10)
+	require.NoError(b.wait(ctx, dp))
+
+	b.inFlight.Set(1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		b.inFlight.Set(0)
+		b.done <- struct{}{}
+	}()
+	require.NoError(b.wait(ctx, dp))
+
+	b.inFlight.Set(1)
+	cancel()
+	require.Equal(context.Canceled, b.wait(ctx, dp))
+
+	dp.requests.Set(0)
+	require.NoError(b.wait(context.Background(), dp))
+}
+
+func TestParseBatchDispatch_Bounded(t *testing.T) {
+	require := require.New(t)
+
+	defer func(n int) { MaxBatchInFlight = n }(MaxBatchInFlight)
+	MaxBatchInFlight = 2
+
+	b := &parseBatch{
+		s:    &ServiceV2{},
+		out:  make(chan *protocol.ParseBatchResponse),
+		done: make(chan struct{}, 1),
+	}
+
+	const n = 10
+	dispatched := make(chan error, 1)
+	go func() {
+		for i := 0; i < n; i++ {
+			err := b.dispatch(context.Background(), &protocol.ParseBatchRequest{
+				ID:      fmt.Sprint(i),
+				Request: &protocol2.ParseRequest{Filename: "foo.py", Content: "\xff"},
+			})
+			if err != nil {
+				dispatched <- err
+				return
+			}
+		}
+		dispatched <- nil
+	}()
+
+	// the invalid files are not parsed, but their responses are still
+	// bounded while the client doesn't receive them
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(MaxBatchInFlight, b.inFlight.Value())
+	select {
+	case <-dispatched:
+		require.Fail("all the files were dispatched")
+	default:
+	}
+
+	for i := 0; i < n; i++ {
+		resp := <-b.out
+		require.NotEqual(uint32(codes.OK), resp.Code)
+		require.True(b.inFlight.Value() <= MaxBatchInFlight)
+	}
+	require.NoError(<-dispatched)
+
+	b.wg.Wait()
+	require.Equal(0, b.inFlight.Value())
+}
@@ -0,0 +1,358 @@
+package daemon
+
+import (
+	"container/list"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/src-d/go-log.v1"
+
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+)
+
+const cacheTempPrefix = ".tmp-"
+
+// cacheKey identifies a parse response in the ParseCache.
+type cacheKey struct {
+	// Hash is the git blob hash of the parsed content.
+	Hash string
+	// Language of the driver that parsed the content.
+	Language string
+	// Digest of the driver image that parsed the content.
+	Digest string
+	// Mode of the transformations applied to the UAST.
+	Mode protocol2.Mode
+}
+
+// path returns the path of the entry relative to the cache directory. Entries
+// are grouped by language and image digest, so they can be invalidated at once.
+func (k cacheKey) path() string {
+	return filepath.Join(
+		strings.ToLower(k.Language), k.Digest, k.Hash[:2],
+		k.Hash+"-"+strconv.Itoa(int(k.Mode)),
+	)
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	data []byte
+}
+
+// ParseCache is a content-addressed cache of parse responses. The most
+// recently used responses are kept in memory, and all of them are written to
+// disk, so the cache survives restarts. Both tiers are limited in size, and
+// evict the least recently used responses first.
+//
+// A nil *ParseCache is valid and caches nothing.
+type ParseCache struct {
+	diskSize int64 // accessed atomically; first for the alignment on 32 bit
+	evicting int32 // set while the disk tier is being evicted
+
+	path    string
+	maxMem  int64
+	maxDisk int64
+
+	mu      sync.Mutex
+	lru     *list.List // most recently used first
+	entries map[cacheKey]*list.Element
+	memSize int64
+}
+
+// NewParseCache creates a cache keeping up to memSize bytes of responses in
+// memory, and up to diskSize bytes under the given directory. If path is empty
+// or diskSize is zero, responses are only kept in memory.
+func NewParseCache(path string, memSize, diskSize int64) (*ParseCache, error) {
+	c := &ParseCache{
+		maxMem:  memSize,
+		maxDisk: diskSize,
+		lru:     list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+	if path == "" || diskSize <= 0 {
+		return c, nil
+	}
+	c.path = path
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+
+	var size int64
+	err := filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		if strings.HasPrefix(fi.Name(), cacheTempPrefix) {
+			return os.Remove(p)
+		}
+		size += fi.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.diskSize = size
+	cacheSize.WithLabelValues("disk").Set(float64(size))
+	return c, nil
+}
+
+// Get returns the cached response for the key, or nil if there is none.
+func (c *ParseCache) Get(key cacheKey) *protocol2.ParseResponse {
+	if c == nil {
+		return nil
+	}
+
+	tier := "memory"
+	data := c.getMem(key)
+	if data == nil {
+		tier = "disk"
+		data = c.getDisk(key)
+	}
+	if data == nil {
+		cacheMisses.Add(1)
+		return nil
+	}
+
+	resp := &protocol2.ParseResponse{}
+	if err := resp.Unmarshal(data); err != nil {
+		log.Warningf("corrupted cache entry for %s (%s): %s", key.Hash, key.Language, err)
+		c.remove(key)
+		cacheMisses.Add(1)
+		return nil
+	}
+	if tier == "disk" {
+		c.putMem(key, data)
+	}
+
+	cacheHits.WithLabelValues(tier).Add(1)
+	return resp
+}
+
+// Put stores the response for the key in the cache.
+func (c *ParseCache) Put(key cacheKey, resp *protocol2.ParseResponse) {
+	if c == nil {
+		return
+	}
+
+	data, err := resp.Marshal()
+	if err != nil {
+		log.Warningf("cannot cache the response for %s (%s): %s", key.Hash, key.Language, err)
+		return
+	}
+
+	c.putMem(key, data)
+	if err := c.putDisk(key, data); err != nil {
+		log.Warningf("cannot write the cache entry for %s (%s): %s", key.Hash, key.Language, err)
+	}
+}
+
+// Invalidate removes all the responses of the driver image with the given
+// digest for the language, or of all the images of the language if the digest
+// is empty.
+func (c *ParseCache) Invalidate(language, digest string) {
+	if c == nil {
+		return
+	}
+
+	language = strings.ToLower(language)
+	c.mu.Lock()
+	for key, e := range c.entries {
+		if strings.ToLower(key.Language) == language && (digest == "" || key.Digest == digest) {
+			c.removeElement(e)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.path == "" {
+		return
+	}
+
+	dir := filepath.Join(c.path, language)
+	if digest != "" {
+		dir = filepath.Join(dir, digest)
+	}
+	size := dirSize(dir)
+	if err := os.RemoveAll(dir); err != nil {
+		log.Errorf(err, "error invalidating the cache for %s", language)
+		return
+	}
+	c.addDiskSize(-size)
+}
+
+func (c *ParseCache) getMem(key cacheKey) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*cacheEntry).data
+}
+
+func (c *ParseCache) putMem(key cacheKey, data []byte) {
+	if int64(len(data)) > c.maxMem {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.removeElement(e)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, data: data})
+	c.memSize += int64(len(data))
+
+	for c.memSize > c.maxMem {
+		c.removeElement(c.lru.Back())
+		cacheEvictions.WithLabelValues("memory").Add(1)
+	}
+	cacheSize.WithLabelValues("memory").Set(float64(c.memSize))
+}
+
+// removeElement removes an entry from the memory tier, and should be called
+// under a lock.
+func (c *ParseCache) removeElement(e *list.Element) {
+	ent := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, ent.key)
+	c.memSize -= int64(len(ent.data))
+	cacheSize.WithLabelValues("memory").Set(float64(c.memSize))
+}
+
+func (c *ParseCache) getDisk(key cacheKey) []byte {
+	if c.path == "" {
+		return nil
+	}
+
+	path := filepath.Join(c.path, key.path())
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warningf("cannot read the cache entry for %s (%s): %s", key.Hash, key.Language, err)
+		}
+		return nil
+	}
+
+	// the modification time is used as the access time for the eviction
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return data
+}
+
+func (c *ParseCache) putDisk(key cacheKey, data []byte) error {
+	if c.path == "" || int64(len(data)) > c.maxDisk {
+		return nil
+	}
+
+	path := filepath.Join(c.path, key.path())
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, cacheTempPrefix)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if c.addDiskSize(int64(len(data))) > c.maxDisk {
+		c.evictDisk()
+	}
+	return nil
+}
+
+// evictDisk removes the least recently used entries from the disk tier, until
+// its size is below 90% of the limit.
+func (c *ParseCache) evictDisk() {
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return // already evicting
+	}
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	type file struct {
+		path  string
+		size  int64
+		mtime time.Time
+	}
+
+	var files []file
+	filepath.Walk(c.path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() || strings.HasPrefix(fi.Name(), cacheTempPrefix) {
+			return nil
+		}
+		files = append(files, file{path: p, size: fi.Size(), mtime: fi.ModTime()})
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].mtime.Before(files[j].mtime)
+	})
+
+	target := c.maxDisk / 10 * 9
+	for _, f := range files {
+		if atomic.LoadInt64(&c.diskSize) <= target {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			continue
+		}
+		c.addDiskSize(-f.size)
+		cacheEvictions.WithLabelValues("disk").Add(1)
+	}
+}
+
+// remove removes the entry from both tiers.
+func (c *ParseCache) remove(key cacheKey) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.removeElement(e)
+	}
+	c.mu.Unlock()
+
+	if c.path == "" {
+		return
+	}
+	path := filepath.Join(c.path, key.path())
+	if fi, err := os.Stat(path); err == nil && os.Remove(path) == nil {
+		c.addDiskSize(-fi.Size())
+	}
+}
+
+func (c *ParseCache) addDiskSize(n int64) int64 {
+	size := atomic.AddInt64(&c.diskSize, n)
+	cacheSize.WithLabelValues("disk").Set(float64(size))
+	return size
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(_ string, fi os.FileInfo, err error) error {
+		if err == nil && !fi.IsDir() {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheKey(content, language, digest string) cacheKey {
+	return cacheKey{Hash: hashGit(content), Language: language, Digest: digest}
+}
+
+func TestParseCache_Nil(t *testing.T) {
+	require := require.New(t)
+
+	var c *ParseCache
+	key := newCacheKey("foo", "python", "abc")
+	c.Put(key, &protocol2.ParseResponse{Uast: []byte("foo")})
+	require.Nil(c.Get(key))
+	c.Invalidate("python", "")
+}
+
+func TestParseCache_Memory(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewParseCache("", 100, 0)
+	require.NoError(err)
+
+	foo := newCacheKey("foo", "python", "abc")
+	require.Nil(c.Get(foo))
+
+	c.Put(foo, &protocol2.ParseResponse{Uast: []byte("foo"), Language: "python"})
+	resp := c.Get(foo)
+	require.NotNil(resp)
+	require.Equal("foo", string(resp.Uast))
+	require.Equal("python", resp.Language)
+
+	// the same content parsed by another image or in another mode is a different entry
+	require.Nil(c.Get(newCacheKey("foo", "python", "def")))
+	native := foo
+	native.Mode = protocol2.Mode_Native
+	require.Nil(c.Get(native))
+
+	bar := newCacheKey("bar", "python", "abc")
+	c.Put(bar, &protocol2.ParseResponse{Uast: make([]byte, 90)})
+	require.NotNil(c.Get(bar))
+	require.Nil(c.Get(foo), "least recently used entry must be evicted")
+	require.True(c.memSize <= 100)
+}
+
+func TestParseCache_Disk(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "parse-cache")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	c, err := NewParseCache(dir, 1024, 1024)
+	require.NoError(err)
+
+	foo := newCacheKey("foo", "python", "abc")
+	bar := newCacheKey("bar", "python", "def")
+	baz := newCacheKey("baz", "go", "abc")
+	for _, key := range []cacheKey{foo, bar, baz} {
+		c.Put(key, &protocol2.ParseResponse{Uast: []byte(key.Hash)})
+	}
+
+	// a new cache on the same path serves the entries from disk
+	c, err = NewParseCache(dir, 1024, 1024)
+	require.NoError(err)
+	require.True(c.diskSize > 0)
+
+	resp := c.Get(foo)
+	require.NotNil(resp)
+	require.Equal(foo.Hash, string(resp.Uast))
+	require.Len(c.entries, 1, "disk hits must be promoted to memory")
+
+	c.Invalidate("Python", "abc")
+	require.Nil(c.Get(foo))
+	require.NotNil(c.Get(bar))
+	require.NotNil(c.Get(baz))
+
+	c.Invalidate("python", "")
+	require.Nil(c.Get(bar))
+	require.NotNil(c.Get(baz))
+
+	_, err = os.Stat(filepath.Join(dir, "python"))
+	require.True(os.IsNotExist(err))
+}
+
+func TestParseCache_DiskEviction(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "parse-cache-eviction")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	c, err := NewParseCache(dir, 0, 100)
+	require.NoError(err)
+
+	var keys []cacheKey
+	for _, content := range []string{"foo", "bar", "baz"} {
+		key := newCacheKey(content, "python", "abc")
+		keys = append(keys, key)
+		c.Put(key, &protocol2.ParseResponse{Uast: make([]byte, 40)})
+	}
+
+	require.True(dirSize(dir) <= 100)
+	require.Equal(dirSize(dir), c.diskSize)
+	require.NotNil(c.Get(keys[2]), "most recent entry must be kept")
+}
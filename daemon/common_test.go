@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"testing"
 
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/bblfshd/runtime"
 
 	protocol2 "github.com/bblfsh/sdk/v3/protocol"
 	"github.com/containers/image/types"
+	"github.com/stretchr/testify/require"
 	oldctx "golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"gopkg.in/bblfsh/sdk.v1/manifest"
@@ -104,6 +107,20 @@ func (d *echoDriver) Service() protocol1.ProtocolServiceClient {
 	return d
 }
 
+func (d *echoDriver) ServiceV2() protocol2.DriverClient {
+	return echoDriverV2{}
+}
+
+// echoDriverV2 returns the content of the file as the UAST.
+type echoDriverV2 struct{}
+
+func (echoDriverV2) Parse(
+	_ oldctx.Context, in *protocol2.ParseRequest, opts ...grpc.CallOption) (*protocol2.ParseResponse, error) {
+	return &protocol2.ParseResponse{
+		Uast: []byte(in.Content),
+	}, nil
+}
+
 func newMockDriverImage(lang string) runtime.DriverImage {
 	return &mockDriverImage{lang: lang}
 }
@@ -132,6 +149,14 @@ func (d *mockDriverImage) WriteTo(path string) error {
 	return writeImageConfig(d.Name(), path)
 }
 
+// writeConfig writes a configuration file with the given name and content to
+// dir, and returns its path.
+func writeConfig(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+	return path
+}
+
 func writeManifest(language, path string) error {
 	manifest := manifest.Manifest{
 		Name: language,
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/yaml.v2"
+)
+
+// readConfig reads a YAML configuration file into v. Unknown fields are not
+// allowed, and the file is reported as an error of the invalid kind, which
+// takes the path and the cause, if it can't be decoded.
+func readConfig(path string, v interface{}, invalid *errors.Kind) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.UnmarshalStrict(data, v); err != nil {
+		return invalid.New(path, err)
+	}
+	return nil
+}
+
+// configFile holds the path of the configuration file loaded last, so it can
+// be read again on reload.
+type configFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+// set records the path of a configuration file once loaded.
+func (f *configFile) set(path string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.path = path
+}
+
+// reload calls load with the path of the file loaded last, or returns an
+// error of the none kind if no file was loaded.
+func (f *configFile) reload(none *errors.Kind, load func(path string) error) error {
+	f.mu.Lock()
+	path := f.path
+	f.mu.Unlock()
+	if path == "" {
+		return none.New()
+	}
+	return load(path)
+}
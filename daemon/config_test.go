@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-config")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	var c struct {
+		Max int `yaml:"max"`
+	}
+	err = readConfig(writeConfig(t, dir, "config.yml", "max: 8"), &c, ErrInvalidScalingConfig)
+	require.NoError(err)
+	require.Equal(8, c.Max)
+
+	err = readConfig(writeConfig(t, dir, "config.yml", "maximum: 8"), &c, ErrInvalidScalingConfig)
+	require.True(ErrInvalidScalingConfig.Is(err), "%v", err)
+
+	err = readConfig(dir+"/missing.yml", &c, ErrInvalidScalingConfig)
+	require.True(os.IsNotExist(err), "%v", err)
+}
+
+func TestConfigFile_Reload(t *testing.T) {
+	require := require.New(t)
+
+	var loaded []string
+	load := func(path string) error {
+		loaded = append(loaded, path)
+		return nil
+	}
+
+	var f configFile
+	err := f.reload(ErrNoScalingConfig, load)
+	require.True(ErrNoScalingConfig.Is(err), "%v", err)
+	require.Len(loaded, 0)
+
+	f.set("scaling.yml")
+	require.NoError(f.reload(ErrNoScalingConfig, load))
+	require.Equal([]string{"scaling.yml"}, loaded)
+}
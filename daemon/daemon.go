@@ -4,21 +4,24 @@ package daemon
 
 import (
 	"context"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/containers/image/types"
 	"github.com/opentracing/opentracing-go"
 	"gopkg.in/src-d/go-log.v1"
 
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/bblfshd/runtime"
 
-	"github.com/bblfsh/sdk/v3/driver"
 	"github.com/bblfsh/sdk/v3/driver/manifest"
 	protocol2 "github.com/bblfsh/sdk/v3/protocol"
 	protocol1 "gopkg.in/bblfsh/sdk.v1/protocol"
@@ -36,23 +39,69 @@ const (
 	keepalivePingWithoutStream = true
 )
 
+var (
+	// driverValidationTimeout is the maximal amount of time a test instance of
+	// a new driver image may take to start and to answer a version request.
+	driverValidationTimeout = time.Minute
+	// poolDrainTimeout is the maximal amount of time to wait for in-flight
+	// requests when a driver pool is replaced. The pool is stopped after it.
+	poolDrainTimeout = time.Minute
+)
+
 // Daemon is a Babelfish server.
 type Daemon struct {
 	UserServer    *grpc.Server
 	ControlServer *grpc.Server
+	// Cache of the parse responses, disabled if nil. It must be set before
+	// serving any requests.
+	Cache *ParseCache
+	// StatsTrailer enables sending the RequestStats of each parse request
+	// in the gRPC trailer of the response.
+	StatsTrailer bool
+	// Audit is the log of the control requests, queried by the AuditLog
+	// control RPC. It must be set before serving any requests.
+	Audit *AuditLog
 
 	version   string
 	build     time.Time
 	runtime   *runtime.Runtime
 	driverEnv []string
 
+	// notReady is set while the daemon is not ready to serve requests.
+	notReady int32
+	// stopping is set once the daemon starts shutting down.
+	stopping int32
+
 	mu      sync.RWMutex
-	pool    map[string]*DriverPool // language ID → driver pool
+	pool    map[string]*DriverPool // pool key → driver pool
+	current map[string]string      // language ID → driver version of its pool
 	aliases map[string]string      // alias → language ID
+
+	configMu      sync.RWMutex
+	scaling       *ScalingConfig   // scaling policies of the pools, default if nil
+	scalingFile   configFile       // file the scaling configuration was loaded from
+	resources     *ResourcesConfig // limits of the driver containers, none if nil
+	resourcesFile configFile       // file the resources configuration was loaded from
+	update        *UpdateConfig    // update policies of the drivers, not checked if nil
+	updateFile    configFile       // file the update configuration was loaded from
+	drivers       *DriversConfig   // drivers that should be installed, not reconciled if nil
+	driversFile   configFile       // file the drivers configuration was loaded from
+
+	reconcileMu sync.Mutex
+
+	updateMu sync.Mutex
+	updates  map[string]*UpdateStatus // language and image reference → last update check
 }
 
 // NewDaemon creates a new server based on the runtime with the given version.
+// The options are used by the user server.
 func NewDaemon(version string, build time.Time, r *runtime.Runtime, opts ...grpc.ServerOption) *Daemon {
+	return NewDaemonWithOptions(version, build, r, opts, nil)
+}
+
+// NewDaemonWithOptions creates a new server like NewDaemon, with the options of
+// the user server and the ones of the control server.
+func NewDaemonWithOptions(version string, build time.Time, r *runtime.Runtime, opts, ctlOpts []grpc.ServerOption) *Daemon {
 	commonOpt := append(protocol2.ServerOptions(),
 		// EnforcementPolicy is used to set keepalive enforcement policy on the
 		// server-side. Server will close connection with a client that violates this
@@ -62,16 +111,19 @@ func NewDaemon(version string, build time.Time, r *runtime.Runtime, opts ...grpc
 			PermitWithoutStream: keepalivePingWithoutStream,
 		}),
 	)
-	opts = append(commonOpt, opts...)
+	opts = append(commonOpt[:len(commonOpt):len(commonOpt)], opts...)
+	ctlOpts = append(commonOpt[:len(commonOpt):len(commonOpt)], ctlOpts...)
 
 	d := &Daemon{
 		version:       version,
 		build:         build,
 		runtime:       r,
 		pool:          make(map[string]*DriverPool),
+		current:       make(map[string]string),
 		aliases:       make(map[string]string),
+		updates:       make(map[string]*UpdateStatus),
 		UserServer:    grpc.NewServer(opts...),
-		ControlServer: grpc.NewServer(commonOpt...),
+		ControlServer: grpc.NewServer(ctlOpts...),
 	}
 	registerGRPC(d)
 	// pass tracing options to each driver
@@ -99,16 +151,98 @@ func registerGRPC(d *Daemon) {
 	s2 := NewServiceV2(d)
 	protocol2.RegisterDriverServer(d.UserServer, s2)
 	protocol2.RegisterDriverHostServer(d.UserServer, s2)
+	protocol.RegisterBatchServiceServer(d.UserServer, s2)
 	protocol.RegisterService(d.ControlServer, NewControlService(d))
+	grpc_health_v1.RegisterHealthServer(d.UserServer, NewHealthService(d, d.UserServer))
+	grpc_health_v1.RegisterHealthServer(d.ControlServer, NewHealthService(d, d.ControlServer))
 }
 
-func (d *Daemon) InstallDriver(language string, image string, update bool) error {
+// InstallDriver installs the driver image for the language. The image is
+// pulled with the given credentials, if any, or with the ones configured in
+// the runtime. The events of the installation are reported to progress, if
+// not nil.
+func (d *Daemon) InstallDriver(language string, image string, update bool, creds *protocol.RegistryCredentials, progress func(*protocol.InstallProgress)) error {
 	driverInstallCalls.Add(1)
 
-	img, err := runtime.NewDriverImage(image)
+	if progress == nil {
+		progress = func(*protocol.InstallProgress) {}
+	}
+
+	progress(&protocol.InstallProgress{Stage: protocol.InstallResolving})
+	var auth *types.DockerAuthConfig
+	if creds != nil {
+		auth = &types.DockerAuthConfig{Username: creds.Username, Password: creds.Password}
+	}
+
+	img, err := runtime.NewDriverImageWithAuth(image, d.runtime.RegistryAuth, auth)
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	img = runtime.WithProgress(img, func(p runtime.Progress) {
+		stage := protocol.InstallDownloading
+		if p.Stage == runtime.Unpacking {
+			stage = protocol.InstallUnpacking
+		}
+
+		progress(&protocol.InstallProgress{
+			Stage:   stage,
+			Layer:   p.Layer,
+			Current: p.Current,
+			Total:   p.Total,
+		})
+	})
+	return d.installImage(language, image, img, update, progress)
+}
+
+// ImportDriver installs the driver stored in a bundle read from r, written by
+// ExportDriver. The driver is installed as the image the bundle was exported
+// from, so it is listed with the same reference, and with the digest of its
+// root filesystem.
+func (d *Daemon) ImportDriver(r io.Reader, update bool) error {
+	driverImportCalls.Add(1)
+
+	b, err := d.runtime.ReadDriverBundle(r)
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	defer func() {
+		if err := b.Close(); err != nil {
+			log.Errorf(err, "error removing driver bundle")
+		}
+	}()
+	return d.installImage(b.Language(), b.Reference(), b, update, func(*protocol.InstallProgress) {})
+}
+
+// ExportDriver writes the given version of the driver for the language, or
+// its current version if version is empty, as a bundle that can be installed
+// without access to the registry with ImportDriver.
+func (d *Daemon) ExportDriver(language, version string, w io.Writer) error {
+	driverExportCalls.Add(1)
+
+	dr, err := d.getDriver(context.TODO(), language, version)
 	if err != nil {
 		return ErrRuntime.Wrap(err)
 	}
+
+	id, err := runtime.NewInstalledDriver(dr)
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	if err := d.runtime.ExportDriver(id, w); err != nil {
+		return err
+	}
+
+	log.Infof("driver %s exported %q (version %s)", language, id.Name(), dr.Manifest.Version)
+	return nil
+}
+
+// installImage installs a driver image for the language, the reference of the
+// image is the one listed once installed. The events of the installation not
+// reported by the image itself are reported to progress.
+func (d *Daemon) installImage(language, image string, img runtime.DriverImage, update bool, progress func(*protocol.InstallProgress)) error {
 	if language == "" {
 		info, err := img.Inspect()
 		if err != nil {
@@ -121,33 +255,157 @@ func (d *Daemon) InstallDriver(language string, image string, update bool) error
 		}
 	}
 
-	s, _, err := d.getDriverImage(context.TODO(), language)
-	if err != nil && !driver.IsMissingDriver(err) {
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
 		return ErrRuntime.Wrap(err)
 	}
-	if err == nil {
+	if !update {
+		for _, dr := range driversWithLang(language, list) {
+			if dr.Reference == image {
+				return ErrAlreadyInstalled.New(language, image)
+			}
+		}
+	}
+
+	sd, err := d.runtime.StageDriver(img)
+	if runtime.ErrImageVerification.Is(err) {
+		return ErrImageVerification.Wrap(err)
+	} else if err != nil {
+		return err
+	}
+
+	version := sd.Status.Manifest.Version
+	if dr := driverWithVersion(language, version, list); dr != nil {
 		if !update {
-			return ErrAlreadyInstalled.Wrap(err, language, image)
+			d.discardDriver(sd)
+			return ErrAlreadyInstalled.New(language, image)
 		}
-		// TODO: the old driver should be removed only after a successful install of the new one
-		if err := d.runtime.RemoveDriver(s); err != nil {
+
+		old, err := runtime.NewInstalledDriver(dr)
+		if err != nil {
+			d.discardDriver(sd)
+			return ErrRuntime.Wrap(err)
+		}
+
+		progress(&protocol.InstallProgress{Stage: protocol.InstallValidating})
+		return d.upgradeDriver(context.TODO(), language, old, sd)
+	}
+
+	// a new version is installed next to the other ones, and only replaces
+	// the current version if update is set
+	if update {
+		progress(&protocol.InstallProgress{Stage: protocol.InstallValidating})
+		if err := d.validateDriver(context.TODO(), language, sd); err != nil {
+			d.discardDriver(sd)
+			return ErrDriverValidation.Wrap(err, img.Name())
+		}
+	}
+
+	id, err := d.runtime.CommitDriver(sd)
+	if err != nil {
+		d.discardDriver(sd)
+		return err
+	}
+
+	if update {
+		if err := d.setCurrent(id); err != nil {
 			return err
 		}
 	}
 
-	_, err = d.runtime.InstallDriver(img, update)
+	log.Infof("driver %s installed %q (version %s)", language, img.Name(), version)
+	return nil
+}
+
+// upgradeDriver replaces an installed version of a driver with a staged image
+// of the same version. The new image is validated first, so a broken image
+// leaves the old one in place. The old image is kept as a rollback generation,
+// and is restored if the driver pool cannot be restarted on the new image.
+func (d *Daemon) upgradeDriver(ctx context.Context, language string, old *runtime.InstalledDriver, sd *runtime.StagedDriver) error {
+	if err := d.validateDriver(ctx, language, sd); err != nil {
+		d.discardDriver(sd)
+		return ErrDriverValidation.Wrap(err, sd.Name())
+	}
+
+	id, err := d.runtime.UpgradeDriver(sd, old)
 	if err != nil {
+		d.discardDriver(sd)
+		return err
+	}
+
+	if err := d.restartPool(ctx, id); err != nil {
+		log.Errorf(err, "driver %s failed to start, rolling back to %q", language, old.Name())
+		if rerr := d.runtime.RollbackDriver(id, old); rerr != nil {
+			log.Errorf(rerr, "error rolling back driver %s", language)
+		}
 		return err
 	}
 
-	log.Infof("driver %s installed %q", language, img.Name())
+	// responses of the replaced image are never served again
+	d.Cache.Invalidate(old.Status.Manifest.Language, old.Status.Digest.String())
+
+	log.Infof("driver %s upgraded %q -> %q", language, old.Name(), id.Name())
 	return nil
 }
 
-func (d *Daemon) RemoveDriver(language string) error {
+func (d *Daemon) discardDriver(sd *runtime.StagedDriver) {
+	if err := d.runtime.DiscardDriver(sd); err != nil {
+		log.Errorf(err, "error removing staged driver %q", sd.Name())
+	}
+}
+
+// validateDriver starts a test instance of the driver image and checks that
+// it answers a version request.
+func (d *Daemon) validateDriver(rctx context.Context, language string, image runtime.DriverImage) error {
+	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.validateDriver")
+	defer sp.Finish()
+
+	ctx, cancel := context.WithTimeout(ctx, driverValidationTimeout)
+	defer cancel()
+
+	drv, err := NewDriverInstance(d.runtime, language, image, d.getDriverInstanceOptions(language))
+	if err != nil {
+		return err
+	}
+
+	err = drv.Start(ctx)
+	if serr := drv.Stop(); serr != nil {
+		log.Debugf("error stopping test instance of %q: %s", image.Name(), serr)
+	}
+	return err
+}
+
+// RemoveDriver removes the given version of the driver for the language, or
+// all its versions if version is empty.
+func (d *Daemon) RemoveDriver(language, version string) error {
 	driverRemoveCalls.Add(1)
 
-	img, _, err := d.getDriverImage(context.TODO(), language)
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	var found []*runtime.DriverImageStatus
+	for _, dr := range driversWithLang(language, list) {
+		if version == "" || dr.Manifest.Version == version {
+			found = append(found, dr)
+		}
+	}
+	if len(found) == 0 {
+		return ErrRuntime.Wrap(&ErrMissingDriver{driverKey(language, version)})
+	}
+
+	for _, dr := range found {
+		if err := d.removeDriver(dr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDriver removes an installed driver image, stopping its pool.
+func (d *Daemon) removeDriver(dr *runtime.DriverImageStatus) error {
+	img, err := runtime.NewInstalledDriver(dr)
 	if err != nil {
 		return ErrRuntime.Wrap(err)
 	}
@@ -155,79 +413,289 @@ func (d *Daemon) RemoveDriver(language string) error {
 	if err := d.runtime.RemoveDriver(img); err != nil {
 		return err
 	}
-	if err := d.removePool(language); err != nil {
+	if err := d.removePool(dr.Manifest.Language, dr.Manifest.Version); err != nil {
 		return err
 	}
+	d.Cache.Invalidate(dr.Manifest.Language, dr.Digest.String())
 
-	log.Infof("driver %s removed %q", language, img.Name())
-	return err
+	log.Infof("driver %s removed %q (version %s)", dr.Manifest.Language, img.Name(), dr.Manifest.Version)
+	return nil
 }
 
-func (d *Daemon) DriverPool(ctx context.Context, language string) (*DriverPool, error) {
-	language = strings.ToLower(language)
-	d.mu.RLock()
-	if l, ok := d.aliases[language]; ok {
-		language = l
+// GC removes the image layers not used by any installed driver, and the
+// temporary files left behind by interrupted installations.
+func (d *Daemon) GC() (*runtime.GCStats, error) {
+	driverGCCalls.Add(1)
+
+	stats, err := d.runtime.GC()
+	if err != nil {
+		return nil, ErrRuntime.Wrap(err)
 	}
-	dp, ok := d.pool[language]
-	d.mu.RUnlock()
-	if ok {
+
+	log.Infof("storage garbage collected: %d layers and %d temporary directories removed, %d bytes released",
+		stats.Layers, stats.Temporary, stats.Size)
+	return stats, nil
+}
+
+// SetCurrentDriver makes the given version of the driver for the language the
+// one used by the requests not asking for a specific version.
+func (d *Daemon) SetCurrentDriver(language, version string) error {
+	dr, err := d.getDriver(context.TODO(), language, version)
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	id, err := runtime.NewInstalledDriver(dr)
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	return d.setCurrent(id)
+}
+
+// setCurrent makes an installed version the current one for its language.
+// Running pools are not restarted: the pool of the previous current version
+// keeps serving the requests asking for that version.
+func (d *Daemon) setCurrent(id *runtime.InstalledDriver) error {
+	if err := d.runtime.SetCurrentDriver(id); err != nil {
+		return err
+	}
+
+	m := id.Status.Manifest
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dp, ok := d.pool[m.Language]; ok {
+		delete(d.pool, m.Language)
+		if v, ok := d.current[m.Language]; ok {
+			d.pool[driverKey(m.Language, v)] = dp
+		} else if err := dp.Stop(); err != nil && !ErrPoolClosed.Is(err) {
+			log.Errorf(err, "error stopping the driver pool for %s", m.Language)
+		}
+	}
+	delete(d.current, m.Language)
+
+	key := driverKey(m.Language, m.Version)
+	if dp, ok := d.pool[key]; ok {
+		delete(d.pool, key)
+		d.pool[m.Language] = dp
+		d.current[m.Language] = m.Version
+	}
+
+	log.Infof("driver %s current version set to %s (%q)", m.Language, m.Version, id.Name())
+	return nil
+}
+
+// DriverPool returns the pool running the given version of the driver for the
+// language, starting it if needed. If version is empty, the pool of the
+// current version is returned.
+func (d *Daemon) DriverPool(ctx context.Context, language, version string) (*DriverPool, error) {
+	if dp, ok := d.runningPool(language, version); ok {
 		return dp, nil
 	}
 
+	language = strings.ToLower(language)
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if l, ok := d.aliases[language]; ok {
 		language = l
 	}
-	dp, ok = d.pool[language]
+	dp, ok := d.pool[d.poolKey(language, version)]
 	if ok {
 		return dp, nil
 	}
 
-	image, m, err := d.getDriverImage(ctx, language)
+	dr, err := d.getDriver(ctx, language, version)
 	if err != nil {
 		return nil, ErrRuntime.Wrap(err)
 	}
 
-	return d.newDriverPool(ctx, m.Language, m.Aliases, image)
+	// the requested version may be the current one, or the language an
+	// alias of a language with a running pool
+	m := dr.Manifest
+	key := driverKey(m.Language, m.Version)
+	if dr.Current {
+		key = m.Language
+	}
+	if dp, ok := d.pool[key]; ok {
+		return dp, nil
+	}
+
+	return d.newDriverPool(ctx, key, dr)
+}
+
+// runningPool returns the pool of the given version of the driver for the
+// language, if it was already started.
+func (d *Daemon) runningPool(language, version string) (*DriverPool, bool) {
+	language = strings.ToLower(language)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if l, ok := d.aliases[language]; ok {
+		language = l
+	}
+	dp, ok := d.pool[d.poolKey(language, version)]
+	return dp, ok
+}
+
+// poolKey returns the key of the pool for the given version of the driver, the
+// pool of the current version is registered under the language ID. It should
+// be called under a lock.
+func (d *Daemon) poolKey(language, version string) string {
+	if version == "" || d.current[language] == version {
+		return language
+	}
+	return driverKey(language, version)
+}
+
+// driverKey returns the identifier of a version of the driver for a language,
+// as used in requests asking for a specific version.
+func driverKey(language, version string) string {
+	if version == "" {
+		return language
+	}
+	return language + "@" + version
+}
+
+// poolFunc is a function to be executed using a driver of the given pool.
+type poolFunc func(ctx context.Context, dp *DriverPool, d Driver) error
+
+// execute runs the function on a driver from the pool. If the pool was closed
+// before assigning a driver to the request, because it was replaced by a driver
+// upgrade, the request is retried once on the current pool for the language.
+func (d *Daemon) execute(ctx context.Context, language, version string, dp *DriverPool, fnc poolFunc) error {
+	run := func(dp *DriverPool) error {
+		return dp.ExecuteCtx(ctx, func(ctx context.Context, drv Driver) error {
+			return fnc(ctx, dp, drv)
+		})
+	}
+
+	err := run(dp)
+	if !ErrPoolClosed.Is(err) {
+		return err
+	}
+
+	cur, perr := d.DriverPool(ctx, language, version)
+	if perr != nil || cur == dp {
+		return err
+	}
+	return run(cur)
 }
 
-func driverWithLang(lang string, list []*runtime.DriverImageStatus) *runtime.DriverImageStatus {
+// driversWithLang returns all the installed versions of the driver for the
+// given language.
+func driversWithLang(lang string, list []*runtime.DriverImageStatus) []*runtime.DriverImageStatus {
 	lang = strings.ToLower(lang)
+	var out []*runtime.DriverImageStatus
 	for _, d := range list {
 		m := d.Manifest
 		if strings.ToLower(m.Language) == lang {
-			return d
+			out = append(out, d)
+			continue
 		}
 		for _, l := range m.Aliases {
 			if strings.ToLower(l) == lang {
-				return d
+				out = append(out, d)
+				break
 			}
 		}
 	}
-	return nil
+	return out
 }
 
-func (d *Daemon) getDriverImage(rctx context.Context, language string) (runtime.DriverImage, *manifest.Manifest, error) {
+// driverWithVersion returns the given version of the driver for the language,
+// or the current one if version is empty.
+func driverWithVersion(lang, version string, list []*runtime.DriverImageStatus) *runtime.DriverImageStatus {
+	var found *runtime.DriverImageStatus
+	for _, d := range driversWithLang(lang, list) {
+		if version == "" && d.Current {
+			return d
+		}
+		if version == "" || d.Manifest.Version != version {
+			continue
+		}
+		if found == nil || d.Current {
+			found = d
+		}
+	}
+	return found
+}
+
+// driverDigest returns the digest of the installed driver of the language with
+// the given image reference, or with the given version if the reference is
+// empty, preferring the current one. It returns an empty string if the driver
+// is not installed.
+func (d *Daemon) driverDigest(language, reference, version string) string {
+	if language == "" {
+		return ""
+	}
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return ""
+	}
+
+	var found *runtime.DriverImageStatus
+	if reference == "" {
+		found = driverWithVersion(language, version, list)
+	} else {
+		for _, dr := range driversWithLang(language, list) {
+			if dr.Reference == reference && (found == nil || dr.Current) {
+				found = dr
+			}
+		}
+	}
+	if found == nil {
+		return ""
+	}
+	return found.Digest.String()
+}
+
+func (d *Daemon) getDriver(rctx context.Context, language, version string) (*runtime.DriverImageStatus, error) {
 	sp, _ := opentracing.StartSpanFromContext(rctx, "bblfshd.runtime.ListDrivers")
 	defer sp.Finish()
 
 	list, err := d.runtime.ListDrivers()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	dr := driverWithLang(language, list)
+	dr := driverWithVersion(language, version, list)
 	if dr == nil {
-		return nil, nil, &ErrMissingDriver{language}
+		return nil, &ErrMissingDriver{driverKey(language, version)}
 	}
-	img, err := runtime.NewDriverImage(dr.Reference)
-	return img, dr.Manifest, err
+	return dr, nil
 }
 
-// newDriverPool, instance a new driver pool for the given language and image
-// and should be called under a lock.
-func (d *Daemon) newDriverPool(rctx context.Context, language string, aliases []string, image runtime.DriverImage) (*DriverPool, error) {
+// newDriverPool, instance a new driver pool for the given version of a driver,
+// registers it under the given key and should be called under a lock.
+func (d *Daemon) newDriverPool(rctx context.Context, key string, dr *runtime.DriverImageStatus) (*DriverPool, error) {
+	image, err := runtime.NewInstalledDriver(dr)
+	if err != nil {
+		return nil, err
+	}
+
+	dp, err := d.startDriverPool(rctx, dr.Manifest.Language, image)
+	if err != nil {
+		return nil, err
+	}
+
+	d.setPool(key, dr.Manifest, dp)
+	return dp, nil
+}
+
+// setPool registers the driver pool under the given key, along with the
+// aliases of the language, and should be called under a lock.
+func (d *Daemon) setPool(key string, m *manifest.Manifest, dp *DriverPool) {
+	d.pool[key] = dp
+	if key == m.Language {
+		d.current[m.Language] = m.Version
+	}
+	for _, l := range m.Aliases {
+		log.Debugf("language alias: %s = %s", m.Language, l)
+		d.aliases[strings.ToLower(l)] = m.Language
+	}
+}
+
+// startDriverPool creates and starts a driver pool for the given language and
+// installed image, without registering it.
+func (d *Daemon) startDriverPool(rctx context.Context, language string, image *runtime.InstalledDriver) (*DriverPool, error) {
 	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.pool.newDriverPool")
 	defer sp.Finish()
 
@@ -240,7 +708,7 @@ func (d *Daemon) newDriverPool(rctx context.Context, language string, aliases []
 
 		log.Debugf("spawning driver instance %q ...", imageName)
 
-		opts := d.getDriverInstanceOptions()
+		opts := d.getDriverInstanceOptions(language)
 		driver, err := NewDriverInstance(d.runtime, language, image, opts)
 		if err != nil {
 			return nil, err
@@ -254,33 +722,191 @@ func (d *Daemon) newDriverPool(rctx context.Context, language string, aliases []
 		return driver, nil
 	})
 	dp.SetLabels(labels)
+	dp.digest = image.Status.Digest.String()
+	dp.language = image.Status.Manifest.Language
+	dp.ScalingPolicy, dp.RecyclePolicy = d.scalingPolicy(language)
 
 	if err := dp.Start(ctx); err != nil {
 		return nil, err
 	}
 
-	d.pool[language] = dp
-	for _, l := range aliases {
-		log.Debugf("language alias: %s = %s", language, l)
-		d.aliases[strings.ToLower(l)] = language
-	}
 	return dp, nil
 }
 
-func (d *Daemon) removePool(language string) error {
+// restartPool replaces the running driver pool for a version of the driver
+// with a new one running the given image. The new pool is started first, and
+// the old one is drained afterwards, so requests already waiting on it are
+// still served. If no pool is running, it will be created on the first
+// request.
+func (d *Daemon) restartPool(ctx context.Context, image *runtime.InstalledDriver) error {
+	m := image.Status.Manifest
+	d.mu.RLock()
+	key := d.poolKey(m.Language, m.Version)
+	old, ok := d.pool[key]
+	d.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	dp, err := d.startDriverPool(ctx, m.Language, image)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.setPool(key, m, dp)
+	d.mu.Unlock()
+
+	dctx, cancel := context.WithTimeout(context.Background(), poolDrainTimeout)
+	defer cancel()
+	if err := old.Drain(dctx); err != nil && !ErrPoolClosed.Is(err) {
+		log.Errorf(err, "error stopping the old driver pool for %s", driverKey(m.Language, m.Version))
+	}
+	return nil
+}
+
+// removePool stops the pool running the given version of the driver.
+func (d *Daemon) removePool(language, version string) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	dp, ok := d.pool[language]
+	key := d.poolKey(language, version)
+	dp, ok := d.pool[key]
 	if !ok {
 		return nil
 	}
 	if err := dp.Stop(); err != nil && !ErrPoolClosed.Is(err) {
 		return err
 	}
-	delete(d.pool, language)
+	delete(d.pool, key)
+	if key == language {
+		delete(d.current, language)
+	}
+	return nil
+}
+
+// scalingPolicy returns a new instance of the scaling policy for the language,
+// and its recycling policy.
+func (d *Daemon) scalingPolicy(language string) (ScalingPolicy, RecyclePolicy) {
+	d.configMu.RLock()
+	defer d.configMu.RUnlock()
+	return d.scaling.Policy(language), d.scaling.Recycle(language)
+}
+
+// LoadScalingConfig loads the scaling configuration file and applies its
+// scaling and recycling policies to the running driver pools and to the pools
+// started afterwards.
+func (d *Daemon) LoadScalingConfig(path string) error {
+	c, err := LoadScalingConfig(path)
+	if err != nil {
+		return err
+	}
+
+	d.configMu.Lock()
+	d.scaling = c
+	d.configMu.Unlock()
+	d.scalingFile.set(path)
+
+	for key, dp := range d.Current() {
+		language := strings.SplitN(key, "@", 2)[0]
+		dp.SetScalingPolicy(c.Policy(language))
+		dp.SetRecyclePolicy(c.Recycle(language))
+	}
+	return nil
+}
+
+// ReloadScalingConfig reads again the scaling configuration file loaded by
+// LoadScalingConfig. The previous configuration is kept if the file is not
+// valid.
+func (d *Daemon) ReloadScalingConfig() error {
+	return d.scalingFile.reload(ErrNoScalingConfig, d.LoadScalingConfig)
+}
+
+// LoadResourcesConfig loads the resources configuration file. The limits apply
+// to the driver instances started afterwards.
+func (d *Daemon) LoadResourcesConfig(path string) error {
+	c, err := LoadResourcesConfig(path)
+	if err != nil {
+		return err
+	}
+
+	d.configMu.Lock()
+	d.resources = c
+	d.configMu.Unlock()
+	d.resourcesFile.set(path)
+	return nil
+}
+
+// ReloadResourcesConfig reads again the resources configuration file loaded by
+// LoadResourcesConfig. The previous configuration is kept if the file is not
+// valid.
+func (d *Daemon) ReloadResourcesConfig() error {
+	return d.resourcesFile.reload(ErrNoResourcesConfig, d.LoadResourcesConfig)
+}
+
+// Prewarm starts the driver pools of the current version of the drivers for
+// the given languages, or for all the installed drivers if the list is empty.
+// It returns once each pool runs the minimal number of instances of its
+// scaling policy.
+func (d *Daemon) Prewarm(ctx context.Context, languages []string) error {
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return err
+	}
+
+	var drivers []*runtime.DriverImageStatus
+	if len(languages) == 0 {
+		drivers = list
+	}
+	for _, lang := range languages {
+		found := driversWithLang(lang, list)
+		if len(found) == 0 {
+			return &ErrMissingDriver{lang}
+		}
+		drivers = append(drivers, found...)
+	}
+
+	pools := make(map[string]*DriverPool)
+	for _, dr := range drivers {
+		lang := dr.Manifest.Language
+		if !dr.Current || pools[lang] != nil {
+			continue
+		}
+		log.Infof("prewarming driver pool for %s", lang)
+		dp, err := d.DriverPool(ctx, lang, "")
+		if err != nil {
+			return err
+		}
+		pools[lang] = dp
+	}
+
+	for lang, dp := range pools {
+		d.configMu.RLock()
+		n := d.scaling.MinInstances(lang)
+		d.configMu.RUnlock()
+		if err := dp.WaitInstances(ctx, n); err != nil {
+			return err
+		}
+		log.Infof("driver pool for %s is running %d instances", lang, n)
+	}
 	return nil
 }
 
+// SetReady sets if the daemon is ready to serve requests, as reported by the
+// health service. It is ready unless set otherwise.
+func (d *Daemon) SetReady(ready bool) {
+	var v int32
+	if !ready {
+		v = 1
+	}
+	atomic.StoreInt32(&d.notReady, v)
+}
+
+// Ready reports if the daemon is ready to serve requests. It is never ready
+// once it starts shutting down.
+func (d *Daemon) Ready() bool {
+	return atomic.LoadInt32(&d.notReady) == 0 && atomic.LoadInt32(&d.stopping) == 0
+}
+
 // Current returns the current list of driver pools.
 func (d *Daemon) Current() map[string]*DriverPool {
 	d.mu.RLock()
@@ -294,6 +920,54 @@ func (d *Daemon) Current() map[string]*DriverPool {
 	return out
 }
 
+// Shutdown stops the daemon gracefully: the servers stop accepting new
+// requests and wait for the ones in flight to complete, and then the pools are
+// drained. If the context is cancelled first, the remaining requests are
+// cancelled and the pools are stopped right away.
+func (d *Daemon) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&d.stopping, 1)
+
+	var wg sync.WaitGroup
+	for _, srv := range []*grpc.Server{d.UserServer, d.ControlServer} {
+		srv := srv
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-ctx.Done():
+				srv.Stop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		mu   sync.Mutex
+		last error
+	)
+	for key, dp := range d.Current() {
+		key, dp := key, dp
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dp.Drain(ctx); err != nil && !ErrPoolClosed.Is(err) {
+				log.Errorf(err, "error stopping the driver pool for %s", key)
+				mu.Lock()
+				last = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return last
+}
+
 // Stop stops all the pools and containers.
 func (d *Daemon) Stop() error {
 	d.mu.Lock()
@@ -308,8 +982,12 @@ func (d *Daemon) Stop() error {
 	return err
 }
 
-func (d *Daemon) getDriverInstanceOptions() *Options {
-	opts := &Options{Env: d.driverEnv}
+func (d *Daemon) getDriverInstanceOptions(language string) *Options {
+	d.configMu.RLock()
+	limits := d.resources.Limits(language)
+	d.configMu.RUnlock()
+
+	opts := &Options{Env: d.driverEnv, Limits: limits}
 	opts.LogLevel = log.DefaultLevel
 	opts.LogFormat = "text"
 
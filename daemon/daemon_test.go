@@ -38,11 +38,11 @@ func TestDaemon_InstallDriver(t *testing.T) {
 	s, tmp := buildMockedDaemon(t)
 	defer os.RemoveAll(tmp)
 
-	err := s.InstallDriver("go", "docker://bblfsh/go-driver:latest", false)
+	err := s.InstallDriver("go", "docker://bblfsh/go-driver:latest", false, nil, nil)
 	require.Nil(err)
-	err = s.InstallDriver("go", "docker://bblfsh/go-driver:latest", false)
+	err = s.InstallDriver("go", "docker://bblfsh/go-driver:latest", false, nil, nil)
 	require.True(ErrAlreadyInstalled.Is(err))
-	err = s.InstallDriver("go", "docker://bblfsh/go-driver:latest", true)
+	err = s.InstallDriver("go", "docker://bblfsh/go-driver:latest", true, nil, nil)
 	require.Nil(err)
 }
 
@@ -51,7 +51,7 @@ func TestDaemon_InstallNonexistentDriver(t *testing.T) {
 	s, tmp := buildMockedDaemon(t)
 	defer os.RemoveAll(tmp)
 
-	err := s.InstallDriver("", "docker://list", false)
+	err := s.InstallDriver("", "docker://list", false, nil, nil)
 	require.Error(err, "An error was expected")
 	require.Equal("errcode.Errors", reflect.TypeOf(err).String())
 }
@@ -110,6 +110,44 @@ func TestDaemonParse_MockedDriverParallelClients(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDaemonShutdown(t *testing.T) {
+	require := require.New(t)
+
+	d, tmp := buildMockedDaemon(t)
+	defer os.RemoveAll(tmp)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(err)
+	served := make(chan error, 1)
+	go func() {
+		served <- d.UserServer.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithTimeout(2*time.Second),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	client := protocol.NewProtocolServiceClient(conn)
+	resp, err := client.Parse(context.TODO(), &protocol.ParseRequest{Content: "# -*- python -*-"})
+	require.NoError(err)
+	require.Equal(protocol.Ok, resp.Status)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = d.Shutdown(ctx)
+	require.NoError(err)
+	require.NoError(<-served)
+	require.False(d.Ready())
+	require.False(d.Current()["python"].Serving())
+
+	_, err = client.Parse(context.TODO(), &protocol.ParseRequest{Content: "# -*- python -*-"})
+	require.Error(err)
+}
+
 func buildMockedDaemon(t *testing.T, images ...runtime.DriverImage) (*Daemon, string) {
 	require := require.New(t)
 
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"google.golang.org/grpc"
 	protocol1 "gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/src-d/go-log.v1"
 )
 
 type Driver interface {
@@ -39,29 +41,53 @@ type DriverInstance struct {
 	Container runtime.Container
 	Image     runtime.DriverImage
 
-	ctx  context.Context
-	conn *grpc.ClientConn
-	srv1 protocol1.ProtocolServiceClient
-	srv2 protocol2.DriverClient
-	tmp  string
+	ctx    context.Context
+	conn   *grpc.ClientConn
+	srv1   protocol1.ProtocolServiceClient
+	srv2   protocol2.DriverClient
+	tmp    string
+	limits runtime.ContainerLimits
+	// oom is closed when the cgroup kills a process of the container because
+	// it ran out of memory, nil if the container has no memory limit.
+	oom chan struct{}
+
+	// health is the result of the health checks of the instance.
+	health struct {
+		sync.Mutex
+		failures int
+		last     time.Time
+		err      string
+	}
 }
 
 const (
 	DriverBinary   = "/opt/driver/bin/driver"
 	GRPCSocket     = "rpc.sock"
 	TmpPathPattern = "/tmp/%s"
+	// SocketDir is the directory of the socket of the driver inside of its
+	// container when /tmp is a tmpfs.
+	SocketDir = "/tmp/.bblfshd"
 )
 
+// oomNotifyDelay is the maximal amount of time to wait for the OOM notification
+// of a container after the driver stopped.
+const oomNotifyDelay = 100 * time.Millisecond
+
 type Options struct {
 	LogLevel  string
 	LogFormat string
 	Env       []string
+	// Limits are the resource limits of the container.
+	Limits runtime.ContainerLimits
 }
 
 // NewDriverInstance represents a running Driver in the runtime. Its holds the
 // container and the connection to the internal grpc server.
 func NewDriverInstance(r *runtime.Runtime, lang string, i runtime.DriverImage, o *Options) (*DriverInstance, error) {
 	id := strings.ToLower(runtime.NewULID().String())
+	tmp := filepath.Join(r.Root, fmt.Sprintf(TmpPathPattern, id))
+	socketDir := containerSocketDir(o.Limits)
+
 	p := &runtime.Process{
 		Args: []string{
 			DriverBinary,
@@ -69,7 +95,7 @@ func NewDriverInstance(r *runtime.Runtime, lang string, i runtime.DriverImage, o
 			"--log-format", o.LogFormat,
 			"--log-fields", logFields(id, lang),
 			"--network", "unix",
-			"--address", fmt.Sprintf(TmpPathPattern, GRPCSocket),
+			"--address", filepath.Join(socketDir, GRPCSocket),
 		},
 		Env:    o.Env,
 		Stdout: os.Stdout,
@@ -77,21 +103,8 @@ func NewDriverInstance(r *runtime.Runtime, lang string, i runtime.DriverImage, o
 		Init:   true,
 	}
 
-	tmp := filepath.Join(r.Root, fmt.Sprintf(TmpPathPattern, id))
-
 	f := func(containerID string) *configs.Config {
-		cfg := runtime.ContainerConfigFactory(containerID)
-		cfg.Mounts = append(cfg.Mounts, &configs.Mount{
-			Source:      tmp,
-			Destination: "/tmp/",
-			Device:      "bind",
-			Flags:       syscall.MS_BIND | syscall.MS_REC | syscall.MS_NOSUID,
-			PremountCmds: []configs.Command{
-				{Path: "mkdir", Args: []string{"-p", tmp}},
-			},
-		})
-
-		return cfg
+		return containerConfig(containerID, tmp, o.Limits)
 	}
 
 	c, err := r.Container(id, i, p, f)
@@ -105,8 +118,9 @@ func NewDriverInstance(r *runtime.Runtime, lang string, i runtime.DriverImage, o
 		Container: c,
 		Image:     i,
 
-		ctx: context.Background(),
-		tmp: tmp,
+		ctx:    context.Background(),
+		tmp:    tmp,
+		limits: o.Limits,
 	}, nil
 }
 
@@ -121,18 +135,102 @@ func (i *DriverInstance) Start(ctx context.Context) error {
 		return err
 	}
 
+	if i.limits.Memory > 0 {
+		i.notifyOOM()
+	}
+
 	if err := i.dial(ctx); err != nil {
 		_ = i.Container.Stop()
 		return err
 	}
 
-	if err := i.loadVersion(); err != nil {
+	if err := i.loadVersion(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// notifyOOM starts watching the OOM notifications of the container cgroup.
+func (i *DriverInstance) notifyOOM() {
+	ch, err := i.Container.NotifyOOM()
+	if err != nil {
+		log.Warningf("cannot watch OOM notifications of driver %s: %s", i.ID(), err)
+		return
+	}
+
+	i.oom = make(chan struct{})
+	go func() {
+		if _, ok := <-ch; ok {
+			close(i.oom)
+		}
+	}()
+}
+
+// OOMKilled reports if the container was killed by the cgroup because it
+// exceeded its memory limit.
+func (i *DriverInstance) OOMKilled() bool {
+	if i.oom == nil {
+		return false
+	}
+	select {
+	case <-i.oom:
+		return true
+	default:
+	}
+
+	if status, err := i.Status(); err == nil && status == protocol.Running {
+		return false
+	}
+
+	// the notification may arrive after the process exits
+	select {
+	case <-i.oom:
+		return true
+	case <-time.After(oomNotifyDelay):
+		return false
+	}
+}
+
+// containerSocketDir returns the directory of the socket of the driver inside
+// of a container with the given limits.
+func containerSocketDir(limits runtime.ContainerLimits) string {
+	if limits.TmpfsSize > 0 {
+		return SocketDir
+	}
+	return "/tmp"
+}
+
+// containerConfig returns the configuration of the container of a driver. The
+// directory tmp of the host holds the socket of the driver, and is its /tmp.
+// If the limits set a tmpfs size, /tmp is instead a tmpfs of that size, so the
+// files written by the driver are limited, and tmp is mounted in SocketDir.
+func containerConfig(containerID, tmp string, limits runtime.ContainerLimits) *configs.Config {
+	cfg := runtime.ContainerConfigFactory(containerID)
+	if limits.TmpfsSize > 0 {
+		cfg.Mounts = append(cfg.Mounts, &configs.Mount{
+			Source:      "tmpfs",
+			Destination: "/tmp",
+			Device:      "tmpfs",
+			Flags:       syscall.MS_NOSUID | syscall.MS_NODEV,
+			Data:        "mode=1777",
+		})
+	}
+
+	cfg.Mounts = append(cfg.Mounts, &configs.Mount{
+		Source:      tmp,
+		Destination: containerSocketDir(limits),
+		Device:      "bind",
+		Flags:       syscall.MS_BIND | syscall.MS_REC | syscall.MS_NOSUID,
+		PremountCmds: []configs.Command{
+			{Path: "mkdir", Args: []string{"-p", tmp}},
+		},
+	})
+	limits.Apply(cfg)
+
+	return cfg
+}
+
 func (i *DriverInstance) dial(ctx context.Context) error {
 	addr := filepath.Join(i.tmp, GRPCSocket)
 
@@ -161,8 +259,8 @@ func (i *DriverInstance) dial(ctx context.Context) error {
 	return err
 }
 
-func (i *DriverInstance) loadVersion() error {
-	_, err := i.srv1.Version(context.Background(), &protocol1.VersionRequest{})
+func (i *DriverInstance) loadVersion(ctx context.Context) error {
+	_, err := i.srv1.Version(ctx, &protocol1.VersionRequest{})
 	if err != nil {
 		return err
 	}
@@ -170,6 +268,24 @@ func (i *DriverInstance) loadVersion() error {
 	return nil
 }
 
+// CheckHealth checks that the driver answers a version request. It returns the
+// number of consecutive failed checks.
+func (i *DriverInstance) CheckHealth(ctx context.Context) (int, error) {
+	err := i.loadVersion(ctx)
+
+	i.health.Lock()
+	defer i.health.Unlock()
+	i.health.last = time.Now()
+	if err != nil {
+		i.health.failures++
+		i.health.err = err.Error()
+	} else {
+		i.health.failures = 0
+		i.health.err = ""
+	}
+	return i.health.failures, err
+}
+
 // Status returns the current status of the container.
 func (i *DriverInstance) Status() (protocol.Status, error) {
 	s, err := i.Container.Status()
@@ -193,12 +309,17 @@ func (i *DriverInstance) State() (*protocol.DriverInstanceState, error) {
 		return nil, err
 	}
 
+	i.health.Lock()
+	defer i.health.Unlock()
 	return &protocol.DriverInstanceState{
-		ID:        i.ID(),
-		Image:     i.Image.Name(),
-		Status:    status,
-		Processes: pid,
-		Created:   state.Created,
+		ID:              i.ID(),
+		Image:           i.Image.Name(),
+		Status:          status,
+		Processes:       pid,
+		Created:         state.Created,
+		HealthFailures:  i.health.failures,
+		LastHealthCheck: i.health.last,
+		HealthError:     i.health.err,
 	}, nil
 }
 
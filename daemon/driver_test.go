@@ -10,6 +10,7 @@ import (
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/bblfshd/runtime"
 
+	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -111,3 +112,27 @@ func IfNetworking(t *testing.T) {
 
 	t.Skip("skipping network test use TEST_NETWORKING to run this test")
 }
+
+func TestContainerConfig_Tmpfs(t *testing.T) {
+	require := require.New(t)
+
+	mounts := func(cfg *configs.Config) map[string]*configs.Mount {
+		out := make(map[string]*configs.Mount)
+		for _, m := range cfg.Mounts {
+			out[m.Destination] = m
+		}
+		return out
+	}
+
+	// /tmp is a directory of the host, holding the socket
+	m := mounts(containerConfig("foo", "/var/lib/bblfshd/tmp/foo", runtime.ContainerLimits{}))
+	require.Equal("bind", m["/tmp"].Device)
+	require.Equal("/var/lib/bblfshd/tmp/foo", m["/tmp"].Source)
+
+	// /tmp is a tmpfs of the given size, and the socket is in a directory of it
+	m = mounts(containerConfig("foo", "/var/lib/bblfshd/tmp/foo", runtime.ContainerLimits{TmpfsSize: 1 << 20}))
+	require.Equal("tmpfs", m["/tmp"].Device)
+	require.Equal("mode=1777,size=1048576", m["/tmp"].Data)
+	require.Equal("bind", m[SocketDir].Device)
+	require.Equal("/var/lib/bblfshd/tmp/foo", m[SocketDir].Source)
+}
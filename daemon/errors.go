@@ -1,8 +1,15 @@
 package daemon
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/sdk/v3/driver"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/go-errors.v1"
 )
 
@@ -14,9 +21,15 @@ var (
 	// ErrAlreadyInstalled indicates that a driver image was already installed
 	// from the reference for the given language.
 	ErrAlreadyInstalled = protocol.ErrAlreadyInstalled
+	// ErrImageVerification indicates that a driver image failed the
+	// verification required by the image policy, and was not installed.
+	ErrImageVerification = protocol.ErrImageVerification
 	// ErrUnauthorized indicates that image registry access failed
 	// and it either requires authentication or does not exist.
 	ErrUnauthorized = errors.NewKind("unauthorized: authentication required to access %s (image: %s)")
+	// ErrDriverValidation indicates that a new driver image failed to start
+	// or to answer requests, and was not installed.
+	ErrDriverValidation = errors.NewKind("driver image %s failed validation")
 	// ErrLanguageDetection indicates that language was not detected by Enry.
 	ErrLanguageDetection = driver.ErrLanguageDetection
 	// ErrUnknownEncoding is returned for parse requests with a file content in a non-UTF8 encoding.
@@ -26,3 +39,85 @@ var (
 // ErrMissingDriver indicates that a driver image for the given language
 // can not be found.
 type ErrMissingDriver = driver.ErrMissingDriver
+
+// ErrDriverOOMKilled indicates that the driver instance serving a request was
+// killed by its cgroup for exceeding the memory limit of the container.
+type ErrDriverOOMKilled struct {
+	// ID of the driver instance.
+	ID string
+}
+
+func (e *ErrDriverOOMKilled) Error() string {
+	return fmt.Sprintf("driver instance %s was killed: out of memory", e.ID)
+}
+
+// GRPCStatus returns the status of the error, used by the gRPC server.
+func (e *ErrDriverOOMKilled) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
+// ErrUnknownPriority is returned for requests with an unknown priority set in
+// their gRPC metadata.
+type ErrUnknownPriority struct {
+	// Priority requested.
+	Priority string
+}
+
+func (e *ErrUnknownPriority) Error() string {
+	return fmt.Sprintf("unknown request priority: %q", e.Priority)
+}
+
+// GRPCStatus returns the status of the error, used by the gRPC server.
+func (e *ErrUnknownPriority) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// ErrRateLimited is returned for requests exceeding the rate limits of their
+// client.
+type ErrRateLimited struct {
+	// Client is the identity of the client.
+	Client string
+	// Reason is the limit exceeded: rate or concurrency.
+	Reason string
+	// RetryAfter is the time after which the request may be allowed.
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	client := e.Client
+	if client == "" {
+		client = "unknown"
+	}
+	return fmt.Sprintf("%s limit exceeded for client %s, retry after %v",
+		e.Reason, client, e.RetryAfter.Round(time.Millisecond))
+}
+
+// GRPCStatus returns the status of the error, used by the gRPC server. The
+// retry hint is sent as a RetryInfo detail.
+func (e *ErrRateLimited) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	if d, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: ptypes.DurationProto(e.RetryAfter),
+	}); err == nil {
+		st = d
+	}
+	return st
+}
+
+// ErrPermissionDenied is returned for control requests the caller is not
+// allowed to send by the control policy.
+type ErrPermissionDenied struct {
+	// Caller is the identity of the caller.
+	Caller string
+	// RPC is the name of the control RPC.
+	RPC string
+}
+
+func (e *ErrPermissionDenied) Error() string {
+	return fmt.Sprintf("caller %s is not allowed to call %s", e.Caller, e.RPC)
+}
+
+// GRPCStatus returns the status of the error, used by the gRPC server.
+func (e *ErrPermissionDenied) GRPCStatus() *status.Status {
+	return status.New(codes.PermissionDenied, e.Error())
+}
@@ -0,0 +1,159 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var _ grpc_health_v1.HealthServer = (*HealthService)(nil)
+
+// healthWatchInterval is how often the serving status is evaluated for the
+// clients watching it.
+var healthWatchInterval = time.Second
+
+// HealthService implements the gRPC health checking protocol.
+//
+// The empty service name and the names of the services registered on the
+// server report the status of the daemon: it is serving once it is ready (see
+// Daemon.Ready). Any other name is a language, optionally followed by
+// "@version", and reports the status of the pool of its driver.
+type HealthService struct {
+	daemon *Daemon
+	server *grpc.Server
+}
+
+// NewHealthService creates a health service for the given server of the
+// daemon.
+func NewHealthService(d *Daemon, s *grpc.Server) *HealthService {
+	return &HealthService{daemon: d, server: s}
+}
+
+// Check implements grpc_health_v1.HealthServer.
+func (s *HealthService) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	st, ok := s.servingStatus(req.Service)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch implements grpc_health_v1.HealthServer. It sends the serving status
+// of the service each time it changes, until the client cancels the stream.
+func (s *HealthService) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := s.servingStatus(req.Service)
+		if !ok {
+			st = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if st != last {
+			err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: st})
+			if err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-ticker.C:
+		}
+	}
+}
+
+// servingStatus returns the serving status of the service, and false if the
+// service is unknown.
+func (s *HealthService) servingStatus(service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	if service == "" {
+		return s.daemon.servingStatus(), true
+	}
+	if _, ok := s.server.GetServiceInfo()[service]; ok {
+		return s.daemon.servingStatus(), true
+	}
+	return s.daemon.languageStatus(service)
+}
+
+// servingStatus returns the serving status of the daemon.
+func (d *Daemon) servingStatus() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if !d.Ready() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// languageStatus returns the serving status of the driver for the language,
+// given as "language" or "language@version" like in parse requests. A driver that is installed but
+// not running yet is serving, since its pool is started on the first request.
+// It returns false if the driver is not installed.
+func (d *Daemon) languageStatus(service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	language, version := splitVersion(service)
+	language = normalize(language)
+
+	if dp, ok := d.runningPool(language, version); ok {
+		if !dp.Serving() {
+			return grpc_health_v1.HealthCheckResponse_NOT_SERVING, true
+		}
+		return grpc_health_v1.HealthCheckResponse_SERVING, true
+	}
+
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, true
+	}
+	if driverWithVersion(language, version, list) == nil {
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING, true
+}
+
+// HealthHandler returns an HTTP handler serving the liveness probe of the
+// daemon on /healthz and its readiness probe on /readyz. Both list the serving
+// status of the running driver pools.
+func (d *Daemon) HealthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		d.writeHealth(w, http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		code := http.StatusOK
+		if d.servingStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			code = http.StatusServiceUnavailable
+		}
+		d.writeHealth(w, code)
+	})
+	return mux
+}
+
+func (d *Daemon) writeHealth(w http.ResponseWriter, code int) {
+	pools := d.Current()
+	keys := make([]string, 0, len(pools))
+	for key := range pools {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "bblfshd: %s\n", d.servingStatus())
+	for _, key := range keys {
+		st := grpc_health_v1.HealthCheckResponse_SERVING
+		if !pools[key].Serving() {
+			st = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		fmt.Fprintf(w, "%s: %s\n", key, st)
+	}
+}
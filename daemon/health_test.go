@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthService_Check(t *testing.T) {
+	require := require.New(t)
+
+	d, tmp := buildMockedDaemon(t)
+	defer os.RemoveAll(tmp)
+	defer d.Stop()
+
+	s := NewHealthService(d, d.UserServer)
+	check := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := s.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(err)
+		return resp.Status
+	}
+
+	require.Equal(grpc_health_v1.HealthCheckResponse_SERVING, check(""))
+	require.Equal(grpc_health_v1.HealthCheckResponse_SERVING, check("gopkg.in.bblfsh.sdk.v2.protocol.Driver"))
+	require.Equal(grpc_health_v1.HealthCheckResponse_SERVING, check("python"))
+	require.Equal(grpc_health_v1.HealthCheckResponse_SERVING, check("Python"))
+
+	_, err := s.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "cobol"})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	d.SetReady(false)
+	require.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING, check(""))
+	require.Equal(grpc_health_v1.HealthCheckResponse_SERVING, check("python"))
+
+	err = d.Current()["python"].Stop()
+	require.NoError(err)
+	require.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING, check("python"))
+	require.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING, check("Python"))
+}
+
+func TestDaemon_HealthHandler(t *testing.T) {
+	require := require.New(t)
+
+	d, tmp := buildMockedDaemon(t)
+	defer os.RemoveAll(tmp)
+	defer d.Stop()
+
+	srv := httptest.NewServer(d.HealthHandler())
+	defer srv.Close()
+
+	get := func(path string) int {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(http.StatusOK, get("/healthz"))
+	require.Equal(http.StatusOK, get("/readyz"))
+
+	d.SetReady(false)
+	require.Equal(http.StatusOK, get("/healthz"))
+	require.Equal(http.StatusServiceUnavailable, get("/readyz"))
+}
@@ -33,3 +33,13 @@ func normalize(languageName string) string {
 	lang = strings.Replace(lang, "#", "sharp", -1)
 	return lang
 }
+
+// splitVersion splits a language of the form "language@version", used to ask
+// for a specific version of the driver instead of the current one.
+func splitVersion(language string) (string, string) {
+	i := strings.LastIndex(language, "@")
+	if i < 0 {
+		return language, ""
+	}
+	return language[:i], language[i+1:]
+}
@@ -49,3 +49,19 @@ func TestGetLanguage(t *testing.T) {
 	}
 	`)))
 }
+
+func TestSplitVersion(t *testing.T) {
+	require := require.New(t)
+
+	lang, version := splitVersion("python")
+	require.Equal("python", lang)
+	require.Equal("", version)
+
+	lang, version = splitVersion("python@v2.9.0")
+	require.Equal("python", lang)
+	require.Equal("v2.9.0", version)
+
+	lang, version = splitVersion("@v2.9.0")
+	require.Equal("", lang)
+	require.Equal("v2.9.0", version)
+}
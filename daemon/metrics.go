@@ -24,6 +24,12 @@ var (
 
 var (
 	driverLabelNames = []string{"lang", "image"}
+	// driverReasonLabelNames are the labels of the driver metrics counting
+	// events that can happen for several reasons.
+	driverReasonLabelNames = []string{"lang", "image", "reason"}
+	// driverPriorityLabelNames are the labels of the driver metrics of the
+	// requests of each priority.
+	driverPriorityLabelNames = []string{"lang", "image", "priority"}
 )
 
 // Driver Control API metrics
@@ -36,6 +42,46 @@ var (
 		Name: "bblfshd_driver_remove_total",
 		Help: "The total number of calls to remove a driver",
 	})
+	driverExportCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_driver_export_total",
+		Help: "The total number of calls to export a driver",
+	})
+	driverImportCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_driver_import_total",
+		Help: "The total number of calls to import a driver",
+	})
+	driverGCCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_driver_gc_total",
+		Help: "The total number of calls to garbage collect the driver storage",
+	})
+	controlDenied = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_control_denied_total",
+		Help: "The total number of control requests denied by the control policy",
+	}, []string{"rpc"})
+)
+
+// Driver update metrics
+var (
+	driverUpdateChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_update_checks_total",
+		Help: "The total number of checks of the updates of the installed drivers",
+	}, []string{"lang"})
+	driverUpdateCheckErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_update_check_errors",
+		Help: "The total number of failed checks of the updates of the installed drivers",
+	}, []string{"lang"})
+	driverUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_updates_total",
+		Help: "The total number of driver updates installed",
+	}, []string{"lang"})
+	driverUpdateErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_update_errors",
+		Help: "The total number of driver updates that failed to install",
+	}, []string{"lang"})
+	driverUpdatesAvailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bblfshd_driver_update_available",
+		Help: "Whether an update is available and not installed for the driver image (1) or not (0)",
+	}, driverLabelNames)
 )
 
 // Public API metrics
@@ -80,6 +126,47 @@ var (
 	parseErrorsV2      = parseErrors.WithLabelValues("v2")
 	parseLatencyV2     = parseLatency.WithLabelValues("v2")
 	parseContentSizeV2 = parseContentSize.WithLabelValues("v2")
+
+	parseBatchCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_parse_batch_total",
+		Help: "The total number of parse batch streams",
+	})
+	parseBatchInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "bblfshd_parse_batch_in_flight",
+		Help: "The number of files from parse batch streams being parsed",
+	})
+	parseBatchThrottled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_parse_batch_throttled",
+		Help: "The total number of times a parse batch stream waited for an overloaded pool",
+	})
+)
+
+// Rate limits metrics
+var (
+	requestsRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_rate_limited_total",
+		Help: "The total number of requests rejected by the rate limits, by language and limit exceeded",
+	}, []string{"lang", "reason"})
+)
+
+// Parse cache metrics
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_cache_hits",
+		Help: "The total number of parse responses served from the cache",
+	}, []string{"tier"})
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bblfshd_cache_misses",
+		Help: "The total number of parse requests not found in the cache",
+	})
+	cacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_cache_evictions",
+		Help: "The total number of parse responses evicted from the cache",
+	}, []string{"tier"})
+	cacheSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bblfshd_cache_bytes",
+		Help: "Size of the parse responses stored in the cache",
+	}, []string{"tier"})
 )
 
 // Scaling metrics
@@ -87,7 +174,7 @@ var (
 	driversSpawned = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bblfshd_driver_spawn",
 		Help: "The total number of driver spawn requests",
-	}, driverLabelNames)
+	}, driverReasonLabelNames)
 	driversSpawnErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bblfshd_driver_spawn_errors",
 		Help: "The total number of errors for driver spawn requests",
@@ -95,6 +182,14 @@ var (
 	driversKilled = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bblfshd_driver_kill",
 		Help: "The total number of driver kill requests",
+	}, driverReasonLabelNames)
+	driversOOMKilled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_oom_kill",
+		Help: "The total number of drivers killed for exceeding their memory limit",
+	}, driverLabelNames)
+	driversHealthFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bblfshd_driver_health_check_failures",
+		Help: "The total number of failed health checks of idle drivers",
 	}, driverLabelNames)
 
 	driversRunning = promauto.NewGaugeVec(prometheus.GaugeOpts{
@@ -113,4 +208,12 @@ var (
 		Name: "bblfshd_driver_scaling_target",
 		Help: "The target number of drivers instances",
 	}, driverLabelNames)
+	driversQueued = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bblfshd_driver_scaling_queue",
+		Help: "The number of requests of each priority queued for a driver",
+	}, driverPriorityLabelNames)
+	driversQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "bblfshd_driver_queue_seconds",
+		Help: "Time requests of each priority spent queued for a driver (seconds)",
+	}, driverPriorityLabelNames)
 )
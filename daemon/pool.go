@@ -46,6 +46,9 @@ var (
 
 const defaultPolicyTargetWindow = 5 // enough to prevent flickering
 
+// defaultExecuteTimeout is used by Execute if no timeout is specified.
+const defaultExecuteTimeout = 5 * time.Second
+
 var (
 	// policyDefaultWindow is a window for the average function used in the default scaling
 	// policy. The window will be divided by policyDefaultTick intervals to calculate the
@@ -62,6 +65,16 @@ var (
 	//
 	// See AIMD for more details.
 	policyDefaultDownscale = mustEnvFloat("BBLFSHD_POLICY_DOWNSCALE_MULT", 0.25)
+
+	// healthCheckInterval is the interval between the health checks of the idle driver
+	// instances. Health checks are disabled if it is zero.
+	healthCheckInterval = mustEnvDur("BBLFSHD_HEALTH_CHECK_INTERVAL", 10*time.Second)
+	// healthCheckTimeout is the maximal amount of time a driver instance may take to
+	// answer a health check.
+	healthCheckTimeout = mustEnvDur("BBLFSHD_HEALTH_CHECK_TIMEOUT", 5*time.Second)
+	// healthCheckFailures is the number of consecutive failed health checks after which
+	// a driver instance is killed and replaced.
+	healthCheckFailures = mustEnvInt("BBLFSHD_HEALTH_CHECK_FAILURES", 3)
 )
 
 func mustEnvInt(env string, def int) int {
@@ -104,13 +117,24 @@ func mustEnvDur(env string, def time.Duration) time.Duration {
 // ensuring each driver does not get concurrent requests. The number of driver
 // instances in the driver pool is controlled by a ScalingPolicy.
 type DriverPool struct {
-	// ScalingPolicy scaling policy used to scale up the instances.
+	// ScalingPolicy scaling policy used to scale up the instances. Use
+	// SetScalingPolicy to change it once the pool is running.
 	ScalingPolicy ScalingPolicy
+	// RecyclePolicy specifies when the instances are retired. Use
+	// SetRecyclePolicy to change it once the pool is running.
+	RecyclePolicy RecyclePolicy
+	// policyMu protects the ScalingPolicy and RecyclePolicy of a running pool.
+	policyMu sync.Mutex
 	// Logger used during the live of the driver pool.
 	Logger log.Logger
 
 	// factory function used to spawn new driver instances.
 	factory FactoryFunction
+	// digest of the driver image run by the pool, if known.
+	digest string
+	// language of the manifest of the driver image run by the pool, set
+	// along with digest.
+	language string
 
 	// wg tracks all goroutines owned by the driver pool.
 	wg sync.WaitGroup
@@ -124,9 +148,10 @@ type DriverPool struct {
 	// stopped is closed when the pool (and the manager goroutine) stops.
 	stopped chan struct{}
 
-	// get serves as a request-response channel to get an idle driver instance.
-	// This channel is used by clients and is accepted by the manager goroutine.
-	get chan driverRequest
+	// queue holds the client requests waiting for an idle driver instance.
+	// Requests are pushed by clients and are taken by the manager goroutine, in
+	// the order they should be served.
+	queue *requestQueue
 	// put returns the driver to the pool. The driver must be active.
 	put chan Driver
 
@@ -148,12 +173,17 @@ type DriverPool struct {
 		sync.RWMutex
 		idle map[Driver]struct{}
 		all  map[Driver]struct{}
+		info map[Driver]*driverInfo
 	}
 
 	requests   atomicInt // requests waiting for a driver
+	executing  atomicInt // requests waiting for a driver or being executed
 	running    atomicInt // total running instances; synced with len(drivers.all)
 	spawning   atomicInt // instances being started
 	targetSize atomicInt // instances wanted
+	surge      atomicInt // instances wanted to replace the ones about to be retired
+	replacing  atomicInt // replacement instances not spawned yet
+	failing    atomicInt // set while the instances fail to start
 
 	exited  atomicInt // drivers exited
 	success atomicInt // requests executed successfully
@@ -167,9 +197,17 @@ type DriverPool struct {
 			target prometheus.Gauge
 		}
 		spawn struct {
-			total prometheus.Counter
+			total *prometheus.CounterVec
 			err   prometheus.Counter
-			kill  prometheus.Counter
+			kill  *prometheus.CounterVec
+			oom   prometheus.Counter
+		}
+		health struct {
+			failed prometheus.Counter
+		}
+		queue struct {
+			depth *prometheus.GaugeVec
+			wait  prometheus.ObserverVec
 		}
 	}
 }
@@ -204,14 +242,21 @@ func (dp *DriverPool) SetLabels(labels []string) {
 		"image":    labels[1],
 	})
 
-	dp.metrics.spawn.total = driversSpawned.WithLabelValues(labels...)
+	curry := prometheus.Labels{"lang": labels[0], "image": labels[1]}
+	dp.metrics.spawn.total = driversSpawned.MustCurryWith(curry)
 	dp.metrics.spawn.err = driversSpawnErrors.WithLabelValues(labels...)
-	dp.metrics.spawn.kill = driversKilled.WithLabelValues(labels...)
+	dp.metrics.spawn.kill = driversKilled.MustCurryWith(curry)
+	dp.metrics.spawn.oom = driversOOMKilled.WithLabelValues(labels...)
+
+	dp.metrics.health.failed = driversHealthFailed.WithLabelValues(labels...)
 
 	dp.metrics.scaling.total = driversRunning.WithLabelValues(labels...)
 	dp.metrics.scaling.idle = driversIdle.WithLabelValues(labels...)
 	dp.metrics.scaling.load = driversRequests.WithLabelValues(labels...)
 	dp.metrics.scaling.target = driversTarget.WithLabelValues(labels...)
+
+	dp.metrics.queue.depth = driversQueued.MustCurryWith(curry)
+	dp.metrics.queue.wait = driversQueueWait.MustCurryWith(curry)
 }
 
 // Start stats the driver pool.
@@ -238,10 +283,11 @@ func (dp *DriverPool) Start(ctx context.Context) error {
 	dp.stopped = make(chan struct{})
 	dp.spawn = make(chan struct{})
 	dp.spawnErr = make(chan error)
-	dp.get = make(chan driverRequest)
+	dp.queue = newRequestQueue()
 	dp.put = make(chan Driver)
 	dp.drivers.idle = make(map[Driver]struct{})
 	dp.drivers.all = make(map[Driver]struct{})
+	dp.drivers.info = make(map[Driver]*driverInfo)
 
 	dp.targetSize.Set(1)
 
@@ -259,6 +305,13 @@ func (dp *DriverPool) Start(ctx context.Context) error {
 		defer dp.wg.Done()
 		dp.manageDrivers()
 	}()
+	if healthCheckInterval > 0 {
+		dp.wg.Add(1)
+		go func() {
+			defer dp.wg.Done()
+			dp.runHealthCheck(dp.poolCtx)
+		}()
+	}
 
 	// wait for a single instance to come up
 	d, err := dp.getDriver(ctx)
@@ -291,7 +344,9 @@ func (dp *DriverPool) runPolicy(ctx context.Context) {
 		idle := len(dp.drivers.idle)
 		dp.drivers.RUnlock()
 
+		dp.policyMu.Lock()
 		target := dp.ScalingPolicy.Scale(total, idle, load)
+		dp.policyMu.Unlock()
 		if target < 1 {
 			// there should be always at least 1 instance
 			// TODO(dennwc): policies must never return 0 instances
@@ -302,6 +357,9 @@ func (dp *DriverPool) runPolicy(ctx context.Context) {
 			dp.metrics.scaling.load.Set(float64(load))
 			dp.metrics.scaling.idle.Set(float64(idle))
 			dp.metrics.scaling.target.Set(float64(target))
+			for _, p := range priorities {
+				dp.metrics.queue.depth.WithLabelValues(string(p)).Set(float64(dp.queue.Depth(p)))
+			}
 		}
 		old := dp.targetSize.Set(target)
 		if old != target {
@@ -318,6 +376,95 @@ func (dp *DriverPool) runPolicy(ctx context.Context) {
 	}
 }
 
+// healthChecker is implemented by drivers that can be actively checked.
+type healthChecker interface {
+	// CheckHealth checks if the driver answers requests. It returns the number of
+	// consecutive failed checks.
+	CheckHealth(ctx context.Context) (int, error)
+}
+
+// runHealthCheck goroutine checks the idle instances on a regular time interval. The
+// instances failing too many consecutive checks are killed, and replaced by the manager
+// goroutine.
+func (dp *DriverPool) runHealthCheck(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	stop := ctx.Done()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		for _, d := range dp.idleDrivers() {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			// the driver may have been taken by a client in the meantime
+			if dp.takeIdle(d) {
+				dp.checkHealth(ctx, d)
+			}
+		}
+	}
+}
+
+// idleDrivers returns the idle drivers that can be health checked.
+func (dp *DriverPool) idleDrivers() []Driver {
+	dp.drivers.RLock()
+	defer dp.drivers.RUnlock()
+	var list []Driver
+	for d := range dp.drivers.idle {
+		if _, ok := d.(healthChecker); ok {
+			list = append(list, d)
+		}
+	}
+	return list
+}
+
+// takeIdle removes the driver from the idle queue. It returns false if the driver is not
+// idle anymore.
+func (dp *DriverPool) takeIdle(d Driver) bool {
+	dp.drivers.Lock()
+	defer dp.drivers.Unlock()
+	if _, ok := dp.drivers.idle[d]; !ok {
+		return false
+	}
+	delete(dp.drivers.idle, d)
+	return true
+}
+
+// checkHealth runs a health check on a driver taken from the idle queue. The driver is
+// either returned to the pool, or killed and replaced if it failed too many checks.
+func (dp *DriverPool) checkHealth(ctx context.Context, d Driver) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	failures, err := d.(healthChecker).CheckHealth(ctx)
+	cancel()
+	if err == nil {
+		_ = dp.putDriver(d)
+		return
+	}
+
+	if dp.metrics.health.failed != nil {
+		dp.metrics.health.failed.Add(1)
+	}
+	if failures < healthCheckFailures {
+		dp.Logger.Warningf("health check of driver %s failed (%d/%d): %s",
+			d.ID(), failures, healthCheckFailures, err)
+		_ = dp.putDriver(d)
+		return
+	}
+
+	dp.killDriver(d, killUnhealthy, "health check failed, replacing", err)
+	// the manager goroutine will spawn a new instance to reach the target size
+	select {
+	case dp.rescale <- struct{}{}:
+	default:
+	}
+}
+
 // spawnOne starts a new driver instance. It will keep trying to run it in case of a failure.
 func (dp *DriverPool) spawnOne() {
 	dp.spawning.Add(1)
@@ -326,17 +473,25 @@ func (dp *DriverPool) spawnOne() {
 	ticker := backoff.NewTicker(backoff.NewExponentialBackOff())
 	defer ticker.Stop()
 
+	reason := spawnScale
+	if dp.replacing.DecPositive() {
+		reason = spawnRecycle
+	}
+
 	// keep trying in case of a failure
 	ctx := dp.poolCtx
 	stop := ctx.Done()
+	start := time.Now()
 	for {
 		if dp.metrics.spawn.total != nil {
-			dp.metrics.spawn.total.Add(1)
+			dp.metrics.spawn.total.WithLabelValues(reason).Add(1)
 		}
 		d, err := dp.factory(ctx)
 		if err == nil {
+			dp.failing.Set(0)
 			dp.drivers.Lock()
 			dp.drivers.all[d] = struct{}{}
+			dp.drivers.info[d] = &driverInfo{started: time.Now(), spawn: time.Since(start)}
 			dp.running.Add(1)
 			dp.drivers.Unlock()
 
@@ -345,6 +500,7 @@ func (dp *DriverPool) spawnOne() {
 				return // done
 			}
 		}
+		dp.failing.Set(1)
 		if dp.metrics.spawn.err != nil {
 			dp.metrics.spawn.err.Add(1)
 		}
@@ -403,9 +559,9 @@ func (dp *DriverPool) setIdle(d Driver) {
 }
 
 // killDriver stops are removes the driver from the queue.
-func (dp *DriverPool) killDriver(d Driver, info string, err error) {
+func (dp *DriverPool) killDriver(d Driver, reason killReason, info string, err error) {
 	if dp.metrics.spawn.kill != nil {
-		dp.metrics.spawn.kill.Add(1)
+		dp.metrics.spawn.kill.WithLabelValues(string(reason)).Add(1)
 	}
 
 	if err != nil {
@@ -415,8 +571,15 @@ func (dp *DriverPool) killDriver(d Driver, info string, err error) {
 	}
 
 	dp.drivers.Lock()
+	if di, ok := dp.drivers.info[d]; ok && di.replaced {
+		// the replacement is now counted as a regular instance, or as a regular
+		// scale up if it wasn't spawned yet
+		dp.surge.Add(-1)
+		dp.replacing.DecPositive()
+	}
 	delete(dp.drivers.all, d)
 	delete(dp.drivers.idle, d)
+	delete(dp.drivers.info, d)
 	dp.running.Add(-1)
 	dp.exited.Add(1)
 	dp.drivers.Unlock()
@@ -428,10 +591,11 @@ func (dp *DriverPool) killDriver(d Driver, info string, err error) {
 
 // scaleDiff returns current difference between the target number of instances and the
 // current number of running instances. This is positive when scaling up, and negative
-// when scaling down.
+// when scaling down. Replacements of the instances about to be retired are added to the
+// target.
 func (dp *DriverPool) scaleDiff() int {
 	total := dp.running.Value()
-	return dp.targetSize.Value() - total
+	return dp.targetSize.Value() + dp.surge.Value() - total
 }
 
 // rescaleLater interrupts the scaling and serves the request first.
@@ -458,22 +622,24 @@ func (dp *DriverPool) scale() {
 			select {
 			case <-stop:
 				return
-			case req := <-dp.get:
+			case <-dp.queue.ready:
 				// no idle drivers, and there is a client waiting for us
 				// do the scaling "inline" while serving the request
-				dp.rescaleLater(req)
-				return
+				if req, ok := dp.queue.pop(); ok {
+					dp.rescaleLater(req)
+					return
+				}
 			case d := <-dp.put:
 				// prefer to kill driver that are returned by clients instead an idle ones
 				// idle map may be accessed without management goroutine, thus it's more
 				// valuable to keep it full
-				dp.killDriver(d, "scale down - kill driver returned by client", nil)
+				dp.killDriver(d, killScale, "scale down - kill driver returned by client", nil)
 				continue
 			default:
 			}
 			// only idle drivers remain - start killing those
 			if d, ok := dp.peekIdle(); ok {
-				dp.killDriver(d, "scale down - kill idle driver", nil)
+				dp.killDriver(d, killScale, "scale down - kill idle driver", nil)
 				continue
 			}
 			// no drivers are idle, only way to downscale is to wait for clients to put
@@ -481,11 +647,14 @@ func (dp *DriverPool) scale() {
 			select {
 			case <-stop:
 				return
-			case req := <-dp.get:
-				dp.rescaleLater(req)
-				return
+			case <-dp.queue.ready:
+				if req, ok := dp.queue.pop(); ok {
+					dp.rescaleLater(req)
+					return
+				}
+				i-- // only cancelled requests were queued
 			case d := <-dp.put:
-				dp.killDriver(d, "scale down - no drivers are idle", nil)
+				dp.killDriver(d, killScale, "scale down - no drivers are idle", nil)
 			}
 		}
 		return
@@ -493,9 +662,12 @@ func (dp *DriverPool) scale() {
 	// scale up
 	for i := 0; i < dn; i++ {
 		select {
-		case req := <-dp.get:
-			dp.rescaleLater(req)
-			return
+		case <-dp.queue.ready:
+			if req, ok := dp.queue.pop(); ok {
+				dp.rescaleLater(req)
+				return
+			}
+			i-- // only cancelled requests were queued
 		case <-stop:
 			return
 		case d := <-dp.put:
@@ -567,7 +739,7 @@ func (dp *DriverPool) scaleDown(req driverRequest, exact bool) bool {
 		//               scaling policy asks us to drain and then asks to scale
 		//               back up - we could have returned this driver to the
 		//               client instead
-		dp.killDriver(d, "scaleDown", nil)
+		dp.killDriver(d, killScale, "scaleDown", nil)
 	case <-dp.rescale:
 		// worth to re-evaluate scaling conditions
 	}
@@ -634,11 +806,11 @@ func (dp *DriverPool) drain() {
 		if !ok {
 			break
 		}
-		dp.killDriver(d, "drain-peekIdle", nil)
+		dp.killDriver(d, killClosed, "drain-peekIdle", nil)
 	}
 	for dp.running.Value() > 0 {
 		d := <-dp.put
-		dp.killDriver(d, "drain-put", nil)
+		dp.killDriver(d, killClosed, "drain-put", nil)
 	}
 }
 
@@ -653,8 +825,10 @@ func (dp *DriverPool) manageDrivers() {
 		select {
 		case d := <-dp.put:
 			dp.setIdle(d)
-		case req := <-dp.get:
-			dp.waitOrScale(req)
+		case <-dp.queue.ready:
+			if req, ok := dp.queue.pop(); ok {
+				dp.waitOrScale(req)
+			}
 		case <-dp.rescale:
 			dp.scale()
 		case <-stop:
@@ -675,13 +849,17 @@ func (dp *DriverPool) getIdle(rctx context.Context) (Driver, error) {
 		return nil, rctx.Err()
 	default:
 	}
-	// fast path - get an idle driver directly from the pool
+	// fast path - get an idle driver directly from the pool, unless other
+	// requests are already queued for one
 	// this function executes on the current goroutine
-	if d, ok := dp.peekIdle(); ok {
-		return d, nil
+	if dp.queue.Len() == 0 {
+		if d, ok := dp.peekIdle(); ok {
+			return d, nil
+		}
 	}
 
-	// slow path - ask the manager goroutine to pick an instance for us
+	// slow path - queue the request for the manager goroutine to pick an
+	// instance for us
 	dp.requests.Add(1)
 	defer dp.requests.Add(-1)
 
@@ -694,52 +872,103 @@ func (dp *DriverPool) getIdle(rctx context.Context) (Driver, error) {
 	req := driverRequest{
 		out: resp, err: errc, cancel: ctx.Done(),
 	}
+	cls := requestClassFrom(rctx)
+	if dp.metrics.queue.wait != nil {
+		defer prometheus.NewTimer(dp.metrics.queue.wait.WithLabelValues(string(cls.priority))).ObserveDuration()
+	}
+	dp.queue.push(cls, req)
+
 	stop := dp.poolCtx.Done()
 	select {
 	case <-req.cancel: // parent context cancelled (same as rctx.Done())
+		dp.queue.remove(cls, req)
 		return nil, ctx.Err()
 	case err := <-errc:
 		return nil, err
-	case dp.get <- req: // send request to get a driver
-		select {
-		case <-req.cancel:
-			return nil, ctx.Err()
-		case err := <-errc:
-			return nil, err
-		case d, ok := <-resp:
-			if ok {
-				return d, nil
-			}
-		case <-stop:
+	case d, ok := <-resp:
+		if ok {
+			return d, nil
 		}
 	case <-stop:
+		dp.queue.remove(cls, req)
 	}
 	return nil, ErrPoolClosed.New()
 }
 
-// putDriver returns the driver to the pool.
+// putDriver returns the driver to the pool. Drivers reaching the limits of the
+// RecyclePolicy are retired instead.
 func (dp *DriverPool) putDriver(d Driver) error {
 	if err := dp.checkStatus(d); err != nil {
 		return err
 	}
+	if dp.checkRecycle(d) {
+		return nil
+	}
 	select {
 	case <-dp.poolCtx.Done():
-		dp.killDriver(d, "putDriver", dp.poolCtx.Err())
+		dp.killDriver(d, killClosed, "putDriver", dp.poolCtx.Err())
 		return ErrPoolClosed.New()
 	case dp.put <- d:
 	}
 	return nil
 }
 
+// checkRecycle retires the driver if it reached the limits of the RecyclePolicy, in
+// which case it returns true. A replacement is requested ahead of time, when the driver
+// gets close to the limits, so the capacity of the pool doesn't dip.
+func (dp *DriverPool) checkRecycle(d Driver) bool {
+	p := dp.recyclePolicy()
+	if p.MaxRequests <= 0 && p.MaxAge <= 0 {
+		return false
+	}
+
+	dp.drivers.Lock()
+	di, ok := dp.drivers.info[d]
+	if !ok {
+		dp.drivers.Unlock()
+		return false
+	}
+	age := time.Since(di.started)
+	retire := p.retire(di.requests, age)
+	replace := !retire && di.markReplaced(p)
+	dp.drivers.Unlock()
+
+	if retire {
+		dp.killDriver(d, killRecycle, "recycling driver", nil)
+	} else if replace {
+		dp.surge.Add(1)
+		dp.replacing.Add(1)
+	} else {
+		return false
+	}
+	dp.signalRescale()
+	return retire
+}
+
+// recyclePolicy returns the current RecyclePolicy of the pool.
+func (dp *DriverPool) recyclePolicy() RecyclePolicy {
+	dp.policyMu.Lock()
+	defer dp.policyMu.Unlock()
+	return dp.RecyclePolicy
+}
+
+// signalRescale lets the manager goroutine start or stop instances.
+func (dp *DriverPool) signalRescale() {
+	select {
+	case dp.rescale <- struct{}{}:
+	default:
+	}
+}
+
 // checkStatus will check if driver is still active. If not, the function returns an error
 // and removes the driver from the pool.
 func (dp *DriverPool) checkStatus(d Driver) error {
 	status, err := d.Status()
 	if err != nil {
-		dp.killDriver(d, "error getting driver status, removing", err)
+		dp.killDriver(d, killStopped, "error getting driver status, removing", err)
 		return err
 	} else if status != protocol.Running {
-		dp.killDriver(d, "removing stopped driver", nil)
+		dp.killDriver(d, killStopped, "removing stopped driver", nil)
 		return errDriverStopped.New()
 	}
 	return nil
@@ -756,7 +985,7 @@ type FunctionCtx func(ctx context.Context, d Driver) error
 // Deprecated: use ExecuteCtx instead.
 func (dp *DriverPool) Execute(c FunctionCtx, timeout time.Duration) error {
 	if timeout == 0 {
-		timeout = 5 * time.Second
+		timeout = defaultExecuteTimeout
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -773,22 +1002,73 @@ func (dp *DriverPool) ExecuteCtx(rctx context.Context, c FunctionCtx) error {
 	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.pool.Execute")
 	defer sp.Finish()
 
+	dp.executing.Add(1)
+	defer dp.executing.Add(-1)
+
 	d, err := dp.getDriver(ctx)
 	if err != nil {
 		dp.errors.Add(1)
 		return err
 	}
 	defer dp.putDriver(d)
+	dp.countRequest(d)
 
-	if err := c(ctx, d); err != nil {
+	start := time.Now()
+	err = c(ctx, d)
+	if st := requestStatsFrom(ctx); st != nil {
+		st.Driver += time.Since(start)
+	}
+	if err != nil {
 		dp.errors.Add(1)
-		return err
+		return dp.checkOOM(d, err)
 	}
 
 	dp.success.Add(1)
 	return nil
 }
 
+// countRequest increments the number of requests served by the driver. The
+// replacement of a driver that will be retired after this request is requested
+// before serving it, so it starts ahead of time even for a small MaxRequests.
+func (dp *DriverPool) countRequest(d Driver) {
+	p := dp.recyclePolicy()
+
+	dp.drivers.Lock()
+	di, ok := dp.drivers.info[d]
+	replace := false
+	if ok {
+		di.requests++
+		replace = di.markReplaced(p)
+	}
+	dp.drivers.Unlock()
+
+	if replace {
+		dp.surge.Add(1)
+		dp.replacing.Add(1)
+		dp.signalRescale()
+	}
+}
+
+// oomNotifier is implemented by drivers that can report if they were killed
+// for exceeding their memory limit.
+type oomNotifier interface {
+	OOMKilled() bool
+}
+
+// checkOOM replaces the error of a failed request by ErrDriverOOMKilled if the
+// driver was killed for exceeding its memory limit.
+func (dp *DriverPool) checkOOM(d Driver, err error) error {
+	n, ok := d.(oomNotifier)
+	if !ok || !n.OOMKilled() {
+		return err
+	}
+	if dp.metrics.spawn.oom != nil {
+		dp.metrics.spawn.oom.Add(1)
+	}
+	dp.Logger.Warningf("driver %s was killed: out of memory", d.ID())
+	return &ErrDriverOOMKilled{ID: d.ID()}
+}
+
 // getDriver returns an idle driver instance. It will ensure that driver is running.
 func (dp *DriverPool) getDriver(rctx context.Context) (Driver, error) {
 	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.pool.getDriver")
@@ -799,21 +1079,46 @@ func (dp *DriverPool) getDriver(rctx context.Context) (Driver, error) {
 		return nil, ErrPoolClosed.New()
 	}
 
+	start := time.Now()
 	for {
 		d, err := dp.getIdle(ctx)
 		if ErrPoolClosed.Is(err) {
+			dp.countWait(ctx, nil, start)
 			return nil, err
 		} else if err != nil {
+			dp.countWait(ctx, nil, start)
 			dp.Logger.Warningf("unable to allocate a driver instance: %s", err)
 			return nil, err
 		}
 		if dp.checkStatus(d) == nil {
+			dp.countWait(ctx, d, start)
 			return d, nil
 		}
 		// retry until the deadline
 	}
 }
 
+// countWait adds the time the request waited for the driver to its stats, and
+// the time it took to spawn the driver if it was started in the meantime.
+func (dp *DriverPool) countWait(ctx context.Context, d Driver, start time.Time) {
+	st := requestStatsFrom(ctx)
+	if st == nil {
+		return
+	}
+	st.Queue += time.Since(start)
+	if d == nil {
+		return
+	}
+	st.Container = d.ID()
+
+	dp.drivers.RLock()
+	di, ok := dp.drivers.info[d]
+	dp.drivers.RUnlock()
+	if ok && di.started.After(start) {
+		st.Spawn += di.spawn
+	}
+}
+
 // Current returns a list of the current instances from the pool, it includes
 // the running ones and those being stopped.
 func (dp *DriverPool) Current() []Driver {
@@ -838,6 +1143,94 @@ func (dp *DriverPool) State() *protocol.DriverPoolState {
 	}
 }
 
+// SetScalingPolicy replaces the scaling policy of the pool. It can be called
+// while the pool is running.
+func (dp *DriverPool) SetScalingPolicy(p ScalingPolicy) {
+	dp.policyMu.Lock()
+	dp.ScalingPolicy = p
+	dp.policyMu.Unlock()
+}
+
+// SetRecyclePolicy replaces the recycling policy of the pool. It can be called
+// while the pool is running.
+func (dp *DriverPool) SetRecyclePolicy(p RecyclePolicy) {
+	dp.policyMu.Lock()
+	dp.RecyclePolicy = p
+	dp.policyMu.Unlock()
+}
+
+// WaitInstances waits until the pool runs at least n instances. It returns an
+// error if the context is cancelled or the pool is closed before.
+func (dp *DriverPool) WaitInstances(ctx context.Context, n int) error {
+	if dp.poolCtx == nil {
+		return ErrPoolClosed.New()
+	}
+
+	ticker := time.NewTicker(policyDefaultTick)
+	defer ticker.Stop()
+	for dp.running.Value() < n {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-dp.poolCtx.Done():
+			return ErrPoolClosed.New()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Serving reports if the pool is running and can get a driver, that is, if an
+// instance is running or the last one started without errors.
+func (dp *DriverPool) Serving() bool {
+	if dp.poolCtx == nil {
+		return false
+	}
+	select {
+	case <-dp.poolCtx.Done():
+		return false
+	default:
+	}
+	return dp.running.Value() > 0 || dp.failing.Value() == 0
+}
+
+// Overloaded reports if there are at least as many requests waiting for a
+// driver as running driver instances, in which case new requests will be
+// queued until the pool scales up or the instances become idle.
+func (dp *DriverPool) Overloaded() bool {
+	running := dp.running.Value()
+	if running < 1 {
+		running = 1
+	}
+	return dp.requests.Value() >= running
+}
+
+// Drain waits for all the requests that are waiting for a driver or are being
+// executed by the pool to complete, and stops the pool. If the context is
+// cancelled first, the pool is stopped without waiting.
+func (dp *DriverPool) Drain(ctx context.Context) error {
+	if dp.poolCtx == nil {
+		return nil // not running
+	}
+
+	ticker := time.NewTicker(policyDefaultTick)
+	defer ticker.Stop()
+
+	for dp.executing.Value() > 0 {
+		select {
+		case <-ctx.Done():
+			dp.Logger.Warningf("stopping the pool with %d requests in flight: %s",
+				dp.executing.Value(), ctx.Err())
+			return dp.Stop()
+		case <-dp.stopped:
+			return ErrPoolClosed.New()
+		case <-ticker.C:
+		}
+	}
+
+	return dp.Stop()
+}
+
 // Stop stop the driver pool, including all its underlying driver instances.
 func (dp *DriverPool) Stop() error {
 	if dp.poolCtx == nil {
@@ -857,6 +1250,48 @@ func (dp *DriverPool) Stop() error {
 	}
 }
 
+// driverInfo is the bookkeeping of a running driver instance.
+type driverInfo struct {
+	// started is when the instance started.
+	started time.Time
+	// spawn is the time it took to start the instance.
+	spawn time.Duration
+	// requests is the number of requests served by the instance.
+	requests int
+	// replaced is set when a replacement was requested ahead of the retirement
+	// of the instance.
+	replaced bool
+}
+
+// markReplaced marks the instance as replaced if it's close enough to the limits of
+// the RecyclePolicy, and reports if a replacement must be requested. It must be
+// called with the drivers lock held.
+func (di *driverInfo) markReplaced(p RecyclePolicy) bool {
+	if di.replaced || !p.replace(di.requests, time.Since(di.started)) {
+		return false
+	}
+	di.replaced = true
+	return true
+}
+
+// killReason is the reason a driver instance is killed, used as a metric label.
+type killReason string
+
+const (
+	killScale     killReason = "scale"     // the pool is scaling down
+	killStopped   killReason = "stopped"   // the driver stopped or its status is unknown
+	killTimeout   killReason = "timeout"   // the driver did not answer a request in time
+	killClosed    killReason = "closed"    // the pool is closing
+	killUnhealthy killReason = "unhealthy" // the driver failed the health checks
+	killRecycle   killReason = "recycle"   // the driver reached the limits of the RecyclePolicy
+)
+
+// Reasons a driver instance is spawned, used as a metric label.
+const (
+	spawnScale   = "scale"   // the pool is scaling up, or replacing a driver that exited
+	spawnRecycle = "recycle" // the driver replaces one that is about to be retired
+)
+
 type atomicInt struct {
 	val int32
 }
@@ -873,6 +1308,47 @@ func (c *atomicInt) Value() int {
 	return int(atomic.LoadInt32(&c.val))
 }
 
+// DecPositive decrements the value if it's positive, and reports if it did.
+func (c *atomicInt) DecPositive() bool {
+	for {
+		v := atomic.LoadInt32(&c.val)
+		if v <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&c.val, v, v-1) {
+			return true
+		}
+	}
+}
+
+// recycleLead is the fraction of the limits of the RecyclePolicy reached by an
+// instance when its replacement is started.
+const recycleLead = 0.9
+
+// RecyclePolicy specifies when driver instances are retired and replaced by new
+// ones, which limits the effect of memory leaks in the drivers. Zero values mean
+// no limit.
+type RecyclePolicy struct {
+	// MaxRequests is the number of requests served by an instance before it is
+	// retired.
+	MaxRequests int
+	// MaxAge is the amount of time after which an instance is retired.
+	MaxAge time.Duration
+}
+
+// retire reports if an instance must be retired.
+func (p RecyclePolicy) retire(requests int, age time.Duration) bool {
+	return (p.MaxRequests > 0 && requests >= p.MaxRequests) ||
+		(p.MaxAge > 0 && age >= p.MaxAge)
+}
+
+// replace reports if a replacement of an instance must be started, because it
+// is about to be retired.
+func (p RecyclePolicy) replace(requests int, age time.Duration) bool {
+	return (p.MaxRequests > 0 && float64(requests) >= recycleLead*float64(p.MaxRequests)) ||
+		(p.MaxAge > 0 && age >= time.Duration(recycleLead*float64(p.MaxAge)))
+}
+
 // ScalingPolicy specifies whether instances should be started or stopped to
 // cope with load.
 type ScalingPolicy interface {
@@ -291,3 +291,333 @@ func TestAIMD(t *testing.T) {
 	require.Equal(1, p.Scale(2, 2, 1))
 	require.Equal(2, p.Scale(2, 2, 2))
 }
+
+func TestDriverPoolDrain(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+
+	ctx := context.Background()
+	err := dp.Start(ctx)
+	require.NoError(err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- dp.ExecuteCtx(ctx, func(_ context.Context, _ Driver) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- dp.Drain(ctx)
+	}()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("pool drained with a request in flight: %v", err)
+	case <-time.After(2 * policyDefaultTick):
+	}
+
+	close(release)
+	require.NoError(<-done)
+	require.NoError(<-drained)
+	require.Equal(1, dp.State().Success)
+	require.Equal(0, dp.State().Running)
+}
+
+type unhealthyDriver struct {
+	*mockDriver
+	failures int
+}
+
+func (d *unhealthyDriver) CheckHealth(ctx context.Context) (int, error) {
+	d.failures++
+	return d.failures, errors.New("driver is not answering")
+}
+
+func TestDriverPoolHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	oldInterval := healthCheckInterval
+	defer func() {
+		healthCheckInterval = oldInterval
+	}()
+	healthCheckInterval = time.Millisecond
+
+	var (
+		mu     sync.Mutex
+		called int
+	)
+	dp := NewDriverPool(func(ctx context.Context) (Driver, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		called++
+		d, err := newMockDriver(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &unhealthyDriver{mockDriver: d.(*mockDriver)}, nil
+	})
+
+	err := dp.Start(context.Background())
+	require.NoError(err)
+	defer dp.Stop()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for dp.State().Exited < 2 {
+		require.True(time.Now().Before(deadline), "unhealthy drivers were not replaced")
+		time.Sleep(healthCheckInterval)
+	}
+
+	mu.Lock()
+	require.True(called >= 2)
+	mu.Unlock()
+}
+
+func TestDriverPoolRecycle(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		mu      sync.Mutex
+		spawned int
+	)
+	dp := NewDriverPool(func(ctx context.Context) (Driver, error) {
+		mu.Lock()
+		spawned++
+		mu.Unlock()
+		return newMockDriver(ctx)
+	})
+	dp.RecyclePolicy = RecyclePolicy{MaxRequests: 10}
+
+	ctx := context.Background()
+	err := dp.Start(ctx)
+	require.NoError(err)
+
+	seen := make(map[Driver]int)
+	for i := 0; i < 50; i++ {
+		err := dp.ExecuteCtx(ctx, func(_ context.Context, d Driver) error {
+			seen[d]++
+			return nil
+		})
+		require.NoError(err)
+	}
+	for _, n := range seen {
+		require.True(n <= 10, "driver served %d requests", n)
+	}
+
+	err = dp.Stop()
+	require.NoError(err)
+	require.Equal(50, dp.State().Success)
+	require.True(len(seen) >= 5, "%d drivers", len(seen))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.True(spawned >= 5, "%d drivers spawned", spawned)
+}
+
+func TestDriverPoolRecycle_MaxRequestsOne(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	dp.RecyclePolicy = RecyclePolicy{MaxRequests: 1}
+
+	ctx := context.Background()
+	err := dp.Start(ctx)
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		err := dp.ExecuteCtx(ctx, func(_ context.Context, d Driver) error {
+			// the replacement is requested before the driver is retired
+			require.Equal(1, dp.surge.Value())
+			return nil
+		})
+		require.NoError(err)
+		require.Equal(0, dp.surge.Value())
+	}
+
+	err = dp.Stop()
+	require.NoError(err)
+	require.Equal(5, dp.State().Success)
+}
+
+func TestDriverPoolKillDriver_PendingReplacement(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	dp.drivers.all = make(map[Driver]struct{})
+	dp.drivers.idle = make(map[Driver]struct{})
+	dp.drivers.info = make(map[Driver]*driverInfo)
+
+	d, err := newMockDriver(context.Background())
+	require.NoError(err)
+	dp.drivers.all[d] = struct{}{}
+	dp.drivers.info[d] = &driverInfo{started: time.Now(), replaced: true}
+	dp.running.Set(1)
+	dp.surge.Set(1)
+	dp.replacing.Set(1)
+
+	dp.killDriver(d, killRecycle, "recycling driver", nil)
+	require.Equal(0, dp.running.Value())
+	require.Equal(0, dp.surge.Value())
+	require.Equal(0, dp.replacing.Value())
+}
+
+func TestDriverPoolExecute_Stats(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(func(ctx context.Context) (Driver, error) {
+		time.Sleep(10 * time.Millisecond)
+		return newMockDriver(ctx)
+	})
+
+	// the first instance is spawned while the request waits for it
+	ctx, stats := withRequestStats(context.Background())
+	err := dp.Start(ctx)
+	require.NoError(err)
+	defer dp.Stop()
+
+	require.True(stats.Spawn >= 10*time.Millisecond, "spawn: %v", stats.Spawn)
+	require.True(stats.Queue >= stats.Spawn, "queue: %v", stats.Queue)
+
+	ctx, stats = withRequestStats(context.Background())
+	var id string
+	err = dp.ExecuteCtx(ctx, func(_ context.Context, d Driver) error {
+		id = d.ID()
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(id, stats.Container)
+	require.Zero(stats.Spawn)
+	require.True(stats.Driver >= 5*time.Millisecond, "driver: %v", stats.Driver)
+}
+
+func TestDriverPoolExecute_Priority(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	dp.ScalingPolicy = MinMax(1, 1, DefaultScalingPolicy())
+
+	ctx := context.Background()
+	err := dp.Start(ctx)
+	require.NoError(err)
+	defer dp.Stop()
+
+	// hold the only instance while the requests are queued
+	d, err := dp.getDriver(ctx)
+	require.NoError(err)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		order []Priority
+	)
+	run := func(p Priority, n int) {
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx := withRequestClass(ctx, requestClass{priority: p, client: string(p)})
+				err := dp.ExecuteCtx(ctx, func(_ context.Context, _ Driver) error {
+					mu.Lock()
+					order = append(order, p)
+					mu.Unlock()
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+	}
+	waitQueued := func(n int) {
+		for dp.requests.Value() < n {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	run(PriorityBatch, 5)
+	waitQueued(5)
+	run(PriorityInteractive, 4)
+	waitQueued(9)
+
+	require.NoError(dp.putDriver(d))
+	wg.Wait()
+
+	// the first batch request was already waiting for the instance
+	b, i := PriorityBatch, PriorityInteractive
+	require.Equal([]Priority{b, i, i, i, i, b, b, b, b}, order)
+}
+
+func TestDriverPoolWaitInstances(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	dp.ScalingPolicy = MinMax(3, 5, AIMD(1, 0.5))
+
+	ctx := context.Background()
+	err := dp.Start(ctx)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err = dp.WaitInstances(ctx, 3)
+	require.NoError(err)
+	require.True(len(dp.Current()) >= 3)
+
+	err = dp.Stop()
+	require.NoError(err)
+
+	err = dp.WaitInstances(ctx, 10)
+	require.True(ErrPoolClosed.Is(err), "%v", err)
+}
+
+func TestDriverPoolServing(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(newMockDriver)
+	require.False(dp.Serving())
+
+	err := dp.Start(context.Background())
+	require.NoError(err)
+	require.True(dp.Serving())
+
+	err = dp.Stop()
+	require.NoError(err)
+	require.False(dp.Serving())
+}
+
+func TestDriverPoolServing_FailingDriver(t *testing.T) {
+	require := require.New(t)
+
+	dp := NewDriverPool(func(ctx context.Context) (Driver, error) {
+		return nil, fmt.Errorf("driver error")
+	})
+	err := dp.Start(context.Background())
+	require.Error(err)
+	require.False(dp.Serving())
+}
+
+func TestRecyclePolicy(t *testing.T) {
+	require := require.New(t)
+
+	p := RecyclePolicy{MaxRequests: 10, MaxAge: time.Minute}
+	require.False(p.retire(8, time.Second))
+	require.False(p.replace(8, time.Second))
+	require.True(p.replace(9, time.Second))
+	require.True(p.retire(10, time.Second))
+	require.True(p.replace(0, 55*time.Second))
+	require.True(p.retire(0, time.Minute))
+
+	p = RecyclePolicy{MaxRequests: 1}
+	require.True(p.replace(1, 0))
+	require.True(p.retire(1, 0))
+
+	p = RecyclePolicy{}
+	require.False(p.retire(1000, time.Hour))
+	require.False(p.replace(1000, time.Hour))
+}
@@ -8,18 +8,31 @@
 		github.com/bblfsh/bblfshd/daemon/protocol/generated.proto
 
 	It has these top-level messages:
+		AuditEntry
+		AuditLogRequest
+		AuditLogResponse
+		DriverBundleChunk
+		DriverChange
 		DriverImageState
 		DriverInstanceState
 		DriverInstanceStatesResponse
 		DriverPoolState
 		DriverPoolStatesResponse
 		DriverStatesResponse
+		ExportDriverRequest
+		GarbageCollectResponse
+		ImportDriverRequest
 		InstallDriverRequest
+		InstallProgress
+		ReconcileDriversRequest
+		ReconcileDriversResponse
 		RemoveDriverRequest
 		Response
+		SetCurrentDriverRequest
 		DriverInstanceStatesRequest
 		DriverPoolStatesRequest
 		DriverStatesRequest
+		GarbageCollectRequest
 */
 package protocol
 
@@ -69,56 +82,118 @@ var Status_value = map[string]int32{
 	"STOPPED": 4,
 }
 
-func (Status) EnumDescriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{0} }
+func (Status) EnumDescriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{4} }
+
+func (m *AuditEntry) Reset()                    { *m = AuditEntry{} }
+func (m *AuditEntry) String() string            { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()               {}
+func (*AuditEntry) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{0} }
+
+func (m *AuditLogRequest) Reset()                    { *m = AuditLogRequest{} }
+func (m *AuditLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*AuditLogRequest) ProtoMessage()               {}
+func (*AuditLogRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{1} }
+
+func (m *AuditLogResponse) Reset()                    { *m = AuditLogResponse{} }
+func (m *AuditLogResponse) String() string            { return proto.CompactTextString(m) }
+func (*AuditLogResponse) ProtoMessage()               {}
+func (*AuditLogResponse) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{2} }
+
+func (m *DriverBundleChunk) Reset()                    { *m = DriverBundleChunk{} }
+func (m *DriverBundleChunk) String() string            { return proto.CompactTextString(m) }
+func (*DriverBundleChunk) ProtoMessage()               {}
+func (*DriverBundleChunk) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{3} }
+
+func (m *DriverChange) Reset()                    { *m = DriverChange{} }
+func (m *DriverChange) String() string            { return proto.CompactTextString(m) }
+func (*DriverChange) ProtoMessage()               {}
+func (*DriverChange) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{4} }
 
 func (m *DriverImageState) Reset()                    { *m = DriverImageState{} }
 func (m *DriverImageState) String() string            { return proto.CompactTextString(m) }
 func (*DriverImageState) ProtoMessage()               {}
-func (*DriverImageState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{0} }
+func (*DriverImageState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{5} }
 
 func (m *DriverInstanceState) Reset()                    { *m = DriverInstanceState{} }
 func (m *DriverInstanceState) String() string            { return proto.CompactTextString(m) }
 func (*DriverInstanceState) ProtoMessage()               {}
-func (*DriverInstanceState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{1} }
+func (*DriverInstanceState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{6} }
 
 func (m *DriverInstanceStatesResponse) Reset()         { *m = DriverInstanceStatesResponse{} }
 func (m *DriverInstanceStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*DriverInstanceStatesResponse) ProtoMessage()    {}
 func (*DriverInstanceStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorGenerated, []int{2}
+	return fileDescriptorGenerated, []int{7}
 }
 
 func (m *DriverPoolState) Reset()                    { *m = DriverPoolState{} }
 func (m *DriverPoolState) String() string            { return proto.CompactTextString(m) }
 func (*DriverPoolState) ProtoMessage()               {}
-func (*DriverPoolState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{3} }
+func (*DriverPoolState) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{8} }
 
 func (m *DriverPoolStatesResponse) Reset()         { *m = DriverPoolStatesResponse{} }
 func (m *DriverPoolStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*DriverPoolStatesResponse) ProtoMessage()    {}
 func (*DriverPoolStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorGenerated, []int{4}
+	return fileDescriptorGenerated, []int{9}
 }
 
 func (m *DriverStatesResponse) Reset()                    { *m = DriverStatesResponse{} }
 func (m *DriverStatesResponse) String() string            { return proto.CompactTextString(m) }
 func (*DriverStatesResponse) ProtoMessage()               {}
-func (*DriverStatesResponse) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{5} }
+func (*DriverStatesResponse) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{10} }
+
+func (m *ExportDriverRequest) Reset()                    { *m = ExportDriverRequest{} }
+func (m *ExportDriverRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportDriverRequest) ProtoMessage()               {}
+func (*ExportDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{11} }
+
+func (m *GarbageCollectResponse) Reset()                    { *m = GarbageCollectResponse{} }
+func (m *GarbageCollectResponse) String() string            { return proto.CompactTextString(m) }
+func (*GarbageCollectResponse) ProtoMessage()               {}
+func (*GarbageCollectResponse) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{12} }
+
+func (m *ImportDriverRequest) Reset()                    { *m = ImportDriverRequest{} }
+func (m *ImportDriverRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportDriverRequest) ProtoMessage()               {}
+func (*ImportDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{13} }
 
 func (m *InstallDriverRequest) Reset()                    { *m = InstallDriverRequest{} }
 func (m *InstallDriverRequest) String() string            { return proto.CompactTextString(m) }
 func (*InstallDriverRequest) ProtoMessage()               {}
-func (*InstallDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{6} }
+func (*InstallDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{14} }
+
+func (m *InstallProgress) Reset()                    { *m = InstallProgress{} }
+func (m *InstallProgress) String() string            { return proto.CompactTextString(m) }
+func (*InstallProgress) ProtoMessage()               {}
+func (*InstallProgress) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{15} }
+
+func (m *ReconcileDriversRequest) Reset()                    { *m = ReconcileDriversRequest{} }
+func (m *ReconcileDriversRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileDriversRequest) ProtoMessage()               {}
+func (*ReconcileDriversRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{16} }
+
+func (m *ReconcileDriversResponse) Reset()                    { *m = ReconcileDriversResponse{} }
+func (m *ReconcileDriversResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileDriversResponse) ProtoMessage()               {}
+func (*ReconcileDriversResponse) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{17} }
 
 func (m *RemoveDriverRequest) Reset()                    { *m = RemoveDriverRequest{} }
 func (m *RemoveDriverRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoveDriverRequest) ProtoMessage()               {}
-func (*RemoveDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{7} }
+func (*RemoveDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{18} }
 
 func (m *Response) Reset()                    { *m = Response{} }
 func (m *Response) String() string            { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()               {}
-func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{8} }
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{19} }
+
+func (m *SetCurrentDriverRequest) Reset()         { *m = SetCurrentDriverRequest{} }
+func (m *SetCurrentDriverRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCurrentDriverRequest) ProtoMessage()    {}
+func (*SetCurrentDriverRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorGenerated, []int{20}
+}
 
 type DriverInstanceStatesRequest struct {
 }
@@ -127,7 +202,7 @@ func (m *DriverInstanceStatesRequest) Reset()         { *m = DriverInstanceState
 func (m *DriverInstanceStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*DriverInstanceStatesRequest) ProtoMessage()    {}
 func (*DriverInstanceStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorGenerated, []int{9}
+	return fileDescriptorGenerated, []int{21}
 }
 
 type DriverPoolStatesRequest struct {
@@ -137,7 +212,7 @@ func (m *DriverPoolStatesRequest) Reset()         { *m = DriverPoolStatesRequest
 func (m *DriverPoolStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*DriverPoolStatesRequest) ProtoMessage()    {}
 func (*DriverPoolStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorGenerated, []int{10}
+	return fileDescriptorGenerated, []int{22}
 }
 
 type DriverStatesRequest struct {
@@ -146,21 +221,42 @@ type DriverStatesRequest struct {
 func (m *DriverStatesRequest) Reset()                    { *m = DriverStatesRequest{} }
 func (m *DriverStatesRequest) String() string            { return proto.CompactTextString(m) }
 func (*DriverStatesRequest) ProtoMessage()               {}
-func (*DriverStatesRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{11} }
+func (*DriverStatesRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{23} }
+
+type GarbageCollectRequest struct {
+}
+
+func (m *GarbageCollectRequest) Reset()                    { *m = GarbageCollectRequest{} }
+func (m *GarbageCollectRequest) String() string            { return proto.CompactTextString(m) }
+func (*GarbageCollectRequest) ProtoMessage()               {}
+func (*GarbageCollectRequest) Descriptor() ([]byte, []int) { return fileDescriptorGenerated, []int{24} }
 
 func init() {
+	proto.RegisterType((*AuditEntry)(nil), "github.com.bblfsh.server.daemon.protocol.AuditEntry")
+	proto.RegisterType((*AuditLogRequest)(nil), "github.com.bblfsh.server.daemon.protocol.AuditLogRequest")
+	proto.RegisterType((*AuditLogResponse)(nil), "github.com.bblfsh.server.daemon.protocol.AuditLogResponse")
+	proto.RegisterType((*DriverBundleChunk)(nil), "github.com.bblfsh.server.daemon.protocol.DriverBundleChunk")
+	proto.RegisterType((*DriverChange)(nil), "github.com.bblfsh.server.daemon.protocol.DriverChange")
 	proto.RegisterType((*DriverImageState)(nil), "github.com.bblfsh.server.daemon.protocol.DriverImageState")
 	proto.RegisterType((*DriverInstanceState)(nil), "github.com.bblfsh.server.daemon.protocol.DriverInstanceState")
 	proto.RegisterType((*DriverInstanceStatesResponse)(nil), "github.com.bblfsh.server.daemon.protocol.DriverInstanceStatesResponse")
 	proto.RegisterType((*DriverPoolState)(nil), "github.com.bblfsh.server.daemon.protocol.DriverPoolState")
 	proto.RegisterType((*DriverPoolStatesResponse)(nil), "github.com.bblfsh.server.daemon.protocol.DriverPoolStatesResponse")
 	proto.RegisterType((*DriverStatesResponse)(nil), "github.com.bblfsh.server.daemon.protocol.DriverStatesResponse")
+	proto.RegisterType((*ExportDriverRequest)(nil), "github.com.bblfsh.server.daemon.protocol.ExportDriverRequest")
+	proto.RegisterType((*GarbageCollectResponse)(nil), "github.com.bblfsh.server.daemon.protocol.GarbageCollectResponse")
+	proto.RegisterType((*ImportDriverRequest)(nil), "github.com.bblfsh.server.daemon.protocol.ImportDriverRequest")
 	proto.RegisterType((*InstallDriverRequest)(nil), "github.com.bblfsh.server.daemon.protocol.InstallDriverRequest")
+	proto.RegisterType((*InstallProgress)(nil), "github.com.bblfsh.server.daemon.protocol.InstallProgress")
+	proto.RegisterType((*ReconcileDriversRequest)(nil), "github.com.bblfsh.server.daemon.protocol.ReconcileDriversRequest")
+	proto.RegisterType((*ReconcileDriversResponse)(nil), "github.com.bblfsh.server.daemon.protocol.ReconcileDriversResponse")
 	proto.RegisterType((*RemoveDriverRequest)(nil), "github.com.bblfsh.server.daemon.protocol.RemoveDriverRequest")
 	proto.RegisterType((*Response)(nil), "github.com.bblfsh.server.daemon.protocol.Response")
+	proto.RegisterType((*SetCurrentDriverRequest)(nil), "github.com.bblfsh.server.daemon.protocol.SetCurrentDriverRequest")
 	proto.RegisterType((*DriverInstanceStatesRequest)(nil), "github.com.bblfsh.server.daemon.protocol.DriverInstanceStatesRequest")
 	proto.RegisterType((*DriverPoolStatesRequest)(nil), "github.com.bblfsh.server.daemon.protocol.DriverPoolStatesRequest")
 	proto.RegisterType((*DriverStatesRequest)(nil), "github.com.bblfsh.server.daemon.protocol.DriverStatesRequest")
+	proto.RegisterType((*GarbageCollectRequest)(nil), "github.com.bblfsh.server.daemon.protocol.GarbageCollectRequest")
 	proto.RegisterEnum("github.com.bblfsh.server.daemon.protocol.Status", Status_name, Status_value)
 }
 
@@ -175,11 +271,18 @@ const _ = grpc.SupportPackageIsVersion4
 // Client API for ProtocolService service
 
 type ProtocolServiceClient interface {
+	AuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error)
 	DriverInstanceStates(ctx context.Context, in *DriverInstanceStatesRequest, opts ...grpc.CallOption) (*DriverInstanceStatesResponse, error)
 	DriverPoolStates(ctx context.Context, in *DriverPoolStatesRequest, opts ...grpc.CallOption) (*DriverPoolStatesResponse, error)
 	DriverStates(ctx context.Context, in *DriverStatesRequest, opts ...grpc.CallOption) (*DriverStatesResponse, error)
+	ExportDriver(ctx context.Context, in *ExportDriverRequest, opts ...grpc.CallOption) (ProtocolService_ExportDriverClient, error)
+	GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
+	ImportDriver(ctx context.Context, opts ...grpc.CallOption) (ProtocolService_ImportDriverClient, error)
 	InstallDriver(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (*Response, error)
+	InstallDriverStream(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (ProtocolService_InstallDriverStreamClient, error)
+	ReconcileDrivers(ctx context.Context, in *ReconcileDriversRequest, opts ...grpc.CallOption) (*ReconcileDriversResponse, error)
 	RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*Response, error)
+	SetCurrentDriver(ctx context.Context, in *SetCurrentDriverRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type protocolServiceClient struct {
@@ -190,6 +293,15 @@ func NewProtocolServiceClient(cc *grpc.ClientConn) ProtocolServiceClient {
 	return &protocolServiceClient{cc}
 }
 
+func (c *protocolServiceClient) AuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error) {
+	out := new(AuditLogResponse)
+	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/AuditLog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *protocolServiceClient) DriverInstanceStates(ctx context.Context, in *DriverInstanceStatesRequest, opts ...grpc.CallOption) (*DriverInstanceStatesResponse, error) {
 	out := new(DriverInstanceStatesResponse)
 	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/DriverInstanceStates", in, out, c.cc, opts...)
@@ -217,6 +329,81 @@ func (c *protocolServiceClient) DriverStates(ctx context.Context, in *DriverStat
 	return out, nil
 }
 
+func (c *protocolServiceClient) ExportDriver(ctx context.Context, in *ExportDriverRequest, opts ...grpc.CallOption) (ProtocolService_ExportDriverClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ProtocolService_serviceDesc.Streams[0], c.cc, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/ExportDriver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &protocolServiceExportDriverClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProtocolService_ExportDriverClient interface {
+	Recv() (*DriverBundleChunk, error)
+	grpc.ClientStream
+}
+
+type protocolServiceExportDriverClient struct {
+	grpc.ClientStream
+}
+
+func (x *protocolServiceExportDriverClient) Recv() (*DriverBundleChunk, error) {
+	m := new(DriverBundleChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *protocolServiceClient) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	out := new(GarbageCollectResponse)
+	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/GarbageCollect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolServiceClient) ImportDriver(ctx context.Context, opts ...grpc.CallOption) (ProtocolService_ImportDriverClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ProtocolService_serviceDesc.Streams[1], c.cc, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/ImportDriver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &protocolServiceImportDriverClient{stream}
+	return x, nil
+}
+
+type ProtocolService_ImportDriverClient interface {
+	Send(*ImportDriverRequest) error
+	CloseAndRecv() (*Response, error)
+	grpc.ClientStream
+}
+
+type protocolServiceImportDriverClient struct {
+	grpc.ClientStream
+}
+
+func (x *protocolServiceImportDriverClient) Send(m *ImportDriverRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *protocolServiceImportDriverClient) CloseAndRecv() (*Response, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *protocolServiceClient) InstallDriver(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/InstallDriver", in, out, c.cc, opts...)
@@ -226,6 +413,47 @@ func (c *protocolServiceClient) InstallDriver(ctx context.Context, in *InstallDr
 	return out, nil
 }
 
+func (c *protocolServiceClient) InstallDriverStream(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (ProtocolService_InstallDriverStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ProtocolService_serviceDesc.Streams[2], c.cc, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/InstallDriverStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &protocolServiceInstallDriverStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProtocolService_InstallDriverStreamClient interface {
+	Recv() (*InstallProgress, error)
+	grpc.ClientStream
+}
+
+type protocolServiceInstallDriverStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *protocolServiceInstallDriverStreamClient) Recv() (*InstallProgress, error) {
+	m := new(InstallProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *protocolServiceClient) ReconcileDrivers(ctx context.Context, in *ReconcileDriversRequest, opts ...grpc.CallOption) (*ReconcileDriversResponse, error) {
+	out := new(ReconcileDriversResponse)
+	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/ReconcileDrivers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *protocolServiceClient) RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/RemoveDriver", in, out, c.cc, opts...)
@@ -235,20 +463,54 @@ func (c *protocolServiceClient) RemoveDriver(ctx context.Context, in *RemoveDriv
 	return out, nil
 }
 
+func (c *protocolServiceClient) SetCurrentDriver(ctx context.Context, in *SetCurrentDriverRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/github.com.bblfsh.server.daemon.protocol.ProtocolService/SetCurrentDriver", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ProtocolService service
 
 type ProtocolServiceServer interface {
+	AuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error)
 	DriverInstanceStates(context.Context, *DriverInstanceStatesRequest) (*DriverInstanceStatesResponse, error)
 	DriverPoolStates(context.Context, *DriverPoolStatesRequest) (*DriverPoolStatesResponse, error)
 	DriverStates(context.Context, *DriverStatesRequest) (*DriverStatesResponse, error)
+	ExportDriver(*ExportDriverRequest, ProtocolService_ExportDriverServer) error
+	GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error)
+	ImportDriver(ProtocolService_ImportDriverServer) error
 	InstallDriver(context.Context, *InstallDriverRequest) (*Response, error)
+	InstallDriverStream(*InstallDriverRequest, ProtocolService_InstallDriverStreamServer) error
+	ReconcileDrivers(context.Context, *ReconcileDriversRequest) (*ReconcileDriversResponse, error)
 	RemoveDriver(context.Context, *RemoveDriverRequest) (*Response, error)
+	SetCurrentDriver(context.Context, *SetCurrentDriverRequest) (*Response, error)
 }
 
 func RegisterProtocolServiceServer(s *grpc.Server, srv ProtocolServiceServer) {
 	s.RegisterService(&_ProtocolService_serviceDesc, srv)
 }
 
+func _ProtocolService_AuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).AuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.bblfsh.server.daemon.protocol.ProtocolService/AuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).AuditLog(ctx, req.(*AuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProtocolService_DriverInstanceStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DriverInstanceStatesRequest)
 	if err := dec(in); err != nil {
@@ -303,6 +565,71 @@ func _ProtocolService_DriverStates_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProtocolService_ExportDriver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDriverRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProtocolServiceServer).ExportDriver(m, &protocolServiceExportDriverServer{stream})
+}
+
+type ProtocolService_ExportDriverServer interface {
+	Send(*DriverBundleChunk) error
+	grpc.ServerStream
+}
+
+type protocolServiceExportDriverServer struct {
+	grpc.ServerStream
+}
+
+func (x *protocolServiceExportDriverServer) Send(m *DriverBundleChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProtocolService_GarbageCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).GarbageCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.bblfsh.server.daemon.protocol.ProtocolService/GarbageCollect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).GarbageCollect(ctx, req.(*GarbageCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProtocolService_ImportDriver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProtocolServiceServer).ImportDriver(&protocolServiceImportDriverServer{stream})
+}
+
+type ProtocolService_ImportDriverServer interface {
+	SendAndClose(*Response) error
+	Recv() (*ImportDriverRequest, error)
+	grpc.ServerStream
+}
+
+type protocolServiceImportDriverServer struct {
+	grpc.ServerStream
+}
+
+func (x *protocolServiceImportDriverServer) SendAndClose(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *protocolServiceImportDriverServer) Recv() (*ImportDriverRequest, error) {
+	m := new(ImportDriverRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _ProtocolService_InstallDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InstallDriverRequest)
 	if err := dec(in); err != nil {
@@ -321,6 +648,45 @@ func _ProtocolService_InstallDriver_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProtocolService_InstallDriverStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstallDriverRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProtocolServiceServer).InstallDriverStream(m, &protocolServiceInstallDriverStreamServer{stream})
+}
+
+type ProtocolService_InstallDriverStreamServer interface {
+	Send(*InstallProgress) error
+	grpc.ServerStream
+}
+
+type protocolServiceInstallDriverStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *protocolServiceInstallDriverStreamServer) Send(m *InstallProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProtocolService_ReconcileDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).ReconcileDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.bblfsh.server.daemon.protocol.ProtocolService/ReconcileDrivers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).ReconcileDrivers(ctx, req.(*ReconcileDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProtocolService_RemoveDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveDriverRequest)
 	if err := dec(in); err != nil {
@@ -339,10 +705,32 @@ func _ProtocolService_RemoveDriver_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProtocolService_SetCurrentDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCurrentDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).SetCurrentDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.bblfsh.server.daemon.protocol.ProtocolService/SetCurrentDriver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).SetCurrentDriver(ctx, req.(*SetCurrentDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ProtocolService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "github.com.bblfsh.server.daemon.protocol.ProtocolService",
 	HandlerType: (*ProtocolServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AuditLog",
+			Handler:    _ProtocolService_AuditLog_Handler,
+		},
 		{
 			MethodName: "DriverInstanceStates",
 			Handler:    _ProtocolService_DriverInstanceStates_Handler,
@@ -355,20 +743,48 @@ var _ProtocolService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DriverStates",
 			Handler:    _ProtocolService_DriverStates_Handler,
 		},
+		{
+			MethodName: "GarbageCollect",
+			Handler:    _ProtocolService_GarbageCollect_Handler,
+		},
 		{
 			MethodName: "InstallDriver",
 			Handler:    _ProtocolService_InstallDriver_Handler,
 		},
+		{
+			MethodName: "ReconcileDrivers",
+			Handler:    _ProtocolService_ReconcileDrivers_Handler,
+		},
 		{
 			MethodName: "RemoveDriver",
 			Handler:    _ProtocolService_RemoveDriver_Handler,
 		},
+		{
+			MethodName: "SetCurrentDriver",
+			Handler:    _ProtocolService_SetCurrentDriver_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportDriver",
+			Handler:       _ProtocolService_ExportDriver_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportDriver",
+			Handler:       _ProtocolService_ImportDriver_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "InstallDriverStream",
+			Handler:       _ProtocolService_InstallDriverStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/bblfsh/bblfshd/daemon/protocol/generated.proto",
 }
 
-func (m *DriverImageState) Marshal() (dAtA []byte, err error) {
+func (m *AuditEntry) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -378,74 +794,99 @@ func (m *DriverImageState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverImageState) MarshalTo(dAtA []byte) (int, error) {
+func (m *AuditEntry) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Reference) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Reference)))
-		i += copy(dAtA[i:], m.Reference)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
+	n1, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.Language) > 0 {
+	i += n1
+	if len(m.Caller) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
-		i += copy(dAtA[i:], m.Language)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Caller)))
+		i += copy(dAtA[i:], m.Caller)
 	}
-	if len(m.Version) > 0 {
+	if len(m.RPC) > 0 {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.RPC)))
+		i += copy(dAtA[i:], m.RPC)
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Build)))
-	n1, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Build, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Language) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
 	}
-	i += n1
-	if len(m.Status) > 0 {
+	if len(m.Reference) > 0 {
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Status)))
-		i += copy(dAtA[i:], m.Status)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Reference)))
+		i += copy(dAtA[i:], m.Reference)
 	}
-	if len(m.OS) > 0 {
+	if len(m.Version) > 0 {
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.OS)))
-		i += copy(dAtA[i:], m.OS)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
 	}
-	if len(m.NativeVersion) > 0 {
-		for _, s := range m.NativeVersion {
-			dAtA[i] = 0x3a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	if m.Update {
+		dAtA[i] = 0x38
+		i++
+		if m.Update {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-	}
-	if len(m.GoVersion) > 0 {
-		dAtA[i] = 0x42
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.GoVersion)))
-		i += copy(dAtA[i:], m.GoVersion)
 	}
-	return i, nil
-}
+	if m.DryRun {
+		dAtA[i] = 0x40
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Digest) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Digest)))
+		i += copy(dAtA[i:], m.Digest)
+	}
+	if len(m.Outcome) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Outcome)))
+		i += copy(dAtA[i:], m.Outcome)
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n2, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	return i, nil
+}
 
-func (m *DriverInstanceState) Marshal() (dAtA []byte, err error) {
+func (m *AuditLogRequest) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -455,58 +896,32 @@ func (m *DriverInstanceState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverInstanceState) MarshalTo(dAtA []byte) (int, error) {
+func (m *AuditLogRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
+	if m.Limit != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Limit))
 	}
-	if len(m.Image) > 0 {
+	if len(m.RPC) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Image)))
-		i += copy(dAtA[i:], m.Image)
-	}
-	if m.Status != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Status))
-	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)))
-	n2, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i:])
-	if err != nil {
-		return 0, err
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.RPC)))
+		i += copy(dAtA[i:], m.RPC)
 	}
-	i += n2
-	if len(m.Processes) > 0 {
-		dAtA4 := make([]byte, len(m.Processes)*10)
-		var j3 int
-		for _, num1 := range m.Processes {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j3++
-			}
-			dAtA4[j3] = uint8(num)
-			j3++
-		}
-		dAtA[i] = 0x2a
+	if len(m.Language) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(j3))
-		i += copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
 	}
 	return i, nil
 }
 
-func (m *DriverInstanceStatesResponse) Marshal() (dAtA []byte, err error) {
+func (m *AuditLogResponse) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -516,7 +931,7 @@ func (m *DriverInstanceStatesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverInstanceStatesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *AuditLogResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -539,13 +954,13 @@ func (m *DriverInstanceStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
-	n5, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	n3, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
-	if len(m.State) > 0 {
-		for _, msg := range m.State {
+	i += n3
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
 			dAtA[i] = 0x1a
 			i++
 			i = encodeVarintGenerated(dAtA, i, uint64(msg.ProtoSize()))
@@ -559,7 +974,7 @@ func (m *DriverInstanceStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DriverPoolState) Marshal() (dAtA []byte, err error) {
+func (m *DriverBundleChunk) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -569,45 +984,21 @@ func (m *DriverPoolState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverPoolState) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverBundleChunk) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Wanted != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Wanted))
-	}
-	if m.Running != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Running))
-	}
-	if m.Waiting != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Waiting))
-	}
-	if m.Success != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Success))
-	}
-	if m.Errors != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Errors))
-	}
-	if m.Exited != 0 {
-		dAtA[i] = 0x30
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(m.Exited))
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
 	return i, nil
 }
 
-func (m *DriverPoolStatesResponse) Marshal() (dAtA []byte, err error) {
+func (m *DriverChange) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -617,66 +1008,45 @@ func (m *DriverPoolStatesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverPoolStatesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverChange) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Errors) > 0 {
-		for _, s := range m.Errors {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	if len(m.Language) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
-	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Reference) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Reference)))
+		i += copy(dAtA[i:], m.Reference)
 	}
-	i += n6
-	if len(m.State) > 0 {
-		for k, _ := range m.State {
-			dAtA[i] = 0x1a
-			i++
-			v := m.State[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.ProtoSize()
-				msgSize += 1 + sovGenerated(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovGenerated(uint64(len(k))) + msgSize
-			i = encodeVarintGenerated(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintGenerated(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintGenerated(dAtA, i, uint64(v.ProtoSize()))
-				n7, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n7
-			}
-		}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if len(m.Action) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Action)))
+		i += copy(dAtA[i:], m.Action)
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
 	return i, nil
 }
 
-func (m *DriverStatesResponse) Marshal() (dAtA []byte, err error) {
+func (m *DriverImageState) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -686,14 +1056,52 @@ func (m *DriverStatesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverStatesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverImageState) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Errors) > 0 {
-		for _, s := range m.Errors {
-			dAtA[i] = 0xa
+	if len(m.Reference) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Reference)))
+		i += copy(dAtA[i:], m.Reference)
+	}
+	if len(m.Language) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Build)))
+	n4, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Build, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	if len(m.Status) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Status)))
+		i += copy(dAtA[i:], m.Status)
+	}
+	if len(m.OS) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.OS)))
+		i += copy(dAtA[i:], m.OS)
+	}
+	if len(m.NativeVersion) > 0 {
+		for _, s := range m.NativeVersion {
+			dAtA[i] = 0x3a
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -706,30 +1114,68 @@ func (m *DriverStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	dAtA[i] = 0x12
+	if len(m.GoVersion) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.GoVersion)))
+		i += copy(dAtA[i:], m.GoVersion)
+	}
+	if m.Current {
+		dAtA[i] = 0x48
+		i++
+		if m.Current {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Digest) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Digest)))
+		i += copy(dAtA[i:], m.Digest)
+	}
+	if len(m.UpdatePolicy) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.UpdatePolicy)))
+		i += copy(dAtA[i:], m.UpdatePolicy)
+	}
+	if m.UpdateAvailable {
+		dAtA[i] = 0x60
+		i++
+		if m.UpdateAvailable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.LatestDigest) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.LatestDigest)))
+		i += copy(dAtA[i:], m.LatestDigest)
+	}
+	dAtA[i] = 0x72
 	i++
-	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
-	n8, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateCheck)))
+	n5, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastUpdateCheck, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
-	if len(m.State) > 0 {
-		for _, msg := range m.State {
-			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintGenerated(dAtA, i, uint64(msg.ProtoSize()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	i += n5
+	if len(m.UpdateError) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.UpdateError)))
+		i += copy(dAtA[i:], m.UpdateError)
 	}
 	return i, nil
 }
 
-func (m *InstallDriverRequest) Marshal() (dAtA []byte, err error) {
+func (m *DriverInstanceState) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -739,37 +1185,77 @@ func (m *InstallDriverRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *InstallDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverInstanceState) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Language) > 0 {
+	if len(m.ID) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
-		i += copy(dAtA[i:], m.Language)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
-	if len(m.ImageReference) > 0 {
+	if len(m.Image) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ImageReference)))
-		i += copy(dAtA[i:], m.ImageReference)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Image)))
+		i += copy(dAtA[i:], m.Image)
 	}
-	if m.Update {
+	if m.Status != 0 {
 		dAtA[i] = 0x18
 		i++
-		if m.Update {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Status))
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)))
+	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if len(m.Processes) > 0 {
+		dAtA8 := make([]byte, len(m.Processes)*10)
+		var j7 int
+		for _, num1 := range m.Processes {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
 		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(j7))
+		i += copy(dAtA[i:], dAtA8[:j7])
+	}
+	if m.HealthFailures != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.HealthFailures))
+	}
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.LastHealthCheck)))
+	n9, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastHealthCheck, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if len(m.HealthError) > 0 {
+		dAtA[i] = 0x42
 		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.HealthError)))
+		i += copy(dAtA[i:], m.HealthError)
 	}
 	return i, nil
 }
 
-func (m *RemoveDriverRequest) Marshal() (dAtA []byte, err error) {
+func (m *DriverInstanceStatesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -779,21 +1265,98 @@ func (m *RemoveDriverRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverInstanceStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Language) > 0 {
-		dAtA[i] = 0xa
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n9, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if len(m.State) > 0 {
+		for _, msg := range m.State {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintGenerated(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *DriverPoolState) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriverPoolState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Wanted != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
-		i += copy(dAtA[i:], m.Language)
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Wanted))
+	}
+	if m.Running != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Running))
+	}
+	if m.Waiting != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Waiting))
+	}
+	if m.Success != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Success))
+	}
+	if m.Errors != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Errors))
+	}
+	if m.Exited != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Exited))
 	}
 	return i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *DriverPoolStatesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -803,7 +1366,7 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverPoolStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -826,15 +1389,43 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
-	n9, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	n10, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n10
+	if len(m.State) > 0 {
+		for k, _ := range m.State {
+			dAtA[i] = 0x1a
+			i++
+			v := m.State[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.ProtoSize()
+				msgSize += 1 + sovGenerated(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovGenerated(uint64(len(k))) + msgSize
+			i = encodeVarintGenerated(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintGenerated(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintGenerated(dAtA, i, uint64(v.ProtoSize()))
+				n11, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n11
+			}
+		}
+	}
 	return i, nil
 }
 
-func (m *DriverInstanceStatesRequest) Marshal() (dAtA []byte, err error) {
+func (m *DriverStatesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -844,15 +1435,50 @@ func (m *DriverInstanceStatesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverInstanceStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DriverStatesResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n12, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
+	if len(m.State) > 0 {
+		for _, msg := range m.State {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintGenerated(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
-func (m *DriverPoolStatesRequest) Marshal() (dAtA []byte, err error) {
+func (m *ExportDriverRequest) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -862,15 +1488,27 @@ func (m *DriverPoolStatesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverPoolStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ExportDriverRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Language) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
 	return i, nil
 }
 
-func (m *DriverStatesRequest) Marshal() (dAtA []byte, err error) {
+func (m *GarbageCollectResponse) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -880,216 +1518,517 @@ func (m *DriverStatesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DriverStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GarbageCollectResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n13, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n13
+	if m.Layers != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Layers))
+	}
+	if m.Temporary != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Temporary))
+	}
+	if m.Size != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Size))
+	}
 	return i, nil
 }
 
-func encodeFixed64Generated(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Generated(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ImportDriverRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *DriverImageState) ProtoSize() (n int) {
+
+func (m *ImportDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Reference)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = len(m.Language)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Build)
-	n += 1 + l + sovGenerated(uint64(l))
-	l = len(m.Status)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = len(m.OS)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	if len(m.NativeVersion) > 0 {
-		for _, s := range m.NativeVersion {
-			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
+	if m.Update {
+		dAtA[i] = 0x8
+		i++
+		if m.Update {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
-	l = len(m.GoVersion)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DriverInstanceState) ProtoSize() (n int) {
+func (m *InstallDriverRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstallDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Language) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
 	}
-	if m.Status != 0 {
-		n += 1 + sovGenerated(uint64(m.Status))
+	if len(m.ImageReference) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ImageReference)))
+		i += copy(dAtA[i:], m.ImageReference)
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.Processes) > 0 {
-		l = 0
-		for _, e := range m.Processes {
-			l += sovGenerated(uint64(e))
+	if m.Update {
+		dAtA[i] = 0x18
+		i++
+		if m.Update {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		n += 1 + sovGenerated(uint64(l)) + l
+		i++
 	}
-	return n
+	if len(m.Username) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Username)))
+		i += copy(dAtA[i:], m.Username)
+	}
+	if len(m.Password) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Password)))
+		i += copy(dAtA[i:], m.Password)
+	}
+	return i, nil
 }
 
-func (m *DriverInstanceStatesResponse) ProtoSize() (n int) {
-	var l int
-	_ = l
-	if len(m.Errors) > 0 {
-		for _, s := range m.Errors {
-			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
-		}
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.State) > 0 {
-		for _, e := range m.State {
-			l = e.ProtoSize()
-			n += 1 + l + sovGenerated(uint64(l))
-		}
+func (m *InstallProgress) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DriverPoolState) ProtoSize() (n int) {
+func (m *InstallProgress) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Wanted != 0 {
-		n += 1 + sovGenerated(uint64(m.Wanted))
-	}
-	if m.Running != 0 {
-		n += 1 + sovGenerated(uint64(m.Running))
+	if len(m.Stage) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Stage)))
+		i += copy(dAtA[i:], m.Stage)
 	}
-	if m.Waiting != 0 {
-		n += 1 + sovGenerated(uint64(m.Waiting))
+	if len(m.Layer) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Layer)))
+		i += copy(dAtA[i:], m.Layer)
 	}
-	if m.Success != 0 {
-		n += 1 + sovGenerated(uint64(m.Success))
+	if m.Current != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Current))
 	}
-	if m.Errors != 0 {
-		n += 1 + sovGenerated(uint64(m.Errors))
+	if m.Total != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(m.Total))
 	}
-	if m.Exited != 0 {
-		n += 1 + sovGenerated(uint64(m.Exited))
+	return i, nil
+}
+
+func (m *ReconcileDriversRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DriverPoolStatesResponse) ProtoSize() (n int) {
+func (m *ReconcileDriversRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Errors) > 0 {
-		for _, s := range m.Errors {
-			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
+	if m.DryRun {
+		dAtA[i] = 0x8
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.State) > 0 {
-		for k, v := range m.State {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.ProtoSize()
-				l += 1 + sovGenerated(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovGenerated(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovGenerated(uint64(mapEntrySize))
-		}
+	return i, nil
+}
+
+func (m *ReconcileDriversResponse) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DriverStatesResponse) ProtoSize() (n int) {
+func (m *ReconcileDriversResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if len(m.Errors) > 0 {
 		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
 			l = len(s)
-			n += 1 + l + sovGenerated(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
-	n += 1 + l + sovGenerated(uint64(l))
-	if len(m.State) > 0 {
-		for _, e := range m.State {
-			l = e.ProtoSize()
-			n += 1 + l + sovGenerated(uint64(l))
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n14, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintGenerated(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *InstallDriverRequest) ProtoSize() (n int) {
-	var l int
-	_ = l
-	l = len(m.Language)
-	if l > 0 {
-		n += 1 + l + sovGenerated(uint64(l))
-	}
-	l = len(m.ImageReference)
-	if l > 0 {
+func (m *RemoveDriverRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Language) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	return i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintGenerated(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)))
+	n15, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Elapsed, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n15
+	return i, nil
+}
+
+func (m *SetCurrentDriverRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetCurrentDriverRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Language) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Language)))
+		i += copy(dAtA[i:], m.Language)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	return i, nil
+}
+
+func (m *DriverInstanceStatesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriverInstanceStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DriverPoolStatesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriverPoolStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DriverStatesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriverStatesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GarbageCollectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GarbageCollectRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func encodeFixed64Generated(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Generated(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *AuditEntry) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Caller)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.RPC)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Reference)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
 		n += 1 + l + sovGenerated(uint64(l))
 	}
 	if m.Update {
 		n += 2
 	}
+	if m.DryRun {
+		n += 2
+	}
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Outcome)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
-func (m *RemoveDriverRequest) ProtoSize() (n int) {
+func (m *AuditLogRequest) ProtoSize() (n int) {
 	var l int
 	_ = l
+	if m.Limit != 0 {
+		n += 1 + sovGenerated(uint64(m.Limit))
+	}
+	l = len(m.RPC)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
 	l = len(m.Language)
 	if l > 0 {
 		n += 1 + l + sovGenerated(uint64(l))
@@ -1097,7 +2036,7 @@ func (m *RemoveDriverRequest) ProtoSize() (n int) {
 	return n
 }
 
-func (m *Response) ProtoSize() (n int) {
+func (m *AuditLogResponse) ProtoSize() (n int) {
 	var l int
 	_ = l
 	if len(m.Errors) > 0 {
@@ -1106,43 +2045,2441 @@ func (m *Response) ProtoSize() (n int) {
 			n += 1 + l + sovGenerated(uint64(l))
 		}
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
-	n += 1 + l + sovGenerated(uint64(l))
-	return n
-}
-
-func (m *DriverInstanceStatesRequest) ProtoSize() (n int) {
-	var l int
-	_ = l
-	return n
-}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.ProtoSize()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DriverBundleChunk) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *DriverChange) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Reference)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *DriverImageState) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Reference)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Build)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.OS)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if len(m.NativeVersion) > 0 {
+		for _, s := range m.NativeVersion {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = len(m.GoVersion)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Current {
+		n += 2
+	}
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.UpdatePolicy)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.UpdateAvailable {
+		n += 2
+	}
+	l = len(m.LatestDigest)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateCheck)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.UpdateError)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *DriverInstanceState) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovGenerated(uint64(m.Status))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Processes) > 0 {
+		l = 0
+		for _, e := range m.Processes {
+			l += sovGenerated(uint64(e))
+		}
+		n += 1 + sovGenerated(uint64(l)) + l
+	}
+	if m.HealthFailures != 0 {
+		n += 1 + sovGenerated(uint64(m.HealthFailures))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastHealthCheck)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.HealthError)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *DriverInstanceStatesResponse) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.State) > 0 {
+		for _, e := range m.State {
+			l = e.ProtoSize()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DriverPoolState) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if m.Wanted != 0 {
+		n += 1 + sovGenerated(uint64(m.Wanted))
+	}
+	if m.Running != 0 {
+		n += 1 + sovGenerated(uint64(m.Running))
+	}
+	if m.Waiting != 0 {
+		n += 1 + sovGenerated(uint64(m.Waiting))
+	}
+	if m.Success != 0 {
+		n += 1 + sovGenerated(uint64(m.Success))
+	}
+	if m.Errors != 0 {
+		n += 1 + sovGenerated(uint64(m.Errors))
+	}
+	if m.Exited != 0 {
+		n += 1 + sovGenerated(uint64(m.Exited))
+	}
+	return n
+}
+
+func (m *DriverPoolStatesResponse) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.State) > 0 {
+		for k, v := range m.State {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.ProtoSize()
+				l += 1 + sovGenerated(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovGenerated(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovGenerated(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *DriverStatesResponse) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.State) > 0 {
+		for _, e := range m.State {
+			l = e.ProtoSize()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExportDriverRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *GarbageCollectResponse) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if m.Layers != 0 {
+		n += 1 + sovGenerated(uint64(m.Layers))
+	}
+	if m.Temporary != 0 {
+		n += 1 + sovGenerated(uint64(m.Temporary))
+	}
+	if m.Size != 0 {
+		n += 1 + sovGenerated(uint64(m.Size))
+	}
+	return n
+}
+
+func (m *ImportDriverRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if m.Update {
+		n += 2
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *InstallDriverRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.ImageReference)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Update {
+		n += 2
+	}
+	l = len(m.Username)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *InstallProgress) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Stage)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Layer)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.Current != 0 {
+		n += 1 + sovGenerated(uint64(m.Current))
+	}
+	if m.Total != 0 {
+		n += 1 + sovGenerated(uint64(m.Total))
+	}
+	return n
+}
+
+func (m *ReconcileDriversRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *ReconcileDriversResponse) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.ProtoSize()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RemoveDriverRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *Response) ProtoSize() (n int) {
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Elapsed)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *SetCurrentDriverRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
+func (m *DriverInstanceStatesRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DriverPoolStatesRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DriverStatesRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GarbageCollectRequest) ProtoSize() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func sovGenerated(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozGenerated(x uint64) (n int) {
+	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AuditEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuditEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuditEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Caller", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Caller = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RPC", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RPC = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reference", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reference = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Update = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Outcome = AuditOutcome(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuditLogRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuditLogRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuditLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RPC", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RPC = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuditLogResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuditLogResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuditLogResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &AuditEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverBundleChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverBundleChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverBundleChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *DriverChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reference", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reference = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = DriverAction(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverImageState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverImageState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverImageState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reference", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reference = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Build, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OS", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OS = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NativeVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NativeVersion = append(m.NativeVersion, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Current = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatePolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatePolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateAvailable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UpdateAvailable = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestDigest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LatestDigest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastUpdateCheck", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastUpdateCheck, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdateError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverInstanceState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverInstanceState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (Status(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType == 0 {
+				var v int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenerated
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Processes = append(m.Processes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenerated
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenerated
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenerated
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Processes = append(m.Processes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Processes", wireType)
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthFailures", wireType)
+			}
+			m.HealthFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HealthFailures |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHealthCheck", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastHealthCheck, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HealthError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverInstanceStatesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverInstanceStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = append(m.State, &DriverInstanceState{})
+			if err := m.State[len(m.State)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverPoolState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverPoolState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Wanted", wireType)
+			}
+			m.Wanted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Wanted |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Running", wireType)
+			}
+			m.Running = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Running |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Waiting", wireType)
+			}
+			m.Waiting = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Waiting |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			m.Success = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Success |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			m.Errors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Errors |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exited", wireType)
+			}
+			m.Exited = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Exited |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func (m *DriverPoolStatesRequest) ProtoSize() (n int) {
-	var l int
-	_ = l
-	return n
-}
-
-func (m *DriverStatesRequest) ProtoSize() (n int) {
-	var l int
-	_ = l
-	return n
-}
-
-func sovGenerated(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozGenerated(x uint64) (n int) {
-	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *DriverImageState) Unmarshal(dAtA []byte) error {
+func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1165,15 +4502,15 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverImageState: wiretype end group for non-group")
+			return fmt.Errorf("proto: DriverPoolStatesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverImageState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DriverPoolStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reference", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1194,15 +4531,218 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = make(map[string]*DriverPoolState)
+			}
+			var mapkey string
+			var mapvalue *DriverPoolState
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenerated
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenerated
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthGenerated
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenerated
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= (int(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthGenerated
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if mapmsglen < 0 {
+						return ErrInvalidLengthGenerated
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &DriverPoolState{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipGenerated(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthGenerated
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.State[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reference = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriverStatesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriverStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1227,13 +4767,13 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Language = string(dAtA[iNdEx:postIndex])
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1243,24 +4783,25 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1284,71 +4825,64 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Build, dAtA[iNdEx:postIndex]); err != nil {
+			m.State = append(m.State, &DriverImageState{})
+			if err := m.State[len(m.State)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OS", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGenerated
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportDriverRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OS = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportDriverRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NativeVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1373,11 +4907,11 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NativeVersion = append(m.NativeVersion, string(dAtA[iNdEx:postIndex]))
+			m.Language = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1402,7 +4936,7 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1425,7 +4959,7 @@ func (m *DriverImageState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
+func (m *GarbageCollectResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1448,15 +4982,15 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverInstanceState: wiretype end group for non-group")
+			return fmt.Errorf("proto: GarbageCollectResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverInstanceState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GarbageCollectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1481,13 +5015,13 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1497,26 +5031,27 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Layers", wireType)
 			}
-			m.Status = 0
+			m.Layers = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1526,16 +5061,16 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= (Status(b) & 0x7F) << shift
+				m.Layers |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Temporary", wireType)
 			}
-			var msglen int
+			m.Temporary = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1545,83 +5080,29 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Temporary |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 5:
-			if wireType == 0 {
-				var v int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowGenerated
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Processes = append(m.Processes, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowGenerated
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthGenerated
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
+			}
+			m.Size = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				for iNdEx < postIndex {
-					var v int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowGenerated
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (int(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Processes = append(m.Processes, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Processes", wireType)
 			}
 		default:
 			iNdEx = preIndex
@@ -1644,7 +5125,7 @@ func (m *DriverInstanceState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
+func (m *ImportDriverRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1667,17 +5148,17 @@ func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverInstanceStatesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportDriverRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverInstanceStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1687,56 +5168,17 @@ func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
+			m.Update = bool(v != 0)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1746,21 +5188,21 @@ func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = append(m.State, &DriverInstanceState{})
-			if err := m.State[len(m.State)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1784,7 +5226,7 @@ func (m *DriverInstanceStatesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
+func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1807,17 +5249,17 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverPoolState: wiretype end group for non-group")
+			return fmt.Errorf("proto: InstallDriverRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverPoolState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InstallDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Wanted", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
 			}
-			m.Wanted = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1827,16 +5269,26 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Wanted |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Running", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImageReference", wireType)
 			}
-			m.Running = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1846,16 +5298,26 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Running |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ImageReference = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Waiting", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
 			}
-			m.Waiting = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1865,16 +5327,17 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Waiting |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Update = bool(v != 0)
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
 			}
-			m.Success = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1884,16 +5347,26 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Success |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Username = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
 			}
-			m.Errors = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -1903,30 +5376,21 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Errors |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exited", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
 			}
-			m.Exited = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Exited |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -1948,7 +5412,7 @@ func (m *DriverPoolState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
+func (m *InstallProgress) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1971,15 +5435,15 @@ func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverPoolStatesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: InstallProgress: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverPoolStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InstallProgress: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2004,13 +5468,13 @@ func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			m.Stage = InstallStage(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Layer", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2020,27 +5484,114 @@ func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Layer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
+			}
+			m.Current = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Current |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReconcileDriversRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReconcileDriversRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReconcileDriversRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2050,115 +5601,12 @@ func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.State == nil {
-				m.State = make(map[string]*DriverPoolState)
-			}
-			var mapkey string
-			var mapvalue *DriverPoolState
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowGenerated
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowGenerated
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthGenerated
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowGenerated
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= (int(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthGenerated
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if mapmsglen < 0 {
-						return ErrInvalidLengthGenerated
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &DriverPoolState{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipGenerated(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthGenerated
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.State[mapkey] = mapvalue
-			iNdEx = postIndex
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -2180,7 +5628,7 @@ func (m *DriverPoolStatesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
+func (m *ReconcileDriversResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2203,10 +5651,10 @@ func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DriverStatesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReconcileDriversResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DriverStatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReconcileDriversResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2270,7 +5718,7 @@ func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2294,8 +5742,8 @@ func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = append(m.State, &DriverImageState{})
-			if err := m.State[len(m.State)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Changes = append(m.Changes, &DriverChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2320,7 +5768,7 @@ func (m *DriverStatesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
+func (m *RemoveDriverRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2343,10 +5791,10 @@ func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InstallDriverRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveDriverRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InstallDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2380,7 +5828,7 @@ func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ImageReference", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2405,28 +5853,8 @@ func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ImageReference = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenerated
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Update = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -2448,7 +5876,7 @@ func (m *InstallDriverRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveDriverRequest) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2471,15 +5899,15 @@ func (m *RemoveDriverRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveDriverRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2504,7 +5932,37 @@ func (m *RemoveDriverRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Language = string(dAtA[iNdEx:postIndex])
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2527,7 +5985,7 @@ func (m *RemoveDriverRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *SetCurrentDriverRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2550,15 +6008,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetCurrentDriverRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetCurrentDriverRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2583,13 +6041,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			m.Language = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -2599,21 +6057,20 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Elapsed, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2786,6 +6243,56 @@ func (m *DriverStatesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GarbageCollectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GarbageCollectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GarbageCollectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipGenerated(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2896,70 +6403,119 @@ func init() {
 }
 
 var fileDescriptorGenerated = []byte{
-	// 1025 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcf, 0x6f, 0x1b, 0x45,
-	0x1b, 0xf6, 0xec, 0xd6, 0x6b, 0xe7, 0x4d, 0x9a, 0x58, 0xd3, 0x7c, 0xf9, 0xb6, 0x4b, 0xbb, 0x36,
-	0x91, 0x2a, 0x2c, 0x24, 0x36, 0xc8, 0x5c, 0x4a, 0x50, 0x2b, 0x25, 0xb5, 0x81, 0x1c, 0x48, 0xac,
-	0x75, 0xca, 0x81, 0x4b, 0xb5, 0xb6, 0x27, 0xdb, 0x55, 0xd7, 0x3b, 0x66, 0x67, 0xd7, 0xa5, 0x07,
-	0x0e, 0x88, 0x4b, 0x55, 0x09, 0x89, 0x13, 0x2a, 0x87, 0x4a, 0x05, 0x8a, 0xc4, 0x9f, 0xc1, 0xb1,
-	0xdc, 0x38, 0xc0, 0x35, 0xa0, 0xf4, 0xc0, 0x11, 0xce, 0x1c, 0x10, 0x9a, 0x1f, 0x6b, 0xaf, 0x9d,
-	0x1c, 0xe2, 0x44, 0xe5, 0xb6, 0xf3, 0xbe, 0xcf, 0x3b, 0xf3, 0xbc, 0xcf, 0xcc, 0x3c, 0xb3, 0x70,
-	0xdd, 0x0f, 0x92, 0xbb, 0x69, 0xd7, 0xe9, 0xd1, 0xc1, 0x46, 0xb7, 0x1b, 0x1e, 0xb0, 0xbb, 0x1b,
-	0x8c, 0xc4, 0x23, 0x12, 0x6f, 0xf4, 0x3d, 0x32, 0xa0, 0xd1, 0xc6, 0x30, 0xa6, 0x09, 0xed, 0xd1,
-	0x70, 0xc3, 0x27, 0x11, 0x89, 0xbd, 0x84, 0xf4, 0x1d, 0x11, 0xc2, 0xf5, 0x49, 0xa5, 0x23, 0x2b,
-	0x1d, 0x59, 0xe9, 0xc8, 0x4a, 0x27, 0xab, 0xb4, 0xde, 0xc8, 0xad, 0xe1, 0x53, 0x9f, 0xca, 0x39,
-	0xbb, 0xe9, 0x81, 0x18, 0x89, 0x81, 0xf8, 0x92, 0x15, 0x56, 0xd5, 0xa7, 0xd4, 0x0f, 0xc9, 0x04,
-	0x95, 0x04, 0x03, 0xc2, 0x12, 0x6f, 0x30, 0x54, 0x00, 0x7b, 0x16, 0xd0, 0x4f, 0x63, 0x2f, 0x09,
-	0xb2, 0x25, 0xd7, 0xbf, 0xd5, 0xa0, 0xd2, 0x8c, 0x83, 0x11, 0x89, 0x77, 0x06, 0x9e, 0x4f, 0x3a,
-	0x89, 0x97, 0x10, 0x7c, 0x05, 0x16, 0x62, 0x72, 0x40, 0x62, 0x12, 0xf5, 0x88, 0x89, 0x6a, 0xa8,
-	0xbe, 0xe0, 0x4e, 0x02, 0xd8, 0x82, 0x72, 0xe8, 0x45, 0x7e, 0xea, 0xf9, 0xc4, 0xd4, 0x44, 0x72,
-	0x3c, 0xc6, 0x26, 0x94, 0x46, 0x24, 0x66, 0x01, 0x8d, 0x4c, 0x5d, 0xa4, 0xb2, 0x21, 0xde, 0x84,
-	0x62, 0x37, 0x0d, 0xc2, 0xbe, 0x79, 0xa1, 0x86, 0xea, 0x8b, 0x0d, 0xcb, 0x91, 0xc4, 0x9c, 0x8c,
-	0x98, 0xb3, 0x9f, 0x31, 0xdf, 0x2e, 0x3f, 0x3f, 0xac, 0x16, 0xbe, 0xfc, 0xad, 0x8a, 0x5c, 0x59,
-	0x82, 0xd7, 0xc0, 0x60, 0x89, 0x97, 0xa4, 0xcc, 0x2c, 0x8a, 0x49, 0xd5, 0x08, 0xaf, 0x81, 0x46,
-	0x99, 0x69, 0xf0, 0xd8, 0xb6, 0x71, 0x74, 0x58, 0xd5, 0xf6, 0x3a, 0xae, 0x46, 0x19, 0xbe, 0x06,
-	0xcb, 0x91, 0x97, 0x04, 0x23, 0x72, 0x27, 0x23, 0x53, 0xaa, 0xe9, 0xf5, 0x05, 0xf7, 0xa2, 0x8c,
-	0x7e, 0xa8, 0x28, 0x5d, 0x05, 0xf0, 0xe9, 0x18, 0x52, 0x96, 0x7d, 0xfa, 0x54, 0xa5, 0x37, 0xcb,
-	0x0f, 0x9f, 0x56, 0x0b, 0x7f, 0x7d, 0x53, 0x2d, 0xac, 0xff, 0x83, 0xe0, 0x92, 0x12, 0x29, 0x62,
-	0x89, 0x17, 0xf5, 0x94, 0x4e, 0x6b, 0xa0, 0x05, 0x7d, 0x29, 0x90, 0x5c, 0x7f, 0xa7, 0xe9, 0x6a,
-	0x41, 0x1f, 0xaf, 0x42, 0x31, 0x18, 0x4c, 0xe4, 0x91, 0x03, 0xfc, 0xfe, 0xb8, 0x0b, 0x2e, 0xcd,
-	0x72, 0xe3, 0x4d, 0xe7, 0xb4, 0xa7, 0xc2, 0xe9, 0x88, 0xba, 0x71, 0xdf, 0x37, 0xa1, 0xd4, 0x8b,
-	0x09, 0x3f, 0x5f, 0x73, 0xa9, 0x99, 0x15, 0xe1, 0x6b, 0xb0, 0x30, 0x8c, 0x69, 0x8f, 0x30, 0x46,
-	0xb8, 0xa4, 0x7a, 0x5d, 0xdf, 0x2e, 0xfd, 0x7d, 0x58, 0xd5, 0x83, 0x28, 0x71, 0x27, 0x99, 0x9c,
-	0x00, 0xbf, 0x20, 0xb8, 0x72, 0x82, 0x00, 0xcc, 0x25, 0x6c, 0x48, 0x23, 0xc6, 0x95, 0x30, 0x48,
-	0x1c, 0xd3, 0x98, 0x99, 0x48, 0x28, 0xad, 0x46, 0xf8, 0x06, 0x94, 0x48, 0xe8, 0x0d, 0x19, 0xe9,
-	0x0b, 0x2d, 0x16, 0x1b, 0x97, 0x8f, 0x31, 0x6d, 0xaa, 0x03, 0x29, 0x89, 0x3e, 0x16, 0x44, 0x55,
-	0x0d, 0xee, 0x40, 0x91, 0xb7, 0x4c, 0x4c, 0xbd, 0xa6, 0xd7, 0x17, 0x1b, 0x37, 0x4e, 0xaf, 0xd8,
-	0x09, 0x6c, 0x5d, 0x39, 0x57, 0xae, 0xad, 0x3f, 0x10, 0xac, 0x48, 0x60, 0x9b, 0xd2, 0x50, 0xee,
-	0x69, 0x15, 0x8c, 0xfb, 0x5e, 0xc4, 0xa5, 0xe5, 0xfb, 0x9a, 0x13, 0x46, 0x85, 0xf1, 0xab, 0x50,
-	0x8a, 0xd3, 0x28, 0x0a, 0x22, 0x5f, 0xb4, 0x94, 0x43, 0x64, 0x71, 0x0e, 0xb9, 0xef, 0x05, 0x09,
-	0x87, 0xe8, 0x33, 0x10, 0x15, 0xe7, 0x10, 0x96, 0xf6, 0xb8, 0xd0, 0x62, 0x0b, 0xf3, 0x10, 0x15,
-	0xe7, 0x4c, 0x94, 0xa6, 0xc5, 0x19, 0x26, 0x4a, 0x5c, 0x0e, 0xf8, 0x24, 0xe0, 0x54, 0x8d, 0x59,
-	0x80, 0x08, 0xe7, 0x3a, 0xfd, 0x55, 0x03, 0x73, 0xa6, 0xd3, 0x97, 0xbe, 0x79, 0xbd, 0xe9, 0xcd,
-	0xfb, 0x60, 0xde, 0xcd, 0x3b, 0xce, 0x54, 0xdc, 0x03, 0xd2, 0x8a, 0x92, 0xf8, 0x81, 0xda, 0x4c,
-	0x8b, 0x01, 0x4c, 0x82, 0xb8, 0x02, 0xfa, 0x3d, 0xf2, 0x40, 0x59, 0x16, 0xff, 0xc4, 0x7b, 0x50,
-	0x1c, 0x79, 0x61, 0x4a, 0x54, 0x07, 0x6f, 0x9f, 0x99, 0x84, 0x2b, 0xe7, 0xd9, 0xd4, 0xae, 0xa3,
-	0x9c, 0xae, 0x3f, 0x21, 0x58, 0x95, 0xc0, 0xff, 0x46, 0xd3, 0xf6, 0xb4, 0xa6, 0x9b, 0x73, 0x5f,
-	0x88, 0xb1, 0xc9, 0x1f, 0xbf, 0x0d, 0x9f, 0xc2, 0xaa, 0xb8, 0x2f, 0x61, 0x28, 0xb1, 0x2e, 0xf9,
-	0x38, 0x25, 0x2c, 0x99, 0xf2, 0x7b, 0x34, 0xe3, 0xf7, 0xaf, 0xc1, 0x8a, 0x30, 0xb7, 0x3b, 0x93,
-	0xf7, 0x42, 0x7a, 0xde, 0xb2, 0x08, 0xbb, 0xe3, 0x47, 0x63, 0x0d, 0x8c, 0x74, 0xd8, 0x97, 0xcc,
-	0x51, 0xbd, 0xec, 0xaa, 0x51, 0x6e, 0xf9, 0x77, 0xe0, 0x92, 0x4b, 0x06, 0x74, 0x44, 0x4e, 0xbd,
-	0x7a, 0xae, 0xf8, 0x1e, 0x94, 0x5f, 0xb2, 0xf4, 0xb9, 0xc5, 0xae, 0xc2, 0x2b, 0x27, 0x9b, 0xa1,
-	0x60, 0xbc, 0x7e, 0x19, 0xfe, 0x7f, 0xfc, 0x00, 0xcb, 0xd4, 0xff, 0xb2, 0x77, 0x64, 0x2a, 0xfc,
-	0xfa, 0x57, 0x08, 0x0c, 0x69, 0xf1, 0xfc, 0x01, 0xbd, 0xe5, 0xb6, 0xb6, 0xf6, 0x5b, 0xcd, 0x4a,
-	0xc1, 0x5a, 0x7c, 0xf4, 0xa4, 0x56, 0xba, 0xa5, 0x4c, 0xdb, 0x84, 0x92, 0x7b, 0x7b, 0x77, 0x77,
-	0x67, 0xf7, 0xbd, 0x0a, 0x92, 0x19, 0x57, 0xd9, 0x8d, 0x09, 0xa5, 0xf6, 0xd6, 0xed, 0x0e, 0xcf,
-	0x68, 0x32, 0xd3, 0xf6, 0x52, 0xc6, 0x33, 0x6b, 0x60, 0xf0, 0x4c, 0xab, 0x59, 0xd1, 0x2d, 0x78,
-	0xf4, 0xa4, 0x66, 0xf0, 0x84, 0x9c, 0xab, 0xb3, 0xbf, 0xd7, 0x6e, 0xb7, 0x9a, 0x95, 0x0b, 0xb2,
-	0xa2, 0x93, 0xd0, 0xe1, 0x90, 0xf4, 0xad, 0xa5, 0x87, 0xdf, 0xd9, 0x85, 0x1f, 0x9e, 0xd9, 0x85,
-	0x1f, 0x9f, 0xd9, 0x85, 0xc6, 0x9f, 0x45, 0x58, 0x69, 0xab, 0x13, 0xd4, 0x21, 0xf1, 0x28, 0xe8,
-	0x11, 0xfc, 0xfd, 0xf8, 0xc8, 0x4f, 0xb7, 0x8f, 0x5b, 0xe7, 0x72, 0xe7, 0x4c, 0x0c, 0xeb, 0xdd,
-	0xf3, 0x4e, 0xa3, 0x8e, 0xc1, 0xd7, 0x28, 0xfb, 0xb3, 0x99, 0xec, 0x03, 0xde, 0x3a, 0x8f, 0x09,
-	0x49, 0x7e, 0xdb, 0xe7, 0xf7, 0x31, 0xfc, 0x05, 0x82, 0xa5, 0xfc, 0x41, 0xc0, 0x73, 0xbf, 0x6c,
-	0xd3, 0x9c, 0x6e, 0x9e, 0xb5, 0x5c, 0xf1, 0xf9, 0x1c, 0xc1, 0xc5, 0xa9, 0xbb, 0x8f, 0xe7, 0x98,
-	0xf1, 0x24, 0xd3, 0xb0, 0x1a, 0xa7, 0xaf, 0x1f, 0xb3, 0xf8, 0x0c, 0xc1, 0x52, 0xde, 0x02, 0xe6,
-	0x51, 0xe5, 0x04, 0xeb, 0x38, 0x0b, 0x87, 0x6d, 0xfb, 0xf9, 0x91, 0x8d, 0x7e, 0x3e, 0xb2, 0xd1,
-	0xef, 0x47, 0x76, 0xe1, 0xf1, 0x0b, 0xbb, 0xf0, 0xf4, 0x85, 0x8d, 0x3e, 0x2a, 0x67, 0xc0, 0xae,
-	0x21, 0xbe, 0xde, 0xfa, 0x37, 0x00, 0x00, 0xff, 0xff, 0xcb, 0xc9, 0x36, 0xb8, 0x0c, 0x0c, 0x00,
-	0x00,
+	// 1814 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4d, 0x6c, 0x23, 0x57,
+	0x1d, 0xf7, 0xf3, 0xc4, 0x1e, 0xef, 0x3f, 0x4e, 0xec, 0xbe, 0xdd, 0x26, 0xb3, 0xa6, 0x6b, 0xa7,
+	0x8b, 0x0a, 0xa1, 0x12, 0xce, 0x12, 0x10, 0xda, 0x0d, 0xb4, 0x90, 0xaf, 0xb6, 0x11, 0xb0, 0x6b,
+	0x8d, 0x77, 0x91, 0xe0, 0x12, 0xbd, 0x8c, 0xdf, 0x4e, 0x46, 0x3b, 0x9e, 0x71, 0xdf, 0xbc, 0xc9,
+	0x36, 0xdc, 0x2a, 0x2e, 0x6d, 0x05, 0x12, 0x02, 0x09, 0x95, 0x43, 0xa5, 0x56, 0xb4, 0x12, 0x07,
+	0xae, 0x5c, 0x11, 0x12, 0x97, 0x72, 0x40, 0xe2, 0x00, 0x27, 0xa4, 0x80, 0xd2, 0x03, 0x57, 0xce,
+	0x3d, 0xa1, 0xf7, 0x31, 0xf6, 0xcc, 0xc4, 0xbb, 0x64, 0x9c, 0xa6, 0xb7, 0xf9, 0x7f, 0xbe, 0xdf,
+	0xfb, 0x7f, 0xbc, 0xf7, 0x7f, 0x03, 0xb7, 0x5d, 0x8f, 0x1f, 0xc6, 0x07, 0x5d, 0x27, 0x1c, 0xae,
+	0x1d, 0x1c, 0xf8, 0x0f, 0xa3, 0xc3, 0xb5, 0x88, 0xb2, 0x23, 0xca, 0xd6, 0x06, 0x84, 0x0e, 0xc3,
+	0x60, 0x6d, 0xc4, 0x42, 0x1e, 0x3a, 0xa1, 0xbf, 0xe6, 0xd2, 0x80, 0x32, 0xc2, 0xe9, 0xa0, 0x2b,
+	0x59, 0x78, 0x75, 0x62, 0xd9, 0x55, 0x96, 0x5d, 0x65, 0xd9, 0x55, 0x96, 0xdd, 0xc4, 0xb2, 0xf5,
+	0xd5, 0xd4, 0x1a, 0x6e, 0xe8, 0x86, 0xca, 0xe7, 0x41, 0xfc, 0x50, 0x52, 0x92, 0x90, 0x5f, 0xca,
+	0xa2, 0xd5, 0x71, 0xc3, 0xd0, 0xf5, 0xe9, 0x44, 0x8b, 0x7b, 0x43, 0x1a, 0x71, 0x32, 0x1c, 0x69,
+	0x85, 0x76, 0x5e, 0x61, 0x10, 0x33, 0xc2, 0xbd, 0x64, 0xc9, 0x9b, 0x1f, 0x18, 0x00, 0x9b, 0xf1,
+	0xc0, 0xe3, 0xbb, 0x01, 0x67, 0xc7, 0xf8, 0x36, 0xcc, 0x09, 0x0f, 0x16, 0x5a, 0x41, 0xab, 0xf3,
+	0xeb, 0xad, 0xae, 0xb2, 0xee, 0x26, 0xd6, 0xdd, 0xfb, 0x89, 0xfb, 0xad, 0xda, 0xc7, 0x27, 0x9d,
+	0xd2, 0x2f, 0xfe, 0xd5, 0x41, 0xb6, 0xb4, 0xc0, 0x4b, 0x50, 0x75, 0x88, 0xef, 0x53, 0x66, 0x95,
+	0x57, 0xd0, 0xea, 0x15, 0x5b, 0x53, 0xf8, 0x3a, 0x18, 0x6c, 0xe4, 0x58, 0x86, 0x60, 0x6e, 0x99,
+	0xa7, 0x27, 0x1d, 0xc3, 0xee, 0x6d, 0xdb, 0x82, 0x87, 0x5b, 0x50, 0xf3, 0x49, 0xe0, 0xc6, 0xc4,
+	0xa5, 0xd6, 0x9c, 0x34, 0x1a, 0xd3, 0xf8, 0x39, 0xb8, 0xc2, 0xe8, 0x43, 0xca, 0x68, 0xe0, 0x50,
+	0xab, 0x22, 0x85, 0x13, 0x06, 0xb6, 0xc0, 0x3c, 0xa2, 0x2c, 0xf2, 0xc2, 0xc0, 0xaa, 0x4a, 0x59,
+	0x42, 0x0a, 0x18, 0xf1, 0x68, 0x40, 0x38, 0xb5, 0xcc, 0x15, 0xb4, 0x5a, 0xb3, 0x35, 0x85, 0x97,
+	0xc1, 0x1c, 0xb0, 0xe3, 0x7d, 0x16, 0x07, 0x56, 0x4d, 0x09, 0x06, 0xec, 0xd8, 0x8e, 0xa5, 0xc1,
+	0xc0, 0x73, 0x69, 0xc4, 0xad, 0x2b, 0x0a, 0xb7, 0xa2, 0xf0, 0x8b, 0x60, 0x86, 0x31, 0x77, 0xc2,
+	0x21, 0xb5, 0x40, 0x62, 0x6f, 0x7e, 0x7a, 0xd2, 0xa9, 0xcb, 0x50, 0xdd, 0x53, 0x7c, 0x3b, 0x51,
+	0xc0, 0xd7, 0xa0, 0x42, 0x19, 0x0b, 0x99, 0x35, 0x2f, 0x5d, 0x28, 0x02, 0xbf, 0x04, 0x26, 0xf5,
+	0xc9, 0x28, 0xa2, 0x03, 0xab, 0x2e, 0xc3, 0x79, 0xfd, 0x4c, 0x38, 0x77, 0x74, 0x32, 0x54, 0x34,
+	0xdf, 0x15, 0xd1, 0x4c, 0x6c, 0x36, 0x6a, 0x6f, 0xbd, 0xdf, 0x29, 0xfd, 0xf7, 0x83, 0x4e, 0xe9,
+	0xe6, 0xeb, 0xd0, 0x90, 0xeb, 0x7e, 0x3f, 0x74, 0x6d, 0xfa, 0x7a, 0x2c, 0xd0, 0xdd, 0x80, 0x8a,
+	0xef, 0x0d, 0x3d, 0x2e, 0x13, 0x65, 0x6c, 0x99, 0x9f, 0x9e, 0x74, 0x0c, 0x2f, 0xe0, 0xb6, 0xe2,
+	0x26, 0x41, 0x2f, 0xff, 0x9f, 0xa0, 0x1b, 0xd9, 0xa0, 0xa7, 0x96, 0xfc, 0x33, 0x82, 0xe6, 0x64,
+	0xcd, 0x68, 0x14, 0x06, 0x91, 0x4c, 0xb1, 0xdc, 0x59, 0x64, 0xa1, 0x15, 0x43, 0x84, 0x4a, 0x51,
+	0xe9, 0x8d, 0x96, 0x8b, 0x6f, 0x14, 0xdf, 0x05, 0x93, 0x06, 0x9c, 0x79, 0x34, 0xb2, 0x8c, 0x15,
+	0x63, 0x75, 0x7e, 0xfd, 0x1b, 0xdd, 0xf3, 0xb6, 0x4b, 0x77, 0x52, 0xba, 0x76, 0xe2, 0x24, 0xb5,
+	0x8b, 0xaf, 0xc1, 0x33, 0x3b, 0xcc, 0x3b, 0xa2, 0x6c, 0x2b, 0x0e, 0x06, 0x3e, 0xdd, 0x3e, 0x8c,
+	0x83, 0x47, 0x18, 0xc3, 0xdc, 0x80, 0x70, 0x22, 0x23, 0x57, 0xb7, 0xe5, 0x77, 0xca, 0xe4, 0xf7,
+	0x08, 0xea, 0xca, 0x66, 0xfb, 0x90, 0x04, 0x2e, 0xcd, 0xc4, 0x0b, 0x3d, 0xad, 0x48, 0xcb, 0x4f,
+	0x29, 0x52, 0x23, 0x5b, 0xa4, 0xab, 0x50, 0x25, 0x8e, 0x08, 0x87, 0x35, 0x37, 0x29, 0x2d, 0xb5,
+	0xea, 0xa6, 0xe4, 0xdb, 0x5a, 0x3e, 0xa9, 0xac, 0x4a, 0xaa, 0xb2, 0x52, 0x70, 0xff, 0x38, 0x07,
+	0x4d, 0x65, 0xb8, 0x37, 0x24, 0x2e, 0xed, 0x73, 0xc2, 0x73, 0xb0, 0x50, 0x1e, 0x56, 0x7a, 0x43,
+	0xe5, 0xdc, 0x86, 0x9e, 0x0c, 0x79, 0x03, 0x2a, 0x07, 0xb1, 0xe7, 0x0f, 0xac, 0xb9, 0x02, 0x27,
+	0x83, 0x32, 0x11, 0x75, 0x13, 0x71, 0xc2, 0xe3, 0x48, 0xef, 0x42, 0x53, 0x78, 0x09, 0xca, 0x61,
+	0xa4, 0x1a, 0x78, 0xab, 0x7a, 0x7a, 0xd2, 0x29, 0xdf, 0xeb, 0xdb, 0xe5, 0x30, 0xc2, 0x2f, 0xc0,
+	0x62, 0x40, 0xb8, 0x77, 0x44, 0xf7, 0x13, 0x30, 0xa6, 0xac, 0xb7, 0x05, 0xc5, 0xfd, 0xa1, 0x86,
+	0x74, 0x03, 0xc0, 0x0d, 0xc7, 0x2a, 0x35, 0xb5, 0x4f, 0x37, 0x4c, 0xc4, 0x16, 0x98, 0x4e, 0xcc,
+	0x18, 0x0d, 0x54, 0x67, 0xd7, 0xec, 0x84, 0x4c, 0xb5, 0x3c, 0x64, 0x5a, 0xfe, 0x8b, 0xb0, 0xa0,
+	0x4e, 0x8b, 0xfd, 0x51, 0xe8, 0x7b, 0xce, 0xb1, 0x6e, 0xe7, 0xba, 0x62, 0xf6, 0x24, 0x0f, 0x7f,
+	0x05, 0x9a, 0x5a, 0x89, 0x1c, 0x11, 0xcf, 0x27, 0x07, 0x3e, 0x95, 0xed, 0x5d, 0xb3, 0x1b, 0x8a,
+	0xbf, 0x99, 0xb0, 0x85, 0x3f, 0x9f, 0x70, 0x1a, 0xf1, 0x7d, 0xbd, 0xdc, 0x82, 0xf2, 0xa7, 0x98,
+	0x3b, 0x6a, 0xd1, 0x1e, 0x3c, 0xe3, 0x93, 0x88, 0xef, 0x6b, 0xa7, 0xce, 0x21, 0x75, 0x1e, 0x59,
+	0x8b, 0x05, 0x82, 0xdc, 0x10, 0xe6, 0x0f, 0xa4, 0xf5, 0xb6, 0x30, 0xc6, 0xcf, 0x83, 0x46, 0xbc,
+	0xaf, 0x4a, 0xa7, 0x21, 0x57, 0x9d, 0x57, 0xbc, 0xdd, 0x5c, 0x01, 0x7d, 0x64, 0xc0, 0x55, 0x5d,
+	0x40, 0x41, 0xc4, 0x49, 0xe0, 0xe8, 0x1a, 0x5a, 0x82, 0xb2, 0x37, 0xb0, 0xd0, 0x24, 0x37, 0x7b,
+	0x3b, 0x76, 0xd9, 0x1b, 0x88, 0x82, 0xf4, 0x86, 0x93, 0xd2, 0x51, 0x04, 0x7e, 0x6d, 0x9c, 0x61,
+	0x51, 0x36, 0x8b, 0xeb, 0xb7, 0xce, 0xdf, 0xc1, 0x7d, 0x69, 0x37, 0xae, 0x89, 0x97, 0xc1, 0x74,
+	0x18, 0x15, 0x57, 0x67, 0xa1, 0x4a, 0x4b, 0x8c, 0xf0, 0x0b, 0x70, 0x65, 0xc4, 0x42, 0x87, 0x46,
+	0x11, 0x15, 0xe5, 0x66, 0xa4, 0x0f, 0xc7, 0x89, 0x04, 0xdf, 0x82, 0xc6, 0x21, 0x25, 0x3e, 0x3f,
+	0xdc, 0x7f, 0x48, 0x3c, 0x3f, 0x66, 0x54, 0xd5, 0x61, 0x4a, 0x79, 0x51, 0xc9, 0x5f, 0xd1, 0xe2,
+	0x71, 0x9e, 0xb4, 0x99, 0xca, 0x93, 0x59, 0x34, 0x4f, 0xaf, 0x49, 0xeb, 0x71, 0x9e, 0xb4, 0x33,
+	0x95, 0x27, 0x55, 0xc1, 0xf3, 0x8a, 0x97, 0xcf, 0xd3, 0xdf, 0x11, 0x3c, 0x37, 0x25, 0x4f, 0xd1,
+	0x65, 0x1f, 0xce, 0x7d, 0xa8, 0x88, 0xcc, 0x50, 0x7d, 0x34, 0xbf, 0x74, 0xfe, 0xc4, 0x4e, 0x41,
+	0x6b, 0x2b, 0x5f, 0xa9, 0x6d, 0xfd, 0x07, 0x41, 0x43, 0x29, 0xf6, 0xc2, 0xd0, 0x57, 0xa5, 0xd7,
+	0x81, 0xea, 0x63, 0x12, 0x70, 0xaa, 0xca, 0x2f, 0x95, 0x12, 0xcd, 0xc6, 0xcf, 0x83, 0xc9, 0xe2,
+	0x20, 0xf0, 0x02, 0xd7, 0x2a, 0x67, 0x35, 0x12, 0xbe, 0x50, 0x79, 0x4c, 0x3c, 0x2e, 0x54, 0x8c,
+	0x9c, 0x8a, 0xe6, 0x0b, 0x95, 0x28, 0x76, 0x44, 0x3d, 0x58, 0x73, 0x39, 0x15, 0xcd, 0x17, 0x48,
+	0x74, 0x4c, 0x2b, 0x39, 0x24, 0x3a, 0xb8, 0x42, 0xe1, 0x0d, 0x4f, 0x40, 0xad, 0xe6, 0x15, 0x24,
+	0x3b, 0xb5, 0xd3, 0x7f, 0x94, 0xc1, 0xca, 0xed, 0xf4, 0xd2, 0x93, 0xe7, 0x64, 0x93, 0xf7, 0x83,
+	0xa2, 0xc9, 0x3b, 0x8b, 0x54, 0xb6, 0x2b, 0x55, 0x17, 0xae, 0xf2, 0xdd, 0x8a, 0x00, 0x26, 0x4c,
+	0xdc, 0x04, 0xe3, 0x11, 0x3d, 0xd6, 0xb7, 0x8e, 0xf8, 0xc4, 0xf7, 0xa0, 0x72, 0x44, 0xfc, 0x98,
+	0xea, 0x1d, 0xdc, 0x99, 0x19, 0x84, 0xad, 0xfc, 0x6c, 0x94, 0x6f, 0xa3, 0x54, 0x5c, 0xff, 0x82,
+	0xe0, 0x9a, 0x52, 0xfc, 0x7c, 0x62, 0xda, 0xcb, 0xc6, 0x74, 0xa3, 0x70, 0x43, 0x8c, 0xef, 0xe9,
+	0xb3, 0xdd, 0xf0, 0x00, 0xae, 0xee, 0xbe, 0x31, 0x0a, 0x19, 0x57, 0xaa, 0xc9, 0xb0, 0xf7, 0xb4,
+	0x11, 0x24, 0x75, 0x63, 0x97, 0x33, 0x37, 0x76, 0xba, 0xf4, 0x10, 0x2c, 0xbd, 0x4a, 0xd8, 0x01,
+	0x71, 0xe9, 0x76, 0xe8, 0xfb, 0xd4, 0xe1, 0x97, 0x1d, 0xa4, 0x0e, 0x54, 0x7d, 0x72, 0x4c, 0x59,
+	0x94, 0xef, 0x3e, 0xcd, 0x16, 0xc7, 0x34, 0xa7, 0xc3, 0x51, 0xc8, 0x08, 0x3b, 0xce, 0xb7, 0xdf,
+	0x44, 0x22, 0x66, 0xb5, 0xc8, 0xfb, 0x89, 0x7a, 0x00, 0x18, 0xb6, 0xfc, 0x4e, 0xed, 0xeb, 0x7b,
+	0x70, 0x75, 0x6f, 0x78, 0x36, 0x5c, 0x93, 0x27, 0x00, 0xca, 0x3c, 0x01, 0x92, 0xc1, 0xaf, 0x3c,
+	0x75, 0xf0, 0xfb, 0x03, 0x82, 0x6b, 0xf2, 0xb0, 0xf2, 0xfd, 0xf3, 0x47, 0xff, 0xcb, 0xd0, 0x90,
+	0x17, 0xe0, 0x7e, 0x7e, 0x0c, 0x5c, 0x94, 0x6c, 0x3b, 0xe1, 0xa6, 0x30, 0x19, 0x19, 0x4c, 0x2d,
+	0xa8, 0xc5, 0x11, 0x65, 0x01, 0x19, 0x8e, 0x9f, 0x40, 0x09, 0x2d, 0x64, 0x23, 0x12, 0x45, 0x8f,
+	0x43, 0x36, 0xd0, 0x83, 0xd3, 0x98, 0x4e, 0xe1, 0x7e, 0x1b, 0x41, 0x43, 0xe3, 0xee, 0xb1, 0xd0,
+	0x65, 0xe2, 0xdc, 0xfa, 0x92, 0xac, 0xd1, 0x04, 0xaf, 0x1a, 0x2f, 0xb5, 0x4e, 0x5f, 0xf0, 0x6d,
+	0x25, 0x16, 0x97, 0xb9, 0xcc, 0x47, 0x72, 0x99, 0x4b, 0x22, 0x3d, 0x38, 0xc9, 0xec, 0x4d, 0x06,
+	0xa7, 0x6b, 0x50, 0xe1, 0x21, 0x27, 0xbe, 0xca, 0x98, 0xad, 0x88, 0x14, 0x96, 0x6f, 0xc3, 0xb2,
+	0x4d, 0x9d, 0x30, 0x70, 0x3c, 0x9f, 0xaa, 0x20, 0x46, 0x49, 0x14, 0x53, 0xef, 0x2f, 0x94, 0x7e,
+	0x7f, 0xa5, 0xac, 0xff, 0x8a, 0xc0, 0x3a, 0x6b, 0x7e, 0xd9, 0xdd, 0x6c, 0x3a, 0x72, 0xce, 0x4f,
+	0xde, 0x1e, 0xdf, 0x2c, 0xda, 0xcf, 0xea, 0x99, 0x60, 0x27, 0x6e, 0xb2, 0xdd, 0x6c, 0xd3, 0x61,
+	0x78, 0x44, 0x3f, 0xdb, 0x6e, 0x7e, 0x04, 0xb5, 0x4b, 0x8e, 0x4a, 0x6a, 0xb1, 0x1f, 0xc1, 0x72,
+	0x9f, 0xf2, 0x6d, 0x95, 0xff, 0xcf, 0x76, 0x1f, 0x37, 0xe0, 0x0b, 0xd3, 0x07, 0x1a, 0xe9, 0xfe,
+	0xe6, 0x75, 0x58, 0x3e, 0x7b, 0x09, 0x29, 0xd1, 0xb3, 0xc9, 0xc8, 0x9a, 0x65, 0x2f, 0xc3, 0xb3,
+	0xf9, 0x53, 0x4e, 0x0a, 0x5e, 0xfc, 0x35, 0x82, 0xaa, 0x1a, 0x33, 0x05, 0xb0, 0x6d, 0x7b, 0x77,
+	0xf3, 0xfe, 0xee, 0x4e, 0xb3, 0xd4, 0x9a, 0x7f, 0xe7, 0xbd, 0x15, 0x73, 0x5b, 0x0f, 0x8e, 0x16,
+	0x98, 0xf6, 0x83, 0xbb, 0x77, 0xf7, 0xee, 0xbe, 0xda, 0x44, 0x4a, 0x62, 0xeb, 0x59, 0xc2, 0x02,
+	0xb3, 0xb7, 0xf9, 0xa0, 0x2f, 0x24, 0x65, 0x25, 0xe9, 0x91, 0x38, 0x12, 0x92, 0x25, 0xa8, 0x0a,
+	0xc9, 0xee, 0x4e, 0xd3, 0x68, 0xc1, 0x3b, 0xef, 0xad, 0x54, 0x85, 0x40, 0xf9, 0xea, 0xdf, 0xbf,
+	0xd7, 0xeb, 0xed, 0xee, 0x34, 0xe7, 0x94, 0x45, 0x9f, 0x87, 0xa3, 0x11, 0x1d, 0xb4, 0xea, 0x6f,
+	0xfd, 0xb6, 0x5d, 0xfa, 0xdd, 0x87, 0xed, 0xd2, 0x9f, 0x3e, 0x6c, 0x97, 0xd6, 0xff, 0xb9, 0x00,
+	0x8d, 0x9e, 0x2e, 0xa7, 0x3e, 0x65, 0x47, 0x9e, 0x43, 0xf1, 0x9b, 0x08, 0x6a, 0xc9, 0xcb, 0x1b,
+	0xdf, 0x29, 0xf8, 0x12, 0x9e, 0xfc, 0x21, 0x68, 0x6d, 0xcc, 0x62, 0xaa, 0xcb, 0xea, 0xa3, 0xf1,
+	0x9d, 0x9a, 0xcd, 0x0d, 0xde, 0xbd, 0xd0, 0xf8, 0x97, 0x64, 0xaa, 0xf5, 0xca, 0x45, 0xdd, 0x68,
+	0x9c, 0xbf, 0x41, 0xd0, 0xcc, 0x17, 0x09, 0xde, 0xbc, 0xc8, 0x94, 0xa3, 0xf0, 0x6d, 0x5d, 0x7c,
+	0x50, 0xc2, 0x3f, 0x1f, 0xff, 0x48, 0xd0, 0xb8, 0x0a, 0x8f, 0xce, 0x59, 0x4c, 0x2f, 0xcf, 0x6a,
+	0xae, 0xf1, 0xfc, 0x0c, 0x41, 0x3d, 0x3d, 0x5c, 0x14, 0xc1, 0x33, 0x65, 0x28, 0x69, 0x7d, 0xab,
+	0x28, 0x9e, 0xd4, 0x3f, 0x98, 0x5b, 0x08, 0xff, 0x0a, 0xc1, 0x62, 0xb6, 0x5b, 0xf1, 0x77, 0xce,
+	0xef, 0x71, 0x6a, 0x9f, 0xb7, 0xbe, 0x3b, 0xbb, 0x03, 0x1d, 0xa4, 0x9f, 0x22, 0xa8, 0xef, 0x0d,
+	0x67, 0x0b, 0xd2, 0x94, 0x51, 0xa4, 0xb5, 0x7e, 0x7e, 0xf3, 0x04, 0xc3, 0x2a, 0x12, 0x28, 0x16,
+	0x32, 0xa3, 0x08, 0x2e, 0x90, 0xfc, 0x69, 0x33, 0xcc, 0x2c, 0x38, 0xf0, 0x2f, 0x11, 0x5c, 0xcd,
+	0x38, 0xeb, 0x73, 0x46, 0xc9, 0xf0, 0xc2, 0x58, 0xee, 0x14, 0xb6, 0x4f, 0xe6, 0x9a, 0x5b, 0x48,
+	0x76, 0x7c, 0x7e, 0x46, 0x28, 0xd2, 0xf1, 0x4f, 0x18, 0x4f, 0x5a, 0x5b, 0x17, 0x71, 0xa1, 0x03,
+	0xf6, 0x26, 0x82, 0x7a, 0xfa, 0xc2, 0x2f, 0x52, 0x3c, 0x53, 0x06, 0x85, 0x99, 0x92, 0xf6, 0x36,
+	0x82, 0x66, 0xfe, 0xc2, 0x2e, 0x12, 0x9f, 0x27, 0x5c, 0xf6, 0xb3, 0x60, 0xd9, 0x6a, 0x7f, 0x7c,
+	0xda, 0x46, 0x7f, 0x3b, 0x6d, 0xa3, 0x7f, 0x9f, 0xb6, 0x4b, 0xef, 0x7e, 0xd2, 0x2e, 0xbd, 0xff,
+	0x49, 0x1b, 0xfd, 0xb8, 0x96, 0x28, 0x1e, 0x54, 0xe5, 0xd7, 0xd7, 0xff, 0x37, 0x00, 0x36, 0xd9,
+	0x01, 0x5a, 0x57, 0x19, 0x00, 0x00,
 }
@@ -1,6 +1,8 @@
 package protocol
 
 import (
+	"bufio"
+	"io"
 	"strings"
 	"time"
 
@@ -15,11 +17,26 @@ import (
 
 var (
 	ErrAlreadyInstalled = errors.NewKind("driver already installed: %s (image reference: %s)")
+	// ErrImageVerification indicates that a driver image failed the signature
+	// or digest verification required by the daemon, and was not installed.
+	ErrImageVerification = errors.NewKind("untrusted driver image")
+	// ErrNoDriversConfig is returned when reconciling the installed drivers
+	// if the daemon was not started with a drivers configuration file.
+	ErrNoDriversConfig = errors.NewKind("no drivers configuration file was loaded")
+	// ErrNoAuditLog is returned when querying the audit log if the daemon
+	// doesn't write one.
+	ErrNoAuditLog = errors.NewKind("no audit log is written")
 )
 
 type Service interface {
-	InstallDriver(language string, image string, update bool) error
-	RemoveDriver(language string) error
+	InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error
+	RemoveDriver(language, version string) error
+	SetCurrentDriver(language, version string) error
+	ExportDriver(language, version string, w io.Writer) error
+	ImportDriver(r io.Reader, update bool) error
+	GarbageCollect() (*GarbageCollectResponse, error)
+	ReconcileDrivers(dryRun bool) ([]*DriverChange, error)
+	AuditLog(limit int, rpc, language string) ([]*AuditEntry, error)
 	DriverStates() ([]*DriverImageState, error)
 	DriverPoolStates() map[string]*DriverPoolState
 	DriverInstanceStates() ([]*DriverInstanceState, error)
@@ -104,6 +121,52 @@ type InstallDriverRequest struct {
 	// Update indicates whether an image should be updated. When set to false,
 	// the installation fails if the image already exists.
 	Update bool
+	// Username and Password to authenticate to the registry of the image,
+	// instead of the credentials configured in the daemon.
+	Username string
+	Password string
+}
+
+// RegistryCredentials are the credentials of an image registry given in an
+// InstallDriverRequest.
+//
+// +protobuf=false
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// InstallStage is a stage of the installation of a driver.
+type InstallStage string
+
+const (
+	// InstallResolving is the stage of the resolution of the image reference
+	// and of the language of the driver.
+	InstallResolving InstallStage = "resolving"
+	// InstallDownloading is the stage of the download of each layer of the
+	// image, reported with the bytes downloaded of the layer.
+	InstallDownloading InstallStage = "downloading"
+	// InstallUnpacking is the stage of the assembly of the root filesystem of
+	// the driver.
+	InstallUnpacking InstallStage = "unpacking"
+	// InstallValidating is the stage of the validation of the new driver,
+	// when it replaces the current one.
+	InstallValidating InstallStage = "validating"
+	// InstallDone is the last event of a successful installation.
+	InstallDone InstallStage = "done"
+)
+
+// InstallProgress is an event of the installation of a driver sent by
+// InstallDriverStream.
+type InstallProgress struct {
+	// Stage of the installation.
+	Stage InstallStage
+	// Layer is the digest of the layer being downloaded.
+	Layer string
+	// Current is the number of bytes of the layer downloaded.
+	Current int64
+	// Total is the size of the layer, or -1 if unknown.
+	Total int64
 }
 
 func (s *protocolServiceServer) InstallDriver(ctx xcontext.Context, req *InstallDriverRequest) (*Response, error) {
@@ -113,30 +176,67 @@ func (s *protocolServiceServer) InstallDriver(ctx xcontext.Context, req *Install
 		resp.Elapsed = time.Since(start)
 	}()
 
+	if err := s.installDriver(req, nil); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InstallDriverStream installs a driver as InstallDriver, and sends the events
+// of the installation to the client. A failed installation ends the stream
+// with the same error InstallDriver returns.
+func (s *protocolServiceServer) InstallDriverStream(req *InstallDriverRequest, stream ProtocolService_InstallDriverStreamServer) error {
+	// the events are not sent once the client is gone, the installation
+	// continues until it ends anyway
+	var serr error
+	err := s.installDriver(req, func(p *InstallProgress) {
+		if serr == nil {
+			serr = stream.Send(p)
+		}
+	})
+	if err != nil {
+		return err
+	} else if serr != nil {
+		return serr
+	}
+
+	return stream.Send(&InstallProgress{Stage: InstallDone})
+}
+
+func (s *protocolServiceServer) installDriver(req *InstallDriverRequest, progress func(*InstallProgress)) error {
+	var creds *RegistryCredentials
+	if req.Username != "" || req.Password != "" {
+		creds = &RegistryCredentials{Username: req.Username, Password: req.Password}
+	}
+
 	err := s.s.InstallDriver(
 		strings.ToLower(req.Language),
 		req.ImageReference,
 		req.Update,
+		creds,
+		progress,
 	)
 
 	if ErrAlreadyInstalled.Is(err) {
-		return nil, status.New(codes.AlreadyExists, err.Error()).Err()
+		return status.New(codes.AlreadyExists, err.Error()).Err()
+	} else if ErrImageVerification.Is(err) {
+		return status.New(codes.FailedPrecondition, err.Error()).Err()
 	} else if errs, ok := err.(errcode.Errors); ok { //docker err codes analysis
 		for _, erro := range errs {
 			if errc, ok := erro.(errcode.Error); ok && errc.ErrorCode() == errcode.ErrorCodeUnauthorized {
-				return nil, status.New(codes.Unauthenticated, err.Error()).Err()
+				return status.New(codes.Unauthenticated, err.Error()).Err()
 			}
 		}
 	}
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return err
 }
 
 type RemoveDriverRequest struct {
 	// Language supported by the driver to be deleted.
 	Language string
+	// Version of the driver to be deleted, all the installed versions are
+	// deleted if empty.
+	Version string
 }
 
 func (s *protocolServiceServer) RemoveDriver(ctx xcontext.Context, req *RemoveDriverRequest) (result *Response, err error) {
@@ -146,7 +246,228 @@ func (s *protocolServiceServer) RemoveDriver(ctx xcontext.Context, req *RemoveDr
 		resp.Elapsed = time.Since(start)
 	}()
 
-	if err := s.s.RemoveDriver(strings.ToLower(req.Language)); err != nil {
+	if err := s.s.RemoveDriver(strings.ToLower(req.Language), req.Version); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type SetCurrentDriverRequest struct {
+	// Language supported by the driver.
+	Language string
+	// Version of the driver to be used by default for the language.
+	Version string
+}
+
+func (s *protocolServiceServer) SetCurrentDriver(ctx xcontext.Context, req *SetCurrentDriverRequest) (*Response, error) {
+	resp := &Response{}
+	start := time.Now()
+	defer func() {
+		resp.Elapsed = time.Since(start)
+	}()
+
+	if err := s.s.SetCurrentDriver(strings.ToLower(req.Language), req.Version); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// bundleChunkSize is the maximum size of the chunks of the bundles sent by
+// ExportDriver and ImportDriver, well below the default message size limit of
+// gRPC.
+const bundleChunkSize = 1 << 20
+
+type ExportDriverRequest struct {
+	// Language supported by the driver to be exported.
+	Language string
+	// Version of the driver to be exported, the current one if empty.
+	Version string
+}
+
+// DriverBundleChunk is a part of a driver bundle sent by ExportDriver.
+type DriverBundleChunk struct {
+	// Data of the bundle, following the data of the previous chunk.
+	Data []byte
+}
+
+// ExportDriver writes a driver as a bundle, sent to the client in chunks. A
+// failed export ends the stream with an error, and the chunks already sent
+// must be discarded.
+func (s *protocolServiceServer) ExportDriver(req *ExportDriverRequest, stream ProtocolService_ExportDriverServer) error {
+	w := bufio.NewWriterSize(chunkWriter(func(data []byte) error {
+		return stream.Send(&DriverBundleChunk{Data: data})
+	}), bundleChunkSize)
+
+	if err := s.s.ExportDriver(strings.ToLower(req.Language), req.Version, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// chunkWriter is an io.Writer sending the data written to it in chunks of
+// bundleChunkSize at most.
+type chunkWriter func(data []byte) error
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		c := p
+		if len(c) > bundleChunkSize {
+			c = c[:bundleChunkSize]
+		}
+		if err := w(c); err != nil {
+			return n, err
+		}
+		n += len(c)
+		p = p[len(c):]
+	}
+	return n, nil
+}
+
+// ImportDriverRequest is sent by the client of ImportDriver as many times as
+// needed to send the bundle: the first message sets Update, and the data of
+// the bundle follows in Data, in the order it was written by ExportDriver.
+type ImportDriverRequest struct {
+	// Update indicates whether the driver should be updated if this version
+	// is already installed, and made the current one.
+	Update bool
+	// Data of the bundle, following the data of the previous message.
+	Data []byte
+}
+
+func (s *protocolServiceServer) ImportDriver(stream ProtocolService_ImportDriverServer) error {
+	resp := &Response{}
+	start := time.Now()
+
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return status.New(codes.InvalidArgument, "no driver bundle was sent").Err()
+	} else if err != nil {
+		return err
+	}
+
+	r := &chunkReader{recv: stream.Recv, data: req.Data}
+	err = s.s.ImportDriver(r, req.Update)
+	if ErrAlreadyInstalled.Is(err) {
+		return status.New(codes.AlreadyExists, err.Error()).Err()
+	} else if ErrImageVerification.Is(err) {
+		return status.New(codes.FailedPrecondition, err.Error()).Err()
+	}
+	if err != nil {
+		return err
+	}
+
+	resp.Elapsed = time.Since(start)
+	return stream.SendAndClose(resp)
+}
+
+// chunkReader is an io.Reader of the data sent by the client of ImportDriver.
+type chunkReader struct {
+	recv func() (*ImportDriverRequest, error)
+	data []byte
+	err  error
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		var req *ImportDriverRequest
+		req, r.err = r.recv()
+		if req != nil {
+			r.data = req.Data
+		}
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+type GarbageCollectResponse struct {
+	protocol.Response
+	// Layers is the number of unused image layers removed.
+	Layers int
+	// Temporary is the number of temporary directories removed, left behind
+	// by interrupted installations.
+	Temporary int
+	// Size is the disk space released, in bytes.
+	Size int64
+}
+
+func (s *protocolServiceServer) GarbageCollect(ctx xcontext.Context, _ *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	start := time.Now()
+	resp, err := s.s.GarbageCollect()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Elapsed = time.Since(start)
+	return resp, nil
+}
+
+type ReconcileDriversRequest struct {
+	// DryRun indicates whether the changes are only reported, without being
+	// applied.
+	DryRun bool
+}
+
+type ReconcileDriversResponse struct {
+	protocol.Response
+	// Changes are the actions taken to reconcile the installed drivers with
+	// the drivers configuration of the daemon.
+	Changes []*DriverChange
+}
+
+func (s *protocolServiceServer) ReconcileDrivers(ctx xcontext.Context, req *ReconcileDriversRequest) (*ReconcileDriversResponse, error) {
+	resp := &ReconcileDriversResponse{}
+	start := time.Now()
+	defer func() {
+		resp.Elapsed = time.Since(start)
+	}()
+
+	var err error
+	resp.Changes, err = s.s.ReconcileDrivers(req.DryRun)
+	if ErrNoDriversConfig.Is(err) {
+		return nil, status.New(codes.FailedPrecondition, err.Error()).Err()
+	} else if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type AuditLogRequest struct {
+	// Limit is the maximal number of entries returned, the most recent ones
+	// being returned. The daemon sets a default if zero.
+	Limit int
+	// RPC filters the entries by the name of the control RPC, if not empty.
+	RPC string
+	// Language filters the entries by the language of the driver, if not
+	// empty.
+	Language string
+}
+
+type AuditLogResponse struct {
+	protocol.Response
+	// Entries are the entries of the audit log, from the oldest to the most
+	// recent.
+	Entries []*AuditEntry
+}
+
+func (s *protocolServiceServer) AuditLog(ctx xcontext.Context, req *AuditLogRequest) (*AuditLogResponse, error) {
+	resp := &AuditLogResponse{}
+	start := time.Now()
+	defer func() {
+		resp.Elapsed = time.Since(start)
+	}()
+
+	var err error
+	resp.Entries, err = s.s.AuditLog(req.Limit, req.RPC, req.Language)
+	if ErrNoAuditLog.Is(err) {
+		return nil, status.New(codes.FailedPrecondition, err.Error()).Err()
+	} else if err != nil {
 		return nil, err
 	}
 	return resp, nil
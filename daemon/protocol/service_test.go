@@ -1,13 +1,19 @@
 package protocol
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"io/ioutil"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/docker/distribution/registry/api/errcode"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -17,7 +23,7 @@ type mockedServiceAnyOtherErr struct {
 	Service
 }
 
-func (s *mockedServiceAnyOtherErr) InstallDriver(language string, image string, update bool) error {
+func (s *mockedServiceAnyOtherErr) InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error {
 	return errcode.Errors{errors.New("any other error")}
 }
 
@@ -40,7 +46,7 @@ type mockedServiceUnauthorizedErr struct {
 	Service
 }
 
-func (s *mockedServiceUnauthorizedErr) InstallDriver(language string, image string, update bool) error {
+func (s *mockedServiceUnauthorizedErr) InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error {
 	return errcode.Errors{
 		errcode.Error{Code: errcode.ErrorCodeDenied},
 		errcode.Error{Code: errcode.ErrorCodeUnauthorized},
@@ -65,3 +71,392 @@ func TestServiceMockDaemon_InstallNonexistentDriver(t *testing.T) {
 
 	require.Equal(codes.Unauthenticated, st.Code())
 }
+
+type mockedServiceCredentials struct {
+	mock.Mock
+	Service
+	creds *RegistryCredentials
+}
+
+func (s *mockedServiceCredentials) InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error {
+	s.creds = creds
+	return nil
+}
+
+func TestServiceMockDaemon_InstallDriverCredentials(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceCredentials)
+	ps := &protocolServiceServer{s}
+
+	//when
+	_, err := ps.InstallDriver(context.Background(), &InstallDriverRequest{})
+	require.NoError(err)
+	require.Nil(s.creds)
+
+	req := &InstallDriverRequest{Username: "bblfsh", Password: "secret"}
+	data, err := req.Marshal()
+	require.NoError(err)
+	req = &InstallDriverRequest{}
+	require.NoError(req.Unmarshal(data))
+
+	_, err = ps.InstallDriver(context.Background(), req)
+
+	//then
+	require.NoError(err)
+	require.Equal(&RegistryCredentials{Username: "bblfsh", Password: "secret"}, s.creds)
+}
+
+type mockedServiceInstallProgress struct {
+	mock.Mock
+	Service
+	err error
+}
+
+func (s *mockedServiceInstallProgress) InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error {
+	progress(&InstallProgress{Stage: InstallResolving})
+	progress(&InstallProgress{Stage: InstallDownloading, Layer: "sha256:1", Current: 512, Total: 1024})
+	progress(&InstallProgress{Stage: InstallDownloading, Layer: "sha256:1", Current: 1024, Total: 1024})
+	progress(&InstallProgress{Stage: InstallUnpacking})
+	return s.err
+}
+
+func TestServiceMockDaemon_InstallDriverStream(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceInstallProgress)
+	srv := grpc.NewServer()
+	RegisterService(srv, s)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+	client := NewProtocolServiceClient(conn)
+
+	recv := func() ([]*InstallProgress, error) {
+		stream, err := client.InstallDriverStream(context.Background(), &InstallDriverRequest{})
+		require.NoError(err)
+
+		var events []*InstallProgress
+		for {
+			p, err := stream.Recv()
+			if err == io.EOF {
+				return events, nil
+			} else if err != nil {
+				return events, err
+			}
+			events = append(events, p)
+		}
+	}
+
+	//when
+	events, err := recv()
+
+	//then
+	require.NoError(err)
+	require.Equal([]*InstallProgress{
+		{Stage: InstallResolving},
+		{Stage: InstallDownloading, Layer: "sha256:1", Current: 512, Total: 1024},
+		{Stage: InstallDownloading, Layer: "sha256:1", Current: 1024, Total: 1024},
+		{Stage: InstallUnpacking},
+		{Stage: InstallDone},
+	}, events)
+
+	//when
+	s.err = ErrAlreadyInstalled.New("go", "docker://bblfsh/go-driver:latest")
+	events, err = recv()
+
+	//then
+	require.Equal(codes.AlreadyExists, status.Code(err))
+	require.Len(events, 4)
+}
+
+type mockedServiceBundle struct {
+	mock.Mock
+	Service
+	bundle []byte
+	update bool
+	err    error
+}
+
+func (s *mockedServiceBundle) ExportDriver(language, version string, w io.Writer) error {
+	if _, err := w.Write(s.bundle); err != nil {
+		return err
+	}
+	return s.err
+}
+
+func (s *mockedServiceBundle) ImportDriver(r io.Reader, update bool) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.bundle, s.update = data, update
+	return s.err
+}
+
+func TestServiceMockDaemon_ExportImportDriver(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceBundle)
+	s.bundle = bytes.Repeat([]byte("bundle"), bundleChunkSize)
+	srv := grpc.NewServer()
+	RegisterService(srv, s)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+	client := NewProtocolServiceClient(conn)
+
+	//when
+	stream, err := client.ExportDriver(context.Background(), &ExportDriverRequest{Language: "go"})
+	require.NoError(err)
+
+	var bundle []byte
+	for {
+		c, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.True(len(c.Data) <= bundleChunkSize)
+		bundle = append(bundle, c.Data...)
+	}
+
+	//then
+	require.Equal(s.bundle, bundle)
+
+	//when
+	s.bundle = nil
+	imp, err := client.ImportDriver(context.Background())
+	require.NoError(err)
+	require.NoError(imp.Send(&ImportDriverRequest{Update: true}))
+	for i := 0; i < len(bundle); i += bundleChunkSize {
+		end := i + bundleChunkSize
+		if end > len(bundle) {
+			end = len(bundle)
+		}
+		require.NoError(imp.Send(&ImportDriverRequest{Data: bundle[i:end]}))
+	}
+	_, err = imp.CloseAndRecv()
+
+	//then
+	require.NoError(err)
+	require.True(s.update)
+	require.Equal(bundle, s.bundle)
+
+	//when
+	s.err = ErrAlreadyInstalled.New("go", "docker://bblfsh/go-driver:latest")
+	imp, err = client.ImportDriver(context.Background())
+	require.NoError(err)
+	require.NoError(imp.Send(&ImportDriverRequest{Data: []byte("bundle")}))
+	_, err = imp.CloseAndRecv()
+
+	//then
+	require.Equal(codes.AlreadyExists, status.Code(err))
+
+	//when
+	s.err = errors.New("no space left on device")
+	stream, err = client.ExportDriver(context.Background(), &ExportDriverRequest{Language: "go"})
+	require.NoError(err)
+	for err == nil {
+		_, err = stream.Recv()
+	}
+
+	//then
+	require.Equal(codes.Unknown, status.Code(err))
+}
+
+type mockedServiceUntrustedImage struct {
+	mock.Mock
+	Service
+}
+
+func (s *mockedServiceUntrustedImage) InstallDriver(language string, image string, update bool, creds *RegistryCredentials, progress func(*InstallProgress)) error {
+	return ErrImageVerification.Wrap(errors.New("the image is not referenced by digest"))
+}
+
+func TestServiceMockDaemon_InstallUntrustedDriver(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceUntrustedImage)
+	ps := &protocolServiceServer{s}
+
+	//when
+	res, err := ps.InstallDriver(context.Background(), &InstallDriverRequest{})
+
+	//then
+	require.Nil(res)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	require.Contains(status.Convert(err).Message(), "not referenced by digest")
+}
+
+type mockedServiceReconcile struct {
+	mock.Mock
+	Service
+	dryRun bool
+}
+
+func (s *mockedServiceReconcile) ReconcileDrivers(dryRun bool) ([]*DriverChange, error) {
+	s.dryRun = dryRun
+	return []*DriverChange{
+		{Language: "go", Reference: "docker://bblfsh/go-driver:v2.7.0", Action: DriverUpgrade},
+		{Language: "java", Reference: "docker://bblfsh/java-driver:latest", Version: "v2.6.0", Action: DriverRemove, Error: "failed"},
+	}, nil
+}
+
+func TestServiceMockDaemon_ReconcileDrivers(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceReconcile)
+	ps := &protocolServiceServer{s}
+
+	//when
+	res, err := ps.ReconcileDrivers(context.Background(), &ReconcileDriversRequest{DryRun: true})
+	require.NoError(err)
+
+	data, err := res.Marshal()
+	require.NoError(err)
+
+	decoded := &ReconcileDriversResponse{}
+	err = decoded.Unmarshal(data)
+
+	//then
+	require.NoError(err)
+	require.True(s.dryRun)
+	require.Equal(res, decoded)
+	require.Len(decoded.Changes, 2)
+	require.Equal(DriverRemove, decoded.Changes[1].Action)
+}
+
+type mockedServiceNoDriversConfig struct {
+	mock.Mock
+	Service
+}
+
+func (s *mockedServiceNoDriversConfig) ReconcileDrivers(dryRun bool) ([]*DriverChange, error) {
+	return nil, ErrNoDriversConfig.New()
+}
+
+func TestServiceMockDaemon_ReconcileDriversNoConfig(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceNoDriversConfig)
+	ps := &protocolServiceServer{s}
+
+	//when
+	res, err := ps.ReconcileDrivers(context.Background(), &ReconcileDriversRequest{})
+
+	//then
+	require.Nil(res)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+type mockedServiceAuditLog struct {
+	mock.Mock
+	Service
+	limit          int
+	rpc, language  string
+	noAuditLogFile bool
+}
+
+func (s *mockedServiceAuditLog) AuditLog(limit int, rpc, language string) ([]*AuditEntry, error) {
+	if s.noAuditLogFile {
+		return nil, ErrNoAuditLog.New()
+	}
+	s.limit, s.rpc, s.language = limit, rpc, language
+	return []*AuditEntry{{
+		Time:      time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		Caller:    "uid=0,gid=0,pid=42",
+		RPC:       "InstallDriver",
+		Language:  "go",
+		Reference: "docker://bblfsh/go-driver:v2.7.0",
+		Update:    true,
+		Digest:    "sha256:5c3e",
+		Outcome:   AuditSucceeded,
+		Elapsed:   3 * time.Second,
+	}, {
+		Time:     time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC),
+		Caller:   "uid=1000,gid=1000,pid=43",
+		RPC:      "RemoveDriver",
+		Language: "go",
+		Outcome:  AuditDenied,
+		Error:    "permission denied",
+	}}, nil
+}
+
+func TestServiceMockDaemon_AuditLog(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceAuditLog)
+	ps := &protocolServiceServer{s}
+
+	//when
+	res, err := ps.AuditLog(context.Background(), &AuditLogRequest{Limit: 10, RPC: "InstallDriver", Language: "go"})
+	require.NoError(err)
+
+	data, err := res.Marshal()
+	require.NoError(err)
+
+	decoded := &AuditLogResponse{}
+	err = decoded.Unmarshal(data)
+
+	//then
+	require.NoError(err)
+	require.Equal(10, s.limit)
+	require.Equal("InstallDriver", s.rpc)
+	require.Equal("go", s.language)
+	require.Equal(res, decoded)
+	require.Len(decoded.Entries, 2)
+	require.Equal(AuditDenied, decoded.Entries[1].Outcome)
+
+	//when
+	s.noAuditLogFile = true
+	res, err = ps.AuditLog(context.Background(), &AuditLogRequest{})
+
+	//then
+	require.Nil(res)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+type mockedServiceGarbageCollect struct {
+	mock.Mock
+	Service
+}
+
+func (s *mockedServiceGarbageCollect) GarbageCollect() (*GarbageCollectResponse, error) {
+	return &GarbageCollectResponse{Layers: 2, Temporary: 1, Size: 1 << 40}, nil
+}
+
+func TestServiceMockDaemon_GarbageCollect(t *testing.T) {
+	require := require.New(t)
+	//given
+	s := new(mockedServiceGarbageCollect)
+	ps := &protocolServiceServer{s}
+
+	//when
+	res, err := ps.GarbageCollect(context.Background(), &GarbageCollectRequest{})
+	require.NoError(err)
+
+	data, err := res.Marshal()
+	require.NoError(err)
+
+	decoded := &GarbageCollectResponse{}
+	err = decoded.Unmarshal(data)
+
+	//then
+	require.NoError(err)
+	require.Equal(res, decoded)
+	require.Equal(2, decoded.Layers)
+	require.Equal(int64(1<<40), decoded.Size)
+}
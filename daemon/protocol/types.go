@@ -1,5 +1,6 @@
 //go:generate proteus -f $GOPATH/src/ -p github.com/bblfsh/bblfshd/daemon/protocol --verbose
 //go:generate stringer -type=Status -output stringer.go
+//go:generate protoc --proto_path=$GOPATH/src --gogo_out=plugins=grpc,Mgithub.com/bblfsh/sdk/v3/protocol/driver.proto=github.com/bblfsh/sdk/v3/protocol:$GOPATH/src github.com/bblfsh/bblfshd/daemon/protocol/batch.proto
 
 package protocol
 
@@ -52,6 +53,13 @@ type DriverInstanceState struct {
 	Created time.Time `json:"created"`
 	// Processes are the pids of the processes running inside of the container.
 	Processes []int `json:"processes"`
+	// HealthFailures is the number of consecutive failed health checks.
+	HealthFailures int `json:"health_failures"`
+	// LastHealthCheck is when the driver was last health checked, zero if it
+	// was never checked.
+	LastHealthCheck time.Time `json:"last_health_check"`
+	// HealthError is the error of the last failed health check.
+	HealthError string `json:"health_error"`
 }
 
 //proteus:generate
@@ -77,4 +85,99 @@ type DriverImageState struct {
 	NativeVersion []string `json:"native_version"`
 	// Go version of the go runtime being use in the driver.
 	GoVersion string `json:"go_version"`
+	// Current is true if this is the version used by the requests not asking
+	// for a specific version of the driver.
+	Current bool `json:"current"`
+	// Digest of the installed image.
+	Digest string `json:"digest"`
+	// UpdatePolicy is the policy of the updates of the driver, empty if its
+	// image reference is not checked for updates.
+	UpdatePolicy string `json:"update_policy,omitempty"`
+	// UpdateAvailable is true if the image reference pointed to a different
+	// image than the installed one at the last check.
+	UpdateAvailable bool `json:"update_available,omitempty"`
+	// LatestDigest is the digest of the image the reference pointed to at
+	// the last check.
+	LatestDigest string `json:"latest_digest,omitempty"`
+	// LastUpdateCheck is when the image reference was last checked for
+	// updates, zero if it was never checked.
+	LastUpdateCheck time.Time `json:"last_update_check"`
+	// UpdateError is the error of the last check or update of the driver.
+	UpdateError string `json:"update_error,omitempty"`
+}
+
+// DriverAction is the action taken on a driver to reconcile the installed
+// drivers with the ones listed in the drivers configuration of the daemon.
+type DriverAction string
+
+const (
+	// DriverInstall installs the listed driver of a language without any
+	// driver installed.
+	DriverInstall DriverAction = "install"
+	// DriverUpgrade installs the listed driver of a language with drivers
+	// installed from other image references, and makes it the current one.
+	DriverUpgrade DriverAction = "upgrade"
+	// DriverSetCurrent makes the listed driver, already installed, the
+	// current one of its language.
+	DriverSetCurrent DriverAction = "set-current"
+	// DriverRemove removes an installed driver not listed.
+	DriverRemove DriverAction = "remove"
+	// DriverUnlisted reports an installed driver not listed, which is kept
+	// since the configuration doesn't prune them.
+	DriverUnlisted DriverAction = "unlisted"
+)
+
+//proteus:generate
+type DriverChange struct {
+	// Language of the driver.
+	Language string `json:"language"`
+	// Reference is the image reference of the driver.
+	Reference string `json:"reference"`
+	// Version of the installed driver, empty if it is not installed yet.
+	Version string `json:"version,omitempty"`
+	// Action taken on the driver.
+	Action DriverAction `json:"action"`
+	// Error of the action, empty if it succeeded or was not applied.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditOutcome is the outcome of a control request written to the audit log.
+type AuditOutcome string
+
+const (
+	// AuditSucceeded is the outcome of the requests completed successfully.
+	AuditSucceeded AuditOutcome = "succeeded"
+	// AuditFailed is the outcome of the requests that returned an error.
+	AuditFailed AuditOutcome = "failed"
+	// AuditDenied is the outcome of the requests rejected by the control
+	// policy of the daemon.
+	AuditDenied AuditOutcome = "denied"
+)
+
+//proteus:generate
+type AuditEntry struct {
+	// Time when the request was received.
+	Time time.Time `json:"time"`
+	// Caller is the identity of the client sending the request.
+	Caller string `json:"caller"`
+	// RPC is the name of the control RPC.
+	RPC string `json:"rpc"`
+	// Language of the driver, if set by the request.
+	Language string `json:"language,omitempty"`
+	// Reference is the image reference of the driver, if set by the request.
+	Reference string `json:"reference,omitempty"`
+	// Version of the driver, if set by the request.
+	Version string `json:"version,omitempty"`
+	// Update is the update flag of the request, if any.
+	Update bool `json:"update,omitempty"`
+	// DryRun is the dry run flag of the request, if any.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Digest of the driver image the request acted on, if known.
+	Digest string `json:"digest,omitempty"`
+	// Outcome of the request.
+	Outcome AuditOutcome `json:"outcome"`
+	// Error returned by the request, if any.
+	Error string `json:"error,omitempty"`
+	// Elapsed is the time the request took, in nanoseconds.
+	Elapsed time.Duration `json:"elapsed"`
 }
@@ -0,0 +1,286 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Priority is the class of a parse request. The drivers of a pool are shared
+// between the priorities of the requests waiting for them.
+type Priority string
+
+const (
+	// PriorityInteractive is the class of the requests a user is waiting for,
+	// like the ones from an editor. It is the default for Parse requests.
+	PriorityInteractive Priority = "interactive"
+	// PriorityBatch is the class of bulk requests, like the ones from an
+	// indexer. It is the default for ParseBatch streams.
+	PriorityBatch Priority = "batch"
+)
+
+// Keys of the gRPC metadata setting the priority of the requests, and the ID
+// of the client sending them. The address of the client is used if its ID is
+// not set.
+const (
+	PriorityKey = "bblfsh-priority"
+	ClientKey   = "bblfsh-client"
+)
+
+var (
+	// InteractiveWeight and BatchWeight are the shares of the drivers of a
+	// pool given to each priority while requests of both are waiting. With the
+	// defaults, interactive requests get 4 out of 5 drivers that become idle,
+	// and batch requests get the rest, or all of them if no interactive
+	// requests are waiting.
+	//
+	// Can be changed by setting BBLFSHD_INTERACTIVE_WEIGHT and
+	// BBLFSHD_BATCH_WEIGHT.
+	InteractiveWeight = mustEnvInt("BBLFSHD_INTERACTIVE_WEIGHT", 4)
+	BatchWeight       = mustEnvInt("BBLFSHD_BATCH_WEIGHT", 1)
+)
+
+// priorities are the request priorities, ties being served in this order.
+var priorities = []Priority{PriorityInteractive, PriorityBatch}
+
+func (p Priority) weight() int {
+	w := BatchWeight
+	if p == PriorityInteractive {
+		w = InteractiveWeight
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// requestClass is the priority and the client of a request.
+type requestClass struct {
+	priority Priority
+	client   string
+}
+
+type requestClassKey struct{}
+
+// withRequestClass returns a context setting the class of the requests
+// executed with it by the driver pools.
+func withRequestClass(ctx context.Context, c requestClass) context.Context {
+	return context.WithValue(ctx, requestClassKey{}, c)
+}
+
+// requestClassFrom returns the class set on the context, or an interactive
+// request of an unknown client.
+func requestClassFrom(ctx context.Context) requestClass {
+	c, ok := ctx.Value(requestClassKey{}).(requestClass)
+	if !ok {
+		c.priority = PriorityInteractive
+	}
+	return c
+}
+
+// requestClassFromMetadata returns the class of a gRPC request, as set by the
+// metadata of the client, or the default priority if it's not set.
+func requestClassFromMetadata(ctx context.Context, def Priority) (requestClass, error) {
+	c := requestClass{priority: def}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(PriorityKey); len(v) != 0 {
+		c.priority = Priority(strings.ToLower(v[0]))
+		switch c.priority {
+		case PriorityInteractive, PriorityBatch:
+		default:
+			return c, &ErrUnknownPriority{Priority: v[0]}
+		}
+	}
+
+	if v := md.Get(ClientKey); len(v) != 0 {
+		c.client = v[0]
+	} else if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		c.client = p.Addr.String()
+		if host, _, err := net.SplitHostPort(c.client); err == nil {
+			c.client = host
+		}
+	}
+	return c, nil
+}
+
+// requestQueue holds the requests waiting for a driver of a pool. They are
+// served with weighted fair queuing: while requests of several priorities are
+// waiting, each priority is served in proportion to its weight, and the
+// clients of the same priority are served in turns.
+type requestQueue struct {
+	// ready is signalled while the queue is not empty. The channel has a
+	// buffer of 1 and sends to this channel must be used with default.
+	ready chan struct{}
+
+	mu      sync.Mutex
+	classes []*classQueue
+	// pass is the virtual time of the last request served.
+	pass float64
+}
+
+// classQueue holds the requests of a single priority.
+type classQueue struct {
+	priority Priority
+	// pass is the virtual time the next request of the priority is due. It
+	// advances by the inverse of the weight with each request served.
+	pass    float64
+	clients map[string][]driverRequest
+	// turns are the clients with waiting requests, in the order they will
+	// be served.
+	turns []string
+	n     int
+}
+
+func newRequestQueue() *requestQueue {
+	q := &requestQueue{ready: make(chan struct{}, 1)}
+	for _, p := range priorities {
+		q.classes = append(q.classes, &classQueue{
+			priority: p,
+			clients:  make(map[string][]driverRequest),
+		})
+	}
+	return q
+}
+
+func (q *requestQueue) class(p Priority) *classQueue {
+	for _, c := range q.classes {
+		if c.priority == p {
+			return c
+		}
+	}
+	return q.classes[0]
+}
+
+// signal notifies the ready channel. Must be called with the lock held.
+func (q *requestQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// push adds a request to the queue.
+func (q *requestQueue) push(cls requestClass, req driverRequest) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c := q.class(cls.priority)
+	if c.n == 0 && c.pass < q.pass {
+		// priorities don't build up credit while they have no requests
+		c.pass = q.pass
+	}
+	if len(c.clients[cls.client]) == 0 {
+		c.turns = append(c.turns, cls.client)
+	}
+	c.clients[cls.client] = append(c.clients[cls.client], req)
+	c.n++
+	q.signal()
+}
+
+// pop removes the next request to serve from the queue. Cancelled requests
+// are dropped. It returns false if the queue is empty.
+func (q *requestQueue) pop() (driverRequest, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer func() {
+		if q.len() > 0 {
+			q.signal()
+		}
+	}()
+
+	for {
+		var next *classQueue
+		for _, c := range q.classes {
+			if c.n > 0 && (next == nil || c.pass < next.pass) {
+				next = c
+			}
+		}
+		if next == nil {
+			return driverRequest{}, false
+		}
+
+		client := next.turns[0]
+		reqs := next.clients[client]
+		req := reqs[0]
+		next.remove(client, 0, true)
+
+		select {
+		case <-req.cancel:
+			continue
+		default:
+		}
+		q.pass = next.pass
+		next.pass += 1 / float64(next.priority.weight())
+		return req, true
+	}
+}
+
+// remove drops a request from the queue, if it's still waiting.
+func (q *requestQueue) remove(cls requestClass, req driverRequest) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c := q.class(cls.priority)
+	for i, r := range c.clients[cls.client] {
+		if r.out == req.out {
+			c.remove(cls.client, i, false)
+			return
+		}
+	}
+}
+
+// len returns the number of requests in the queue. Must be called with the
+// lock held.
+func (q *requestQueue) len() int {
+	n := 0
+	for _, c := range q.classes {
+		n += c.n
+	}
+	return n
+}
+
+// Len returns the number of requests in the queue.
+func (q *requestQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len()
+}
+
+// Depth returns the number of requests of the priority in the queue.
+func (q *requestQueue) Depth(p Priority) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.class(p).n
+}
+
+// remove drops the i-th request of the client. If served is set, the client
+// moves to the end of the turns.
+func (c *classQueue) remove(client string, i int, served bool) {
+	reqs := c.clients[client]
+	reqs = append(reqs[:i], reqs[i+1:]...)
+	c.n--
+	if len(reqs) != 0 {
+		c.clients[client] = reqs
+		if !served {
+			return
+		}
+	} else {
+		delete(c.clients, client)
+	}
+
+	for j, cl := range c.turns {
+		if cl == client {
+			c.turns = append(c.turns[:j], c.turns[j+1:]...)
+			break
+		}
+	}
+	if len(reqs) != 0 {
+		c.turns = append(c.turns, client)
+	}
+}
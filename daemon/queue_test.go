@@ -0,0 +1,122 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type testQueue struct {
+	*requestQueue
+	names   map[chan<- Driver]string
+	cancels map[chan<- Driver]chan struct{}
+}
+
+func newTestQueue() *testQueue {
+	return &testQueue{
+		requestQueue: newRequestQueue(),
+		names:        make(map[chan<- Driver]string),
+		cancels:      make(map[chan<- Driver]chan struct{}),
+	}
+}
+
+func (q *testQueue) pushN(p Priority, client string, n int) []driverRequest {
+	var reqs []driverRequest
+	for i := 0; i < n; i++ {
+		out, cancel := make(chan Driver), make(chan struct{})
+		req := driverRequest{out: out, cancel: cancel}
+		q.names[out] = client
+		q.cancels[out] = cancel
+		q.push(requestClass{priority: p, client: client}, req)
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+func (q *testQueue) popN(n int) string {
+	var out []string
+	for i := 0; i < n; i++ {
+		req, ok := q.pop()
+		if !ok {
+			break
+		}
+		out = append(out, q.names[req.out])
+	}
+	return strings.Join(out, " ")
+}
+
+func TestRequestQueue(t *testing.T) {
+	require := require.New(t)
+
+	q := newTestQueue()
+	_, ok := q.pop()
+	require.False(ok)
+
+	q.pushN(PriorityBatch, "a", 4)
+	q.pushN(PriorityBatch, "b", 4)
+	q.pushN(PriorityInteractive, "ide", 8)
+	require.Equal(16, q.Len())
+	require.Equal(8, q.Depth(PriorityBatch))
+
+	// interactive requests get 4 drivers out of 5, and the batch clients
+	// are served in turns
+	require.Equal("ide a ide ide ide ide b ide ide ide", q.popN(10))
+	require.Equal(0, q.Depth(PriorityInteractive))
+	require.Equal("a b a b a b", q.popN(10))
+	require.Equal(0, q.Len())
+
+	// the batch requests served while no interactive ones were waiting
+	// don't delay the next ones
+	q.pushN(PriorityBatch, "a", 10)
+	require.Equal("a a a a a a a a a a", q.popN(10))
+	q.pushN(PriorityInteractive, "ide", 20)
+	q.pushN(PriorityBatch, "a", 1)
+	require.Equal("ide ide ide ide ide a", q.popN(6))
+}
+
+func TestRequestQueue_Cancel(t *testing.T) {
+	require := require.New(t)
+
+	q := newTestQueue()
+	a := q.pushN(PriorityBatch, "a", 2)
+	b := q.pushN(PriorityBatch, "b", 2)
+
+	close(q.cancels[a[0].out])
+	q.remove(requestClass{priority: PriorityBatch, client: "b"}, b[0])
+	require.Equal(3, q.Len())
+
+	// cancelled requests are dropped
+	require.Equal("b a", q.popN(2))
+	require.Equal(0, q.Len())
+}
+
+func TestRequestClassFromMetadata(t *testing.T) {
+	require := require.New(t)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242},
+	})
+
+	c, err := requestClassFromMetadata(ctx, PriorityBatch)
+	require.NoError(err)
+	require.Equal(requestClass{priority: PriorityBatch, client: "10.0.0.1"}, c)
+
+	c, err = requestClassFromMetadata(metadata.NewIncomingContext(ctx, metadata.Pairs(
+		PriorityKey, "Interactive",
+		ClientKey, "ide",
+	)), PriorityBatch)
+	require.NoError(err)
+	require.Equal(requestClass{priority: PriorityInteractive, client: "ide"}, c)
+
+	_, err = requestClassFromMetadata(metadata.NewIncomingContext(ctx, metadata.Pairs(
+		PriorityKey, "urgent",
+	)), PriorityBatch)
+	require.Error(err)
+
+	require.Equal(PriorityInteractive, requestClassFrom(context.Background()).priority)
+}
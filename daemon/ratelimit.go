@@ -0,0 +1,475 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+	protocol1 "gopkg.in/bblfsh/sdk.v1/protocol"
+)
+
+var (
+	// ErrInvalidRateLimitsConfig is returned if the rate limits configuration
+	// file cannot be parsed or describes invalid limits.
+	ErrInvalidRateLimitsConfig = errors.NewKind("invalid rate limits configuration %s: %s")
+	// ErrNoRateLimitsConfig is returned when reloading the rate limits
+	// configuration if no configuration file was loaded.
+	ErrNoRateLimitsConfig = errors.NewKind("no rate limits configuration file was loaded")
+)
+
+// APIKeyKey is the key of the gRPC metadata with the API key of the client,
+// used to identify it by the rate limits.
+const APIKeyKey = "bblfsh-api-key"
+
+// concurrencyRetryAfter is the retry hint of the requests rejected for having
+// too many requests in flight.
+const concurrencyRetryAfter = time.Second
+
+// rateLimitSweepInterval is the interval between the removals of the state of
+// the clients that are not limited anymore.
+const rateLimitSweepInterval = time.Minute
+
+// IdentitySource is a source of the identity of the clients.
+type IdentitySource string
+
+const (
+	// IdentityTLS identifies clients by the subject of their TLS certificate.
+	IdentityTLS IdentitySource = "tls"
+	// IdentityAPIKey identifies clients by the API key set in the
+	// bblfsh-api-key metadata, if it's one of the keys of the configuration.
+	IdentityAPIKey IdentitySource = "api-key"
+	// IdentityAddress identifies clients by their IP address.
+	IdentityAddress IdentitySource = "address"
+)
+
+// defaultIdentity is the order the sources of the identity of the clients are
+// tried in, if not set by the configuration.
+var defaultIdentity = []IdentitySource{IdentityTLS, IdentityAPIKey, IdentityAddress}
+
+// RateLimit is the limits of the requests of each client. Zero values are
+// unlimited.
+type RateLimit struct {
+	// Rate is the number of requests per second a client may send.
+	Rate float64 `yaml:"rate"`
+	// Burst is the number of requests a client may send at once above the
+	// rate. Defaults to the rate, rounded up.
+	Burst int `yaml:"burst"`
+	// Concurrent is the number of requests of a client that may be in flight.
+	Concurrent int `yaml:"concurrent"`
+}
+
+func (l RateLimit) validate() error {
+	switch {
+	case l.Rate < 0:
+		return fmt.Errorf("rate must not be negative")
+	case l.Burst < 0:
+		return fmt.Errorf("burst must not be negative")
+	case l.Concurrent < 0:
+		return fmt.Errorf("concurrent must not be negative")
+	case l.Burst > 0 && l.Rate == 0:
+		return fmt.Errorf("burst requires a rate")
+	}
+	return nil
+}
+
+func (l RateLimit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+// RateLimitsConfig is the configuration of the rate limits of the user API,
+// loaded from a YAML file:
+//
+//   identity: [tls, api-key, address]
+//   api-keys: [...]
+//   default:
+//     rate: 100
+//     burst: 200
+//     concurrent: 16
+//   languages:
+//     java:
+//       rate: 10
+//       concurrent: 4
+//
+// The default limits apply to all the requests of a client, and the limits of
+// a language to its requests for that language, on top of the default ones.
+// Clients are identified by the first source of the identity list they can be
+// identified by. API keys are chosen by the clients, so only the listed ones
+// identify them: a client sending any other key would otherwise escape its
+// limits by sending a new key with each request.
+type RateLimitsConfig struct {
+	// Identity are the sources of the identity of the clients, in order.
+	Identity []IdentitySource `yaml:"identity"`
+	// APIKeys are the API keys the clients may be identified by.
+	APIKeys []string `yaml:"api-keys"`
+	// Default are the limits of all the requests of a client.
+	Default RateLimit `yaml:"default"`
+	// Languages are the limits of the requests of a client for each language.
+	Languages map[string]RateLimit `yaml:"languages"`
+}
+
+// LoadRateLimitsConfig reads and validates the rate limits configuration file.
+func LoadRateLimitsConfig(path string) (*RateLimitsConfig, error) {
+	var c RateLimitsConfig
+	if err := readConfig(path, &c, ErrInvalidRateLimitsConfig); err != nil {
+		return nil, err
+	}
+
+	if len(c.Identity) == 0 {
+		c.Identity = defaultIdentity
+	}
+	for _, src := range c.Identity {
+		switch src {
+		case IdentityTLS, IdentityAPIKey, IdentityAddress:
+		default:
+			return nil, ErrInvalidRateLimitsConfig.New(path, fmt.Errorf("unknown identity source %q", src))
+		}
+	}
+
+	for _, key := range c.APIKeys {
+		if key == "" {
+			return nil, ErrInvalidRateLimitsConfig.New(path, fmt.Errorf("empty API key"))
+		}
+	}
+
+	if err := c.Default.validate(); err != nil {
+		return nil, ErrInvalidRateLimitsConfig.New(path, err)
+	}
+
+	langs := make(map[string]RateLimit, len(c.Languages))
+	for lang, l := range c.Languages {
+		if err := l.validate(); err != nil {
+			return nil, ErrInvalidRateLimitsConfig.New(path, fmt.Errorf("%s: %s", lang, err))
+		}
+		langs[strings.ToLower(lang)] = l
+	}
+	c.Languages = langs
+	return &c, nil
+}
+
+// validAPIKey returns true if the key is one of the API keys of the
+// configuration.
+func (c *RateLimitsConfig) validAPIKey(key string) bool {
+	valid := false
+	for _, k := range c.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// limitKey identifies the state of the limits of a client, for all its
+// requests if the language is empty, or for its requests for the language.
+type limitKey struct {
+	client   string
+	language string
+}
+
+// limitState is the token bucket and the requests in flight of a client.
+type limitState struct {
+	tokens   float64
+	last     time.Time
+	inFlight int
+}
+
+// refill adds the tokens earned since the last request.
+func (s *limitState) refill(l RateLimit, now time.Time) {
+	if l.Rate > 0 {
+		s.tokens = math.Min(l.burst(), s.tokens+now.Sub(s.last).Seconds()*l.Rate)
+	}
+	s.last = now
+}
+
+// check returns the reason the request is rejected, and when it may be
+// retried, or an empty reason if it's allowed.
+func (s *limitState) check(l RateLimit, concurrent bool) (string, time.Duration) {
+	if concurrent && l.Concurrent > 0 && s.inFlight >= l.Concurrent {
+		return "concurrency", concurrencyRetryAfter
+	}
+	if l.Rate > 0 && s.tokens < 1 {
+		return "rate", time.Duration((1 - s.tokens) / l.Rate * float64(time.Second))
+	}
+	return "", 0
+}
+
+// RateLimiter enforces the rate limits of the requests to the user API. Its
+// interceptors must be passed to the gRPC server, and don't limit any request
+// until a configuration is loaded.
+type RateLimiter struct {
+	mu     sync.Mutex
+	config *RateLimitsConfig
+	file   configFile
+	state  map[limitKey]*limitState
+	swept  time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter creates a new rate limiter without limits.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		state: make(map[limitKey]*limitState),
+		now:   time.Now,
+	}
+}
+
+// ServerOptions returns the gRPC server options installing the interceptors of
+// the limiter.
+func (l *RateLimiter) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(l.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(l.StreamServerInterceptor()),
+	}
+}
+
+// LoadConfig loads the rate limits configuration file. The clients keep the
+// state of their limits.
+func (l *RateLimiter) LoadConfig(path string) error {
+	c, err := LoadRateLimitsConfig(path)
+	if err != nil {
+		return err
+	}
+	l.SetConfig(c)
+	l.file.set(path)
+	return nil
+}
+
+// ReloadConfig reads again the rate limits configuration file loaded by
+// LoadConfig. The previous configuration is kept if the file is not valid.
+func (l *RateLimiter) ReloadConfig() error {
+	return l.file.reload(ErrNoRateLimitsConfig, l.LoadConfig)
+}
+
+// SetConfig sets the rate limits. A nil configuration disables them.
+func (l *RateLimiter) SetConfig(c *RateLimitsConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = c
+}
+
+// UnaryServerInterceptor returns the interceptor limiting the unary requests.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := l.acquire(ctx, req, true)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the interceptor limiting the streams. A
+// stream counts as a single request in flight, and each message received
+// counts towards the rate of the client. The messages are checked by the
+// handler with limitMessage, so a message over the limits fails on its own
+// instead of failing the whole stream.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		release, err := l.acquire(ctx, nil, true)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		ctx = context.WithValue(ctx, messageLimitKey{}, func(m interface{}) error {
+			release, err := l.acquire(ctx, m, false)
+			if err != nil {
+				return err
+			}
+			release()
+			return nil
+		})
+		return handler(srv, &limitedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// limitedStream is a stream whose messages are limited with limitMessage.
+type limitedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream.
+func (s *limitedStream) Context() context.Context {
+	return s.ctx
+}
+
+type messageLimitKey struct{}
+
+// limitMessage checks the rate limits of a message received by a stream, and
+// returns ErrRateLimited if it's over the limits of its client. Messages of
+// streams not limited by a RateLimiter are always allowed.
+func limitMessage(ctx context.Context, m interface{}) error {
+	if limit, ok := ctx.Value(messageLimitKey{}).(func(interface{}) error); ok {
+		return limit(m)
+	}
+	return nil
+}
+
+// acquire checks the limits of the client sending the request, and takes a
+// token from its buckets. If concurrent is set, the request is counted in
+// flight until release is called.
+func (l *RateLimiter) acquire(ctx context.Context, req interface{}, concurrent bool) (func(), error) {
+	l.mu.Lock()
+	c := l.config
+	l.mu.Unlock()
+	if c == nil {
+		return func() {}, nil
+	}
+
+	client := clientIdentity(ctx, c)
+	keys := []limitKey{{client: client}}
+	limits := []RateLimit{c.Default}
+	var lang string
+	if len(c.Languages) != 0 {
+		lang = requestLanguage(req)
+		if ll, ok := c.Languages[lang]; ok {
+			keys = append(keys, limitKey{client: client, language: lang})
+			limits = append(limits, ll)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.swept) > rateLimitSweepInterval {
+		l.sweep(c, now)
+	}
+
+	states := make([]*limitState, len(keys))
+	for i, k := range keys {
+		s, ok := l.state[k]
+		if !ok {
+			s = &limitState{tokens: limits[i].burst()}
+			l.state[k] = s
+		}
+		s.refill(limits[i], now)
+		if reason, retry := s.check(limits[i], concurrent); reason != "" {
+			requestsRateLimited.WithLabelValues(k.language, reason).Add(1)
+			return nil, &ErrRateLimited{Client: client, Reason: reason, RetryAfter: retry}
+		}
+		states[i] = s
+	}
+
+	for i, s := range states {
+		if limits[i].Rate > 0 {
+			s.tokens--
+		}
+		if concurrent {
+			s.inFlight++
+		}
+	}
+	if !concurrent {
+		return func() {}, nil
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			for _, s := range states {
+				s.inFlight--
+			}
+		})
+	}, nil
+}
+
+// sweep removes the state of the clients without requests in flight and with
+// full buckets, which are the same as a new state. Must be called with the
+// lock held.
+func (l *RateLimiter) sweep(c *RateLimitsConfig, now time.Time) {
+	l.swept = now
+	for k, s := range l.state {
+		lim := c.Default
+		if k.language != "" {
+			lim = c.Languages[k.language]
+		}
+		s.refill(lim, now)
+		if s.inFlight == 0 && (lim.Rate == 0 || s.tokens >= lim.burst()) {
+			delete(l.state, k)
+		}
+	}
+}
+
+// clientIdentity returns the identity of the client of a request, taken from
+// the first source of the configuration it can be identified by. Clients that
+// cannot be identified share an empty identity.
+func clientIdentity(ctx context.Context, c *RateLimitsConfig) string {
+	p, _ := peer.FromContext(ctx)
+	for _, src := range c.Identity {
+		switch src {
+		case IdentityTLS:
+			if p == nil {
+				continue
+			}
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) != 0 {
+				return "tls:" + info.State.PeerCertificates[0].Subject.String()
+			}
+		case IdentityAPIKey:
+			md, _ := metadata.FromIncomingContext(ctx)
+			if v := md.Get(APIKeyKey); len(v) != 0 && c.validAPIKey(v[0]) {
+				// the key is a secret, and the identity is shown in errors
+				return "api-key:" + hashSHA1(v[0])[:12]
+			}
+		case IdentityAddress:
+			if p == nil || p.Addr == nil {
+				continue
+			}
+			addr := p.Addr.String()
+			if host, _, err := net.SplitHostPort(addr); err == nil {
+				addr = host
+			}
+			return "address:" + addr
+		}
+	}
+	return ""
+}
+
+// requestLanguage returns the language of a parse request, detecting it if
+// it's not set, or an empty string for other requests.
+func requestLanguage(req interface{}) string {
+	var language, filename, content string
+	switch r := req.(type) {
+	case *protocol2.ParseRequest:
+		language, filename, content = r.Language, r.Filename, r.Content
+	case *protocol.ParseBatchRequest:
+		if r.Request == nil {
+			return ""
+		}
+		language, filename, content = r.Request.Language, r.Request.Filename, r.Request.Content
+	case *protocol1.ParseRequest:
+		language, filename, content = r.Language, r.Filename, r.Content
+	case *protocol1.NativeParseRequest:
+		language, filename, content = r.Language, r.Filename, r.Content
+	default:
+		return ""
+	}
+
+	language, _ = splitVersion(language)
+	if language == "" {
+		return GetLanguage(filename, []byte(content))
+	}
+	return normalize(language)
+}
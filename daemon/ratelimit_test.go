@@ -0,0 +1,208 @@
+package daemon
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadRateLimitsConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-limits")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "limits.yml", `
+api-keys: [secret]
+default:
+  rate: 100
+  concurrent: 16
+languages:
+  Java:
+    rate: 0.5
+    burst: 2
+`)
+	c, err := LoadRateLimitsConfig(path)
+	require.NoError(err)
+	require.Equal(defaultIdentity, c.Identity)
+	require.Equal([]string{"secret"}, c.APIKeys)
+	require.Equal(RateLimit{Rate: 100, Concurrent: 16}, c.Default)
+	require.Equal(RateLimit{Rate: 0.5, Burst: 2}, c.Languages["java"])
+	require.Equal(100.0, c.Default.burst())
+	require.Equal(1.0, RateLimit{Rate: 0.5}.burst())
+
+	for _, data := range []string{
+		"default: {rate: -1}",
+		"default: {burst: 10}",
+		"languages: {java: {concurrent: -1}}",
+		"identity: [cookie]",
+		"default: {rate: 1, requests: 2}",
+		"api-keys: ['']",
+	} {
+		_, err := LoadRateLimitsConfig(writeConfig(t, dir, "limits.yml", data))
+		require.True(ErrInvalidRateLimitsConfig.Is(err), "%q: %v", data, err)
+	}
+}
+
+func clientContext(addr string, md ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 4242},
+	})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(md...))
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter()
+	l.now = func() time.Time { return now }
+
+	alice, bob := clientContext("10.0.0.1"), clientContext("10.0.0.2")
+	goReq := &protocol2.ParseRequest{Language: "go"}
+	javaReq := &protocol2.ParseRequest{Language: "java@v2.6.0"}
+
+	// no limits until a configuration is set
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire(alice, goReq, true)
+		require.NoError(err)
+	}
+
+	l.SetConfig(&RateLimitsConfig{
+		Identity:  defaultIdentity,
+		Default:   RateLimit{Rate: 2, Burst: 3, Concurrent: 2},
+		Languages: map[string]RateLimit{"java": {Rate: 1}},
+	})
+
+	// concurrent requests
+	r1, err := l.acquire(alice, goReq, true)
+	require.NoError(err)
+	r2, err := l.acquire(alice, goReq, true)
+	require.NoError(err)
+	_, err = l.acquire(alice, goReq, true)
+	require.Equal(&ErrRateLimited{Client: "address:10.0.0.1", Reason: "concurrency", RetryAfter: concurrencyRetryAfter}, err)
+	r1()
+	r1() // released once
+	r2()
+
+	// the burst is used, and tokens are refilled at the rate
+	_, err = l.acquire(alice, goReq, false)
+	require.NoError(err)
+	_, err = l.acquire(alice, goReq, false)
+	require.Equal(&ErrRateLimited{Client: "address:10.0.0.1", Reason: "rate", RetryAfter: time.Second / 2}, err)
+
+	_, err = l.acquire(bob, goReq, false)
+	require.NoError(err)
+
+	now = now.Add(time.Second)
+	_, err = l.acquire(alice, javaReq, false)
+	require.NoError(err)
+	_, err = l.acquire(alice, javaReq, false)
+	require.Error(err, "java rate")
+	_, err = l.acquire(alice, goReq, false)
+	require.NoError(err)
+
+	// the state of the clients not limited anymore is removed
+	now = now.Add(2 * rateLimitSweepInterval)
+	_, err = l.acquire(bob, goReq, false)
+	require.NoError(err)
+	require.Len(l.state, 1)
+}
+
+func TestRateLimiterInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	l := NewRateLimiter()
+	l.SetConfig(&RateLimitsConfig{
+		Identity: []IdentitySource{IdentityAPIKey, IdentityAddress},
+		APIKeys:  []string{"secret", "other"},
+		Default:  RateLimit{Rate: 1},
+	})
+	intercept := l.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	ctx := clientContext("10.0.0.1", APIKeyKey, "secret")
+	resp, err := intercept(ctx, &protocol2.ParseRequest{}, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(err)
+	require.Equal("ok", resp)
+
+	_, err = intercept(ctx, &protocol2.ParseRequest{}, &grpc.UnaryServerInfo{}, handler)
+	st := status.Convert(err)
+	require.Equal(codes.ResourceExhausted, st.Code())
+	require.NotContains(st.Message(), "secret")
+	require.Len(st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(ok)
+	require.True(info.RetryDelay.Seconds <= 1 && info.RetryDelay.Nanos >= 0)
+
+	// another key is another client
+	ctx = clientContext("10.0.0.1", APIKeyKey, "other")
+	_, err = intercept(ctx, &protocol2.ParseRequest{}, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(err)
+
+	// unknown keys are ignored, so they don't escape the limits
+	ctx = clientContext("10.0.0.1", APIKeyKey, "unknown")
+	_, err = intercept(ctx, &protocol2.ParseRequest{}, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(err)
+	ctx = clientContext("10.0.0.1", APIKeyKey, "unknown-2")
+	_, err = intercept(ctx, &protocol2.ParseRequest{}, &grpc.UnaryServerInfo{}, handler)
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+	require.Contains(status.Convert(err).Message(), "address:10.0.0.1")
+}
+
+// streamContext is a server stream with the given context.
+type streamContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s streamContext) Context() context.Context { return s.ctx }
+
+func TestRateLimiterStreamInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	l := NewRateLimiter()
+	l.SetConfig(&RateLimitsConfig{
+		Identity: defaultIdentity,
+		Default:  RateLimit{Rate: 1, Burst: 3, Concurrent: 1},
+	})
+	intercept := l.StreamServerInterceptor()
+	ss := streamContext{ctx: clientContext("10.0.0.1")}
+
+	// the messages over the limits fail on their own
+	var errs []error
+	err := intercept(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		for i := 0; i < 3; i++ {
+			errs = append(errs, limitMessage(ss.Context(), &protocol2.ParseRequest{}))
+		}
+
+		// a stream counts as a request in flight
+		return intercept(nil, ss, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+	})
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+
+	require.Len(errs, 3)
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.Equal(codes.ResourceExhausted, status.Code(errs[2]))
+
+	// streams not limited allow all the messages
+	require.NoError(limitMessage(context.Background(), &protocol2.ParseRequest{}))
+}
@@ -0,0 +1,237 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/bblfsh/bblfshd/runtime"
+
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+var (
+	// ErrInvalidDriversConfig is returned if the drivers configuration file
+	// cannot be parsed or lists an invalid image reference.
+	ErrInvalidDriversConfig = errors.NewKind("invalid drivers configuration %s: %s")
+	// ErrNoDriversConfig is returned when reconciling the installed drivers
+	// if the daemon was not started with a drivers configuration file.
+	ErrNoDriversConfig = protocol.ErrNoDriversConfig
+)
+
+// DriversConfig is the set of drivers the daemon should have installed,
+// loaded from a YAML file:
+//
+//   prune: true
+//   drivers:
+//     go: docker://bblfsh/go-driver:v2.7.0
+//     python: docker://bblfsh/python-driver@sha256:...
+//
+// Each language is mapped to the image reference of its driver, which can be
+// pinned by the digest of its manifest. The drivers of the languages not
+// listed, and the other images of the listed ones, are removed if Prune is
+// set, and kept otherwise.
+type DriversConfig struct {
+	// Prune removes the installed drivers not listed.
+	Prune bool `yaml:"prune"`
+	// Drivers are the image references of the driver of each language.
+	Drivers map[string]string `yaml:"drivers"`
+}
+
+// LoadDriversConfig reads and validates the drivers configuration file.
+func LoadDriversConfig(path string) (*DriversConfig, error) {
+	var c DriversConfig
+	if err := readConfig(path, &c, ErrInvalidDriversConfig); err != nil {
+		return nil, err
+	}
+
+	drivers := make(map[string]string, len(c.Drivers))
+	for lang, ref := range c.Drivers {
+		if _, err := runtime.ParseImageName(ref); err != nil {
+			return nil, ErrInvalidDriversConfig.New(path, fmt.Errorf("%s: %s", lang, err))
+		}
+		drivers[strings.ToLower(lang)] = ref
+	}
+	c.Drivers = drivers
+	return &c, nil
+}
+
+// Plan returns the changes needed to reconcile the installed drivers with the
+// configuration, sorted by language.
+func (c *DriversConfig) Plan(list []*runtime.DriverImageStatus) []*protocol.DriverChange {
+	var changes []*protocol.DriverChange
+	for lang, ref := range c.Drivers {
+		installed := driversWithLang(lang, list)
+
+		var found *runtime.DriverImageStatus
+		for _, dr := range installed {
+			if dr.Reference == ref {
+				found = dr
+			}
+		}
+
+		change := &protocol.DriverChange{Language: lang, Reference: ref}
+		switch {
+		case found != nil && found.Current:
+			continue
+		case found != nil:
+			change.Version = found.Manifest.Version
+			change.Action = protocol.DriverSetCurrent
+		case len(installed) == 0:
+			change.Action = protocol.DriverInstall
+		default:
+			change.Action = protocol.DriverUpgrade
+		}
+		changes = append(changes, change)
+	}
+
+	action := protocol.DriverUnlisted
+	if c.Prune {
+		action = protocol.DriverRemove
+	}
+	for _, dr := range list {
+		lang := strings.ToLower(dr.Manifest.Language)
+		if ref, ok := c.Drivers[lang]; ok && ref == dr.Reference {
+			continue
+		}
+
+		changes = append(changes, &protocol.DriverChange{
+			Language:  lang,
+			Reference: dr.Reference,
+			Version:   dr.Manifest.Version,
+			Action:    action,
+		})
+	}
+
+	// removals go last, so a language keeps its drivers until the listed one
+	// is installed
+	sort.SliceStable(changes, func(i, j int) bool {
+		ri := changes[i].Action == protocol.DriverRemove
+		rj := changes[j].Action == protocol.DriverRemove
+		if ri != rj {
+			return rj
+		}
+		return changes[i].Language < changes[j].Language
+	})
+	return changes
+}
+
+// LoadDriversConfig loads the drivers configuration file. The installed
+// drivers are reconciled with it by ReconcileDrivers.
+func (d *Daemon) LoadDriversConfig(path string) error {
+	c, err := LoadDriversConfig(path)
+	if err != nil {
+		return err
+	}
+
+	d.configMu.Lock()
+	d.drivers = c
+	d.configMu.Unlock()
+	d.driversFile.set(path)
+	return nil
+}
+
+// ReloadDriversConfig reads again the drivers configuration file loaded by
+// LoadDriversConfig. The previous configuration is kept if the file is not
+// valid.
+func (d *Daemon) ReloadDriversConfig() error {
+	return d.driversFile.reload(ErrNoDriversConfig, d.LoadDriversConfig)
+}
+
+// ReconcileDrivers installs, upgrades and removes drivers until the installed
+// ones match the drivers configuration, and returns the changes made. Failed
+// changes report their error, and the drivers of a language whose listed
+// driver failed to install are not removed. If dryRun is set, the changes are
+// only returned.
+func (d *Daemon) ReconcileDrivers(ctx context.Context, dryRun bool) ([]*protocol.DriverChange, error) {
+	d.configMu.RLock()
+	c := d.drivers
+	d.configMu.RUnlock()
+	if c == nil {
+		return nil, ErrNoDriversConfig.New()
+	}
+
+	// concurrent reconciliations would plan the same changes
+	d.reconcileMu.Lock()
+	defer d.reconcileMu.Unlock()
+
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return nil, ErrRuntime.Wrap(err)
+	}
+
+	changes := c.Plan(list)
+	if dryRun {
+		return changes, nil
+	}
+
+	failed := make(map[string]bool)
+	for _, ch := range changes {
+		if ctx.Err() != nil {
+			return changes, ctx.Err()
+		}
+
+		var err error
+		switch ch.Action {
+		case protocol.DriverInstall, protocol.DriverUpgrade:
+			err = d.InstallDriver(ch.Language, ch.Reference, true, nil, nil)
+		case protocol.DriverSetCurrent:
+			err = d.SetCurrentDriver(ch.Language, ch.Version)
+		case protocol.DriverRemove:
+			if failed[ch.Language] {
+				err = fmt.Errorf("kept since the driver listed for %s is not installed", ch.Language)
+				break
+			}
+			err = d.removeUnlisted(ch)
+		case protocol.DriverUnlisted:
+			log.Infof("driver %s (%s, version %s) is not listed in the drivers configuration",
+				ch.Language, ch.Reference, ch.Version)
+			continue
+		}
+
+		if err != nil {
+			failed[ch.Language] = true
+			ch.Error = err.Error()
+			log.Errorf(err, "error reconciling driver %s: %s %s", ch.Language, ch.Action, ch.Reference)
+			continue
+		}
+		log.Infof("driver %s reconciled: %s %s", ch.Language, ch.Action, ch.Reference)
+	}
+	return changes, nil
+}
+
+// removeUnlisted removes the driver image of a planned removal. The image is
+// looked up by its reference, since removing by version would also remove the
+// listed driver if it replaced the image in place.
+func (d *Daemon) removeUnlisted(ch *protocol.DriverChange) error {
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	dr := unlistedDriver(ch, list)
+	if dr == nil {
+		log.Infof("driver %s (%s, version %s) was replaced by the listed driver",
+			ch.Language, ch.Reference, ch.Version)
+		return nil
+	}
+	return d.removeDriver(dr)
+}
+
+// unlistedDriver returns the installed driver image removed by a change, or
+// nil if it's not installed anymore. Upgrading a language to an image of a
+// version already installed, such as pinning by digest the image of a tag,
+// replaces the installed image of that version.
+func unlistedDriver(ch *protocol.DriverChange, list []*runtime.DriverImageStatus) *runtime.DriverImageStatus {
+	for _, dr := range driversWithLang(ch.Language, list) {
+		if dr.Reference == ch.Reference && dr.Manifest.Version == ch.Version {
+			return dr
+		}
+	}
+	return nil
+}
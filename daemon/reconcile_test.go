@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/bblfsh/bblfshd/runtime"
+
+	"github.com/bblfsh/sdk/v3/driver/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDriversConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-drivers")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "drivers.yml", `
+prune: true
+drivers:
+  Go: docker://bblfsh/go-driver:v2.7.0
+  python: docker://bblfsh/python-driver@sha256:0000000000000000000000000000000000000000000000000000000000000000
+`)
+	c, err := LoadDriversConfig(path)
+	require.NoError(err)
+	require.True(c.Prune)
+	require.Equal("docker://bblfsh/go-driver:v2.7.0", c.Drivers["go"])
+	require.Len(c.Drivers, 2)
+
+	for _, data := range []string{
+		"drivers: {go: bblfsh/go-driver}",
+		"drivers: {go: docker://bblfsh/go-driver:v2.7.0}\nremove: true",
+		"drivers: [go]",
+	} {
+		_, err := LoadDriversConfig(writeConfig(t, dir, "drivers.yml", data))
+		require.True(ErrInvalidDriversConfig.Is(err), "%q: %v", data, err)
+	}
+}
+
+func TestDriversConfigPlan(t *testing.T) {
+	require := require.New(t)
+
+	installed := func(lang, ref, version string, current bool) *runtime.DriverImageStatus {
+		return &runtime.DriverImageStatus{
+			Reference: ref,
+			Manifest:  &manifest.Manifest{Language: lang, Version: version},
+			Current:   current,
+		}
+	}
+
+	list := []*runtime.DriverImageStatus{
+		installed("go", "docker://bblfsh/go-driver:v2.6.0", "v2.6.0", true),
+		installed("python", "docker://bblfsh/python-driver:v2.9.0", "v2.9.0", true),
+		installed("java", "docker://bblfsh/java-driver:v2.5.0", "v2.5.0", true),
+		installed("java", "docker://bblfsh/java-driver:v2.6.0", "v2.6.0", false),
+		installed("ruby", "docker://bblfsh/ruby-driver:latest", "v2.1.0", true),
+	}
+
+	c := &DriversConfig{Drivers: map[string]string{
+		"go":     "docker://bblfsh/go-driver:v2.7.0",
+		"python": "docker://bblfsh/python-driver:v2.9.0",
+		"java":   "docker://bblfsh/java-driver:v2.6.0",
+		"rust":   "docker://bblfsh/rust-driver:latest",
+	}}
+
+	require.Equal([]*protocol.DriverChange{
+		{Language: "go", Reference: "docker://bblfsh/go-driver:v2.7.0", Action: protocol.DriverUpgrade},
+		{Language: "go", Reference: "docker://bblfsh/go-driver:v2.6.0", Version: "v2.6.0", Action: protocol.DriverUnlisted},
+		{Language: "java", Reference: "docker://bblfsh/java-driver:v2.6.0", Version: "v2.6.0", Action: protocol.DriverSetCurrent},
+		{Language: "java", Reference: "docker://bblfsh/java-driver:v2.5.0", Version: "v2.5.0", Action: protocol.DriverUnlisted},
+		{Language: "ruby", Reference: "docker://bblfsh/ruby-driver:latest", Version: "v2.1.0", Action: protocol.DriverUnlisted},
+		{Language: "rust", Reference: "docker://bblfsh/rust-driver:latest", Action: protocol.DriverInstall},
+	}, c.Plan(list))
+
+	// removals are done once the listed drivers are installed
+	c.Prune = true
+	require.Equal([]*protocol.DriverChange{
+		{Language: "go", Reference: "docker://bblfsh/go-driver:v2.7.0", Action: protocol.DriverUpgrade},
+		{Language: "java", Reference: "docker://bblfsh/java-driver:v2.6.0", Version: "v2.6.0", Action: protocol.DriverSetCurrent},
+		{Language: "rust", Reference: "docker://bblfsh/rust-driver:latest", Action: protocol.DriverInstall},
+		{Language: "go", Reference: "docker://bblfsh/go-driver:v2.6.0", Version: "v2.6.0", Action: protocol.DriverRemove},
+		{Language: "java", Reference: "docker://bblfsh/java-driver:v2.5.0", Version: "v2.5.0", Action: protocol.DriverRemove},
+		{Language: "ruby", Reference: "docker://bblfsh/ruby-driver:latest", Version: "v2.1.0", Action: protocol.DriverRemove},
+	}, c.Plan(list))
+
+	// nothing to do once reconciled
+	c = &DriversConfig{Prune: true, Drivers: map[string]string{
+		"python": "docker://bblfsh/python-driver:v2.9.0",
+	}}
+	require.Empty(c.Plan(list[1:2]))
+}
+
+func TestDriversConfigPlan_Pin(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		tag    = "docker://bblfsh/python-driver:latest"
+		pinned = "docker://bblfsh/python-driver@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	)
+	installed := func(ref string) []*runtime.DriverImageStatus {
+		return []*runtime.DriverImageStatus{{
+			Reference: ref,
+			Manifest:  &manifest.Manifest{Language: "python", Version: "v2.9.0"},
+			Current:   true,
+		}}
+	}
+
+	// the image of a tag is pinned by its digest
+	c := &DriversConfig{Prune: true, Drivers: map[string]string{"python": pinned}}
+	changes := c.Plan(installed(tag))
+	require.Equal([]*protocol.DriverChange{
+		{Language: "python", Reference: pinned, Action: protocol.DriverUpgrade},
+		{Language: "python", Reference: tag, Version: "v2.9.0", Action: protocol.DriverRemove},
+	}, changes)
+
+	// the upgrade replaced the image of the same version, so the pinned one
+	// is not removed
+	require.Nil(unlistedDriver(changes[1], installed(pinned)))
+	require.NotNil(unlistedDriver(changes[1], installed(tag)))
+	require.Empty(c.Plan(installed(pinned)))
+}
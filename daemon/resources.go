@@ -0,0 +1,152 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bblfsh/bblfshd/runtime"
+
+	"github.com/docker/go-units"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrInvalidResourcesConfig is returned if the resources configuration
+	// file cannot be parsed or describes invalid limits.
+	ErrInvalidResourcesConfig = errors.NewKind("invalid resources configuration %s: %s")
+	// ErrNoResourcesConfig is returned when reloading the resources
+	// configuration if the daemon was not started with a configuration file.
+	ErrNoResourcesConfig = errors.NewKind("no resources configuration file was loaded")
+)
+
+// ByteSize is a size in bytes, which can be written in YAML as a number or as
+// a human-readable string, like "512m" or "1GiB".
+type ByteSize int64
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	n, err := units.RAMInBytes(str)
+	if err != nil {
+		return err
+	}
+	*s = ByteSize(n)
+	return nil
+}
+
+// LimitsConfig describes the resource limits of the containers of a driver.
+// Fields left unset take the value of the default limits.
+type LimitsConfig struct {
+	// Memory is the memory limit of each instance, including swap. Instances
+	// exceeding it are killed.
+	Memory ByteSize `yaml:"memory"`
+	// CPUs is the number of CPUs each instance may use.
+	CPUs float64 `yaml:"cpus"`
+	// Pids is the maximal number of processes of each instance.
+	Pids int64 `yaml:"pids"`
+	// TmpfsSize is the maximal size of the tmpfs mounts of each instance.
+	// If set, /tmp is a tmpfs, limiting the size of the files written by
+	// the driver.
+	TmpfsSize ByteSize `yaml:"tmpfs-size"`
+}
+
+// merge returns the configuration with the unset fields taken from def.
+func (c LimitsConfig) merge(def LimitsConfig) LimitsConfig {
+	if c.Memory == 0 {
+		c.Memory = def.Memory
+	}
+	if c.CPUs == 0 {
+		c.CPUs = def.CPUs
+	}
+	if c.Pids == 0 {
+		c.Pids = def.Pids
+	}
+	if c.TmpfsSize == 0 {
+		c.TmpfsSize = def.TmpfsSize
+	}
+	return c
+}
+
+func (c LimitsConfig) validate() error {
+	switch {
+	case c.Memory < 0:
+		return fmt.Errorf("memory must not be negative")
+	case c.CPUs < 0:
+		return fmt.Errorf("cpus must not be negative")
+	case c.Pids < 0:
+		return fmt.Errorf("pids must not be negative")
+	case c.TmpfsSize < 0:
+		return fmt.Errorf("tmpfs-size must not be negative")
+	}
+	return nil
+}
+
+// Limits returns the container limits described by the configuration.
+func (c LimitsConfig) Limits() runtime.ContainerLimits {
+	return runtime.ContainerLimits{
+		Memory:    int64(c.Memory),
+		CPUs:      c.CPUs,
+		Pids:      c.Pids,
+		TmpfsSize: int64(c.TmpfsSize),
+	}
+}
+
+// ResourcesConfig is the configuration of the resource limits of the driver
+// containers, loaded from a YAML file:
+//
+//   default:
+//     memory: 1g
+//     pids: 256
+//   languages:
+//     java:
+//       memory: 4g
+//       cpus: 2
+//
+// Languages without a configuration use the default limits. Containers have
+// no limits if the configuration is not set.
+type ResourcesConfig struct {
+	// Default are the limits of the languages not listed in Languages.
+	Default LimitsConfig `yaml:"default"`
+	// Languages are the limits of each language.
+	Languages map[string]LimitsConfig `yaml:"languages"`
+}
+
+// LoadResourcesConfig reads and validates the resources configuration file.
+func LoadResourcesConfig(path string) (*ResourcesConfig, error) {
+	var c ResourcesConfig
+	if err := readConfig(path, &c, ErrInvalidResourcesConfig); err != nil {
+		return nil, err
+	}
+
+	if err := c.Default.validate(); err != nil {
+		return nil, ErrInvalidResourcesConfig.New(path, err)
+	}
+
+	langs := make(map[string]LimitsConfig, len(c.Languages))
+	for lang, lc := range c.Languages {
+		lc = lc.merge(c.Default)
+		if err := lc.validate(); err != nil {
+			return nil, ErrInvalidResourcesConfig.New(path, fmt.Errorf("%s: %s", lang, err))
+		}
+		langs[strings.ToLower(lang)] = lc
+	}
+	c.Languages = langs
+	return &c, nil
+}
+
+// Limits returns the container limits for the language. A nil configuration
+// returns no limits.
+func (c *ResourcesConfig) Limits(language string) runtime.ContainerLimits {
+	if c == nil {
+		return runtime.ContainerLimits{}
+	}
+	if lc, ok := c.Languages[strings.ToLower(language)]; ok {
+		return lc.Limits()
+	}
+	return c.Default.Limits()
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bblfsh/bblfshd/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadResourcesConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-resources")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "resources.yml")
+	require.NoError(ioutil.WriteFile(path, []byte(`
+default:
+  memory: 1g
+  pids: 256
+languages:
+  Java:
+    memory: 4g
+    cpus: 2
+`), 0644))
+
+	c, err := LoadResourcesConfig(path)
+	require.NoError(err)
+
+	require.Equal(runtime.ContainerLimits{
+		Memory: 4 << 30,
+		CPUs:   2,
+		Pids:   256,
+	}, c.Limits("java"))
+	require.Equal(runtime.ContainerLimits{
+		Memory: 1 << 30,
+		Pids:   256,
+	}, c.Limits("go"))
+
+	require.NoError(ioutil.WriteFile(path, []byte("default: {memory: foo}"), 0644))
+	_, err = LoadResourcesConfig(path)
+	require.True(ErrInvalidResourcesConfig.Is(err))
+
+	c = nil
+	require.Equal(runtime.ContainerLimits{}, c.Limits("go"))
+}
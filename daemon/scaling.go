@@ -0,0 +1,215 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrInvalidScalingConfig is returned if the scaling configuration file
+	// cannot be parsed or describes an invalid policy.
+	ErrInvalidScalingConfig = errors.NewKind("invalid scaling configuration %s: %s")
+	// ErrNoScalingConfig is returned when reloading the scaling configuration
+	// if the daemon was not started with a configuration file.
+	ErrNoScalingConfig = errors.NewKind("no scaling configuration file was loaded")
+)
+
+// PolicyConfig describes a scaling policy composed of the MinMax, AIMD,
+// MovingAverage and TargetMovingAverage policies. Fields left unset take the
+// value of the default policy.
+type PolicyConfig struct {
+	// Min is the minimal number of instances (see MinMax).
+	Min int `yaml:"min"`
+	// Max is the maximal number of instances (see MinMax).
+	Max int `yaml:"max"`
+	// Add is the additive increase of the instances (see AIMD).
+	Add int `yaml:"add"`
+	// Mul is the multiplicative decrease of the unused instances (see AIMD).
+	Mul float64 `yaml:"mul"`
+	// LoadWindow is the window of the moving average of the load (see
+	// MovingAverage). A negative value disables the average.
+	LoadWindow time.Duration `yaml:"load-window"`
+	// TargetWindow is the number of samples in the moving average of the
+	// target number of instances (see TargetMovingAverage). A negative value
+	// disables the average.
+	TargetWindow int `yaml:"target-window"`
+	// MaxRequests is the number of requests served by an instance before it
+	// is retired (see RecyclePolicy).
+	MaxRequests int `yaml:"max-requests-per-instance"`
+	// MaxAge is the amount of time after which an instance is retired (see
+	// RecyclePolicy).
+	MaxAge time.Duration `yaml:"max-instance-age"`
+}
+
+// DefaultPolicyConfig returns the configuration of DefaultScalingPolicy.
+func DefaultPolicyConfig() PolicyConfig {
+	return PolicyConfig{
+		Min:          DefaultMinInstancesPerDriver,
+		Max:          DefaultMaxInstancesPerDriver,
+		Add:          policyDefaultScale,
+		Mul:          policyDefaultDownscale,
+		LoadWindow:   policyDefaultWindow,
+		TargetWindow: defaultPolicyTargetWindow,
+	}
+}
+
+// merge returns the configuration with the unset fields taken from def.
+func (c PolicyConfig) merge(def PolicyConfig) PolicyConfig {
+	if c.Min == 0 {
+		c.Min = def.Min
+	}
+	if c.Max == 0 {
+		c.Max = def.Max
+	}
+	if c.Add == 0 {
+		c.Add = def.Add
+	}
+	if c.Mul == 0 {
+		c.Mul = def.Mul
+	}
+	if c.LoadWindow == 0 {
+		c.LoadWindow = def.LoadWindow
+	}
+	if c.TargetWindow == 0 {
+		c.TargetWindow = def.TargetWindow
+	}
+	if c.MaxRequests == 0 {
+		c.MaxRequests = def.MaxRequests
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = def.MaxAge
+	}
+	return c
+}
+
+func (c PolicyConfig) validate() error {
+	switch {
+	case c.Max < 1:
+		return fmt.Errorf("max must be positive")
+	case c.Min > c.Max:
+		return fmt.Errorf("min (%d) is greater than max (%d)", c.Min, c.Max)
+	case c.Add < 1:
+		return fmt.Errorf("add must be positive")
+	case c.Mul <= 0 || c.Mul > 1:
+		return fmt.Errorf("mul must be in (0, 1]")
+	case c.MaxRequests < 0:
+		return fmt.Errorf("max-requests-per-instance must not be negative")
+	case c.MaxAge < 0:
+		return fmt.Errorf("max-instance-age must not be negative")
+	}
+	return nil
+}
+
+// Policy returns a new instance of the scaling policy. Instances returned by
+// this function should not be reused.
+func (c PolicyConfig) Policy() ScalingPolicy {
+	p := MinMax(c.minInstances(), c.Max, AIMD(c.Add, c.Mul))
+	if window := int(c.LoadWindow / policyDefaultTick); window > 0 {
+		p = MovingAverage(window, p)
+	}
+	if c.TargetWindow > 0 {
+		p = TargetMovingAverage(c.TargetWindow, p)
+	}
+	return p
+}
+
+// minInstances returns the minimal number of instances of the policy.
+func (c PolicyConfig) minInstances() int {
+	if c.Min <= 0 {
+		return c.Max
+	}
+	return c.Min
+}
+
+// Recycle returns the recycling policy of the instances.
+func (c PolicyConfig) Recycle() RecyclePolicy {
+	return RecyclePolicy{MaxRequests: c.MaxRequests, MaxAge: c.MaxAge}
+}
+
+// ScalingConfig is the configuration of the scaling policies of the driver
+// pools, loaded from a YAML file:
+//
+//   default:
+//     max: 8
+//   languages:
+//     java:
+//       max: 2
+//       load-window: 10s
+//       max-requests-per-instance: 1000
+//     go:
+//       min: 2
+//
+// Languages without a configuration use the default policy. Fields not set
+// by the default policy are taken from the BBLFSHD_* environment variables.
+// Instances are never recycled if no limit is set.
+type ScalingConfig struct {
+	// Default is the policy of the languages not listed in Languages.
+	Default PolicyConfig `yaml:"default"`
+	// Languages are the policies of each language.
+	Languages map[string]PolicyConfig `yaml:"languages"`
+}
+
+// LoadScalingConfig reads and validates the scaling configuration file.
+func LoadScalingConfig(path string) (*ScalingConfig, error) {
+	var c ScalingConfig
+	if err := readConfig(path, &c, ErrInvalidScalingConfig); err != nil {
+		return nil, err
+	}
+
+	c.Default = c.Default.merge(DefaultPolicyConfig())
+	if err := c.Default.validate(); err != nil {
+		return nil, ErrInvalidScalingConfig.New(path, err)
+	}
+
+	langs := make(map[string]PolicyConfig, len(c.Languages))
+	for lang, pc := range c.Languages {
+		pc = pc.merge(c.Default)
+		if err := pc.validate(); err != nil {
+			return nil, ErrInvalidScalingConfig.New(path, fmt.Errorf("%s: %s", lang, err))
+		}
+		langs[strings.ToLower(lang)] = pc
+	}
+	c.Languages = langs
+	return &c, nil
+}
+
+// Policy returns a new instance of the scaling policy for the language.
+// A nil configuration returns DefaultScalingPolicy.
+func (c *ScalingConfig) Policy(language string) ScalingPolicy {
+	if c == nil {
+		return DefaultScalingPolicy()
+	}
+	if pc, ok := c.Languages[strings.ToLower(language)]; ok {
+		return pc.Policy()
+	}
+	return c.Default.Policy()
+}
+
+// Recycle returns the recycling policy of the instances for the language.
+// A nil configuration never recycles the instances.
+func (c *ScalingConfig) Recycle(language string) RecyclePolicy {
+	if c == nil {
+		return RecyclePolicy{}
+	}
+	if pc, ok := c.Languages[strings.ToLower(language)]; ok {
+		return pc.Recycle()
+	}
+	return c.Default.Recycle()
+}
+
+// MinInstances returns the minimal number of instances of the scaling policy
+// for the language.
+func (c *ScalingConfig) MinInstances(language string) int {
+	if c == nil {
+		return DefaultPolicyConfig().minInstances()
+	}
+	if pc, ok := c.Languages[strings.ToLower(language)]; ok {
+		return pc.minInstances()
+	}
+	return c.Default.minInstances()
+}
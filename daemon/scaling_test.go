@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadScalingConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-scaling")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "scaling.yml", `
+default:
+  max: 8
+languages:
+  Java:
+    max: 2
+    load-window: 10s
+    max-requests-per-instance: 100
+  go:
+    min: 2
+`)
+	c, err := LoadScalingConfig(path)
+	require.NoError(err)
+
+	def := DefaultPolicyConfig()
+	require.Equal(8, c.Default.Max)
+	require.Equal(def.Min, c.Default.Min)
+	require.Equal(def.Add, c.Default.Add)
+
+	java := c.Languages["java"]
+	require.Equal(2, java.Max)
+	require.Equal(10*time.Second, java.LoadWindow)
+	require.Equal(def.Mul, java.Mul)
+
+	golang := c.Languages["go"]
+	require.Equal(2, golang.Min)
+	require.Equal(8, golang.Max)
+
+	require.Equal(RecyclePolicy{MaxRequests: 100}, c.Recycle("java"))
+	require.Equal(RecyclePolicy{}, c.Recycle("go"))
+
+	require.Equal(2, c.MinInstances("go"))
+	require.Equal(def.Min, c.MinInstances("python"))
+
+	p := c.Policy("java")
+	require.Equal(2, p.Scale(2, 0, 10))
+	p = c.Policy("python")
+	require.True(p.Scale(8, 0, 100) <= 8)
+}
+
+func TestLoadScalingConfig_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bblfshd-scaling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, data := range []string{
+		"default: {min: 4, max: 2}",
+		"languages: {java: {mul: 2}}",
+		"languages: {java: {maximum: 2}}",
+	} {
+		_, err := LoadScalingConfig(writeConfig(t, dir, "scaling.yml", data))
+		require.True(t, ErrInvalidScalingConfig.Is(err), "%q: %v", data, err)
+	}
+}
+
+func TestScalingConfig_Nil(t *testing.T) {
+	var c *ScalingConfig
+	require.NotNil(t, c.Policy("go"))
+	require.Equal(t, DefaultPolicyConfig().Min, c.MinInstances("go"))
+}
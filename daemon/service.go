@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -17,6 +18,7 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
 
 	"github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/bblfsh/sdk/v3/driver/manifest"
@@ -65,10 +67,15 @@ func (s *ServiceV2) Parse(rctx xcontext.Context, req *protocol2.ParseRequest) (r
 	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.v2.Parse")
 	defer sp.Finish()
 
+	ctx, stats := withRequestStats(ctx)
 	resp = &protocol2.ParseResponse{}
 	start := time.Now()
 	defer func() {
-		s.logResponse(gerr, req.Filename, req.Language, req.Content, time.Since(start))
+		s.logResponse(gerr, req.Filename, req.Language, req.Content, time.Since(start), stats)
+		stats.setTags(sp)
+		if md := stats.trailer(); md != nil && s.daemon.StatsTrailer {
+			_ = grpc.SetTrailer(rctx, md)
+		}
 	}()
 
 	if req.Content == "" {
@@ -83,16 +90,46 @@ func (s *ServiceV2) Parse(rctx xcontext.Context, req *protocol2.ParseRequest) (r
 		return nil, err
 	}
 
-	language, dp, err := s.selectPool(ctx, req.Language, req.Content, req.Filename)
+	cls, err := requestClassFromMetadata(rctx, PriorityInteractive)
+	if err != nil {
+		parseErrorsV2.Add(1)
+		return nil, err
+	}
+	ctx = withRequestClass(ctx, cls)
+
+	language, version, dp, err := s.selectPool(ctx, req.Language, req.Content, req.Filename)
 	if err != nil {
 		parseErrorsV2.Add(1)
 		log.Errorf(err, "error selecting pool")
 		return nil, err
 	}
 
+	return s.parse(ctx, language, version, dp, req)
+}
+
+// parse executes the request in the given pool of drivers for the language,
+// unless the response is found in the cache.
+func (s *ServiceV2) parse(ctx context.Context, language, version string, dp *DriverPool, req *protocol2.ParseRequest) (*protocol2.ParseResponse, error) {
 	req.Language = language
 
-	err = dp.ExecuteCtx(ctx, func(ctx context.Context, driver Driver) error {
+	cache := s.daemon.Cache
+	if dp.digest == "" {
+		cache = nil // the image is unknown, so the key cannot be built
+	}
+	var key cacheKey
+	if cache != nil {
+		// the responses are stored under the language of the driver, so
+		// the ones requested through an alias are invalidated with it
+		key = cacheKey{Hash: hashGit(req.Content), Language: dp.language, Digest: dp.digest, Mode: req.Mode}
+		if resp := cache.Get(key); resp != nil {
+			resp.Language = language
+			return resp, nil
+		}
+	}
+
+	var resp *protocol2.ParseResponse
+	err := s.daemon.execute(ctx, language, version, dp, func(ctx context.Context, dp *DriverPool, driver Driver) error {
+		var err error
 		resp, err = parseV2(ctx, dp, driver, req)
 		return err
 	})
@@ -101,6 +138,9 @@ func (s *ServiceV2) Parse(rctx xcontext.Context, req *protocol2.ParseRequest) (r
 	}
 	if resp != nil {
 		resp.Language = language
+		if err == nil {
+			cache.Put(key, resp)
+		}
 	}
 	return resp, err
 }
@@ -132,7 +172,7 @@ func parseV2(ctx context.Context, pool *DriverPool, drv Driver, req *protocol2.P
 		return resp, err
 
 	case <-ctxKill.Done():
-		pool.killDriver(drv, "parseV2", ctxKill.Err())
+		pool.killDriver(drv, killTimeout, "parseV2", ctxKill.Err())
 		return nil, ctxKill.Err()
 	}
 }
@@ -160,7 +200,7 @@ func (s *ServiceV2) SupportedLanguages(rctx xcontext.Context, _ *protocol2.Suppo
 
 	start := time.Now()
 	defer func() {
-		s.logResponse(gerr, "", "", "", time.Since(start))
+		s.logResponse(gerr, "", "", "", time.Since(start), nil)
 	}()
 
 	drivers, err := s.daemon.runtime.ListDrivers()
@@ -178,8 +218,9 @@ func (s *ServiceV2) SupportedLanguages(rctx xcontext.Context, _ *protocol2.Suppo
 	return &protocol2.SupportedLanguagesResponse{Languages: out}, nil
 }
 
-func (s *ServiceV2) logResponse(err error, filename, language, content string, elapsed time.Duration) {
+func (s *ServiceV2) logResponse(err error, filename, language, content string, elapsed time.Duration, stats *RequestStats) {
 	fields := log.Fields{"elapsed": elapsed}
+	stats.fields(fields)
 	if filename != "" {
 		fields["filename"] = filename
 	}
@@ -215,26 +256,27 @@ func (s *ServiceV2) detectLanguage(rctx context.Context, content, filename strin
 	return language, nil
 }
 
-func (s *ServiceV2) selectPool(rctx context.Context, language, content, filename string) (string, *DriverPool, error) {
+func (s *ServiceV2) selectPool(rctx context.Context, language, content, filename string) (string, string, *DriverPool, error) {
 	sp, ctx := opentracing.StartSpanFromContext(rctx, "bblfshd.pool.select")
 	defer sp.Finish()
 
+	language, version := splitVersion(language)
 	if language == "" {
 		lang, err := s.detectLanguage(ctx, content, filename)
 		if err != nil {
-			return "", nil, err
+			return "", "", nil, err
 		}
 		language = lang
 	} else { // always re-map enry->bblfsh language names
 		language = normalize(language)
 	}
 
-	dp, err := s.daemon.DriverPool(ctx, language)
+	dp, err := s.daemon.DriverPool(ctx, language, version)
 	if err != nil {
-		return language, nil, ErrUnexpected.Wrap(err)
+		return language, version, nil, ErrUnexpected.Wrap(err)
 	}
 
-	return language, dp, nil
+	return language, version, dp, nil
 }
 
 var _ protocol1.Service = (*Service)(nil)
@@ -275,7 +317,7 @@ func (d *Service) Parse(req *protocol1.ParseRequest) *protocol1.ParseResponse {
 		return resp
 	}
 
-	language, dp, err := d.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
+	language, version, dp, err := d.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
 	if err != nil {
 		log.Errorf(err, "error selecting pool")
 		resp.Response = newResponseFromError(err)
@@ -285,10 +327,10 @@ func (d *Service) Parse(req *protocol1.ParseRequest) *protocol1.ParseResponse {
 
 	req.Language = language
 
-	err = dp.Execute(func(ctx context.Context, driver Driver) error {
+	err = d.execute(language, version, dp, req.Timeout, func(ctx context.Context, dp *DriverPool, driver Driver) error {
 		resp, err = parseV1(ctx, dp, driver, req)
 		return err
-	}, req.Timeout)
+	})
 
 	if err != nil {
 		resp = &protocol1.ParseResponse{}
@@ -326,7 +368,7 @@ func parseV1(ctx context.Context, pool *DriverPool, drv Driver, req *protocol1.P
 		return resp, err
 
 	case <-ctxKill.Done():
-		pool.killDriver(drv, "parseV1", ctxKill.Err())
+		pool.killDriver(drv, killTimeout, "parseV1", ctxKill.Err())
 		return nil, ctxKill.Err()
 	}
 }
@@ -388,7 +430,7 @@ func (d *Service) NativeParse(req *protocol1.NativeParseRequest) *protocol1.Nati
 		return resp
 	}
 
-	language, dp, err := d.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
+	language, version, dp, err := d.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
 	if err != nil {
 		log.Errorf(err, "error selecting pool")
 		resp.Response = newResponseFromError(err)
@@ -397,10 +439,10 @@ func (d *Service) NativeParse(req *protocol1.NativeParseRequest) *protocol1.Nati
 
 	req.Language = language
 
-	err = dp.Execute(func(ctx context.Context, driver Driver) error {
+	err = d.execute(language, version, dp, req.Timeout, func(ctx context.Context, _ *DriverPool, driver Driver) error {
 		resp, err = driver.Service().NativeParse(ctx, req)
 		return err
-	}, req.Timeout)
+	})
 
 	if err != nil {
 		resp = &protocol1.NativeParseResponse{}
@@ -411,23 +453,36 @@ func (d *Service) NativeParse(req *protocol1.NativeParseRequest) *protocol1.Nati
 	return resp
 }
 
-func (s *Service) selectPool(ctx context.Context, language, content, filename string) (string, *DriverPool, error) {
+// execute is the same as Daemon.execute, but applies a v1 request timeout.
+func (s *Service) execute(language, version string, dp *DriverPool, timeout time.Duration, fnc poolFunc) error {
+	if timeout == 0 {
+		timeout = defaultExecuteTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return s.daemon.execute(ctx, language, version, dp, fnc)
+}
+
+func (s *Service) selectPool(ctx context.Context, language, content, filename string) (string, string, *DriverPool, error) {
+	language, version := splitVersion(language)
 	if language == "" {
 		language = GetLanguage(filename, []byte(content))
 		if language == "" {
-			return language, nil, ErrLanguageDetection.New()
+			return language, version, nil, ErrLanguageDetection.New()
 		}
 		log.Debugf("detected language %q, filename %q", language, filename)
 	} else { // always re-map enry->bblfsh language names
 		language = normalize(language)
 	}
 
-	dp, err := s.daemon.DriverPool(ctx, language)
+	dp, err := s.daemon.DriverPool(ctx, language, version)
 	if err != nil {
-		return language, nil, ErrUnexpected.Wrap(err)
+		return language, version, nil, ErrUnexpected.Wrap(err)
 	}
 
-	return language, dp, nil
+	return language, version, dp, nil
 }
 
 // Version implements protocol1.Service.
@@ -527,6 +582,33 @@ func (s *ControlService) DriverInstanceStates() ([]*protocol.DriverInstanceState
 	return out, nil
 }
 
+func (s *ControlService) GarbageCollect() (*protocol.GarbageCollectResponse, error) {
+	stats, err := s.Daemon.GC()
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.GarbageCollectResponse{
+		Layers:    stats.Layers,
+		Temporary: stats.Temporary,
+		Size:      stats.Size,
+	}, nil
+}
+
+func (s *ControlService) ReconcileDrivers(dryRun bool) ([]*protocol.DriverChange, error) {
+	return s.Daemon.ReconcileDrivers(context.TODO(), dryRun)
+}
+
+func (s *ControlService) AuditLog(limit int, rpc, language string) ([]*protocol.AuditEntry, error) {
+	if s.Daemon.Audit == nil {
+		return nil, protocol.ErrNoAuditLog.New()
+	}
+	return s.Daemon.Audit.Recent(limit, func(e *protocol.AuditEntry) bool {
+		return (rpc == "" || strings.EqualFold(e.RPC, rpc)) &&
+			(language == "" || strings.EqualFold(e.Language, language))
+	})
+}
+
 func (s *ControlService) DriverStates() ([]*protocol.DriverImageState, error) {
 	list, err := s.Daemon.runtime.ListDrivers()
 	if err != nil {
@@ -535,7 +617,7 @@ func (s *ControlService) DriverStates() ([]*protocol.DriverImageState, error) {
 
 	var out []*protocol.DriverImageState
 	for _, d := range list {
-		out = append(out, &protocol.DriverImageState{
+		state := &protocol.DriverImageState{
 			Reference:     d.Reference,
 			Language:      d.Manifest.Language,
 			Version:       d.Manifest.Version,
@@ -543,7 +625,18 @@ func (s *ControlService) DriverStates() ([]*protocol.DriverImageState, error) {
 			Status:        string(d.Manifest.Status),
 			GoVersion:     string(d.Manifest.Runtime.GoVersion),
 			NativeVersion: []string{d.Manifest.Runtime.NativeVersion},
-		})
+			Current:       d.Current,
+			Digest:        d.Digest.String(),
+		}
+		st := s.Daemon.UpdateStatus(d.Manifest.Language, d.Reference)
+		if st != nil && st.Installed == state.Digest {
+			state.UpdatePolicy = string(st.Policy)
+			state.UpdateAvailable = st.Available
+			state.LatestDigest = st.Latest
+			state.LastUpdateCheck = st.Checked
+			state.UpdateError = st.Error
+		}
+		out = append(out, state)
 	}
 
 	return out, nil
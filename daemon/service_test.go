@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	protocol2 "github.com/bblfsh/sdk/v3/protocol"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/bblfsh/sdk.v1/protocol"
 )
@@ -30,6 +31,32 @@ func TestServiceParse(t *testing.T) {
 	require.True(resp.Elapsed.Nanoseconds() > 0)
 }
 
+func TestServiceV2Parse_CacheAlias(t *testing.T) {
+	require := require.New(t)
+
+	d, tmp := buildMockedDaemon(t)
+	defer os.RemoveAll(tmp)
+
+	c, err := NewParseCache("", 1<<20, 0)
+	require.NoError(err)
+	d.Cache = c
+
+	dp := d.pool["python"]
+	dp.digest, dp.language = "abc", "python"
+	d.aliases["py"] = "python"
+
+	s := NewServiceV2(d)
+	_, err = s.Parse(context.Background(), &protocol2.ParseRequest{Language: "py", Content: "foo"})
+	require.NoError(err)
+
+	// the response is stored under the language of the driver, not the alias
+	key := cacheKey{Hash: hashGit("foo"), Language: "python", Digest: "abc"}
+	require.NotNil(c.Get(key))
+
+	c.Invalidate("python", "abc")
+	require.Nil(c.Get(key))
+}
+
 // TODO(dennwc): Add test cases for V2
 func TestServiceParseV1(t *testing.T) {
 	require := require.New(t)
@@ -39,7 +66,7 @@ func TestServiceParseV1(t *testing.T) {
 
 	s := NewService(d)
 	req := &protocol.ParseRequest{Filename: "foo.py", Content: "foo"}
-	lang, dp, err := s.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
+	lang, _, dp, err := s.selectPool(context.TODO(), req.Language, req.Content, req.Filename)
 	require.NoError(err)
 	require.Equal("python", lang)
 
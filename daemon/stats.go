@@ -0,0 +1,85 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// Keys of the gRPC trailer the stats of a parse request are sent in, if
+// enabled by Daemon.StatsTrailer. Durations are formatted as time.Duration.
+const (
+	StatsQueueKey     = "bblfshd-queue-time"
+	StatsSpawnKey     = "bblfshd-spawn-time"
+	StatsDriverKey    = "bblfshd-driver-time"
+	StatsContainerKey = "bblfshd-container"
+)
+
+// RequestStats is the time a parse request spent in each stage of its
+// execution, and the driver instance that served it.
+type RequestStats struct {
+	// Queue is the time spent waiting for an idle driver, including Spawn.
+	Queue time.Duration
+	// Spawn is the time it took to start the driver instance, if it was
+	// started while the request was waiting for it.
+	Spawn time.Duration
+	// Driver is the time the driver took to serve the request.
+	Driver time.Duration
+	// Container is the ID of the container of the driver instance.
+	Container string
+}
+
+type requestStatsKey struct{}
+
+// withRequestStats returns a context collecting the stats of the requests
+// executed with it by the driver pools.
+func withRequestStats(ctx context.Context) (context.Context, *RequestStats) {
+	st := &RequestStats{}
+	return context.WithValue(ctx, requestStatsKey{}, st), st
+}
+
+// requestStatsFrom returns the stats collected by the context, or nil.
+func requestStatsFrom(ctx context.Context) *RequestStats {
+	st, _ := ctx.Value(requestStatsKey{}).(*RequestStats)
+	return st
+}
+
+// fields adds the stats to the fields of a log line.
+func (st *RequestStats) fields(fields log.Fields) {
+	if st == nil || st.Container == "" {
+		return
+	}
+	fields["queue"] = st.Queue
+	fields["spawn"] = st.Spawn
+	fields["driver"] = st.Driver
+	fields["container"] = st.Container
+}
+
+// setTags sets the stats as tags of the span.
+func (st *RequestStats) setTags(sp opentracing.Span) {
+	if st == nil || st.Container == "" {
+		return
+	}
+	sp.SetTag("bblfshd.queue", st.Queue.String())
+	sp.SetTag("bblfshd.spawn", st.Spawn.String())
+	sp.SetTag("bblfshd.driver", st.Driver.String())
+	sp.SetTag("bblfshd.container", st.Container)
+}
+
+// trailer returns the stats as gRPC metadata.
+func (st *RequestStats) trailer() metadata.MD {
+	if st == nil || st.Container == "" {
+		return nil
+	}
+	return metadata.Pairs(
+		StatsQueueKey, st.Queue.String(),
+		StatsSpawnKey, st.Spawn.String(),
+		StatsDriverKey, st.Driver.String(),
+		StatsContainerKey, st.Container,
+	)
+}
@@ -0,0 +1,170 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// ErrInvalidTLSConfig is returned if the TLS certificates of a server cannot
+// be loaded.
+var ErrInvalidTLSConfig = errors.NewKind("invalid TLS configuration: %s")
+
+// certCheckInterval is the minimal interval between the checks of the
+// certificate files for changes.
+var certCheckInterval = 10 * time.Second
+
+// TLSFiles are the files of the TLS certificates of a server.
+type TLSFiles struct {
+	// Cert is the certificate of the server, followed by its intermediates.
+	Cert string
+	// Key is the private key of the certificate.
+	Key string
+	// ClientCA are the CA certificates the certificates of the clients are
+	// verified against. Client certificates are not requested if not set.
+	ClientCA string
+}
+
+// CertReloader serves the TLS certificates of a server, loaded from files.
+// The files are loaded again when they change, so certificates can be renewed
+// without restarting the server.
+type CertReloader struct {
+	files         TLSFiles
+	requireClient bool
+
+	mu      sync.Mutex
+	config  *tls.Config
+	modTime []time.Time
+	checked time.Time
+}
+
+// NewCertReloader loads the certificates of a server. If requireClient is set,
+// clients must present a certificate verified by the client CA.
+func NewCertReloader(files TLSFiles, requireClient bool) (*CertReloader, error) {
+	if files.Cert == "" || files.Key == "" {
+		return nil, ErrInvalidTLSConfig.New("both a certificate and a key are required")
+	}
+	if requireClient && files.ClientCA == "" {
+		return nil, ErrInvalidTLSConfig.New("a client CA is required to verify the client certificates")
+	}
+
+	r := &CertReloader{files: files, requireClient: requireClient}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ServerOption returns the gRPC server option serving TLS with the current
+// certificates.
+func (r *CertReloader) ServerOption() grpc.ServerOption {
+	return grpc.Creds(credentials.NewTLS(&tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}))
+}
+
+// current returns the TLS configuration with the current certificates, loading
+// them again if the files changed.
+func (r *CertReloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < certCheckInterval {
+		return r.config
+	}
+	r.checked = time.Now()
+
+	if !r.changed() {
+		return r.config
+	}
+	if err := r.load(); err != nil {
+		log.Errorf(err, "error reloading TLS certificates, keeping the previous ones")
+		return r.config
+	}
+	log.Infof("TLS certificates reloaded from %s", r.files.Cert)
+	return r.config
+}
+
+func (r *CertReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checked = time.Now()
+	return r.load()
+}
+
+// paths are the certificate files being watched.
+func (r *CertReloader) paths() []string {
+	paths := []string{r.files.Cert, r.files.Key}
+	if r.files.ClientCA != "" {
+		paths = append(paths, r.files.ClientCA)
+	}
+	return paths
+}
+
+// changed returns true if a file was modified since it was loaded. Must be
+// called with the lock held.
+func (r *CertReloader) changed() bool {
+	for i, path := range r.paths() {
+		fi, err := os.Stat(path)
+		if err != nil || !fi.ModTime().Equal(r.modTime[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads the certificate files. Must be called with the lock held.
+func (r *CertReloader) load() error {
+	var modTime []time.Time
+	for _, path := range r.paths() {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return ErrInvalidTLSConfig.New(err)
+		}
+		modTime = append(modTime, fi.ModTime())
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.Cert, r.files.Key)
+	if err != nil {
+		return ErrInvalidTLSConfig.New(err)
+	}
+
+	c := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// the configuration replaces the one of the gRPC credentials
+		NextProtos: []string{"h2"},
+	}
+
+	if r.files.ClientCA != "" {
+		data, err := ioutil.ReadFile(r.files.ClientCA)
+		if err != nil {
+			return ErrInvalidTLSConfig.New(err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return ErrInvalidTLSConfig.New(fmt.Errorf("no certificates found in %s", r.files.ClientCA))
+		}
+		c.ClientCAs = pool
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.requireClient {
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	r.config, r.modTime = c, modTime
+	return nil
+}
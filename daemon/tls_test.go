@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self-signed certificate and its key to the files.
+func writeTestCert(t *testing.T, files TLSFiles, name string, modTime time.Time) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	require.NoError(ioutil.WriteFile(files.Cert, certPEM, 0644))
+	require.NoError(ioutil.WriteFile(files.Key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+	require.NoError(ioutil.WriteFile(files.ClientCA, certPEM, 0644))
+	for _, path := range []string{files.Cert, files.Key, files.ClientCA} {
+		require.NoError(os.Chtimes(path, modTime, modTime))
+	}
+}
+
+func certName(t *testing.T, c *tls.Config) string {
+	cert, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return cert.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-tls")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	defer func(d time.Duration) { certCheckInterval = d }(certCheckInterval)
+	certCheckInterval = 0
+
+	files := TLSFiles{
+		Cert:     filepath.Join(dir, "cert.pem"),
+		Key:      filepath.Join(dir, "key.pem"),
+		ClientCA: filepath.Join(dir, "ca.pem"),
+	}
+
+	_, err = NewCertReloader(files, false)
+	require.True(ErrInvalidTLSConfig.Is(err), "%v", err)
+	_, err = NewCertReloader(TLSFiles{Cert: files.Cert, Key: files.Key}, true)
+	require.True(ErrInvalidTLSConfig.Is(err), "%v", err)
+
+	then := time.Now().Add(-time.Minute)
+	writeTestCert(t, files, "first", then)
+
+	r, err := NewCertReloader(files, true)
+	require.NoError(err)
+	c := r.current()
+	require.Equal("first", certName(t, c))
+	require.Equal(tls.RequireAndVerifyClientCert, c.ClientAuth)
+	require.Equal(uint16(tls.VersionTLS12), c.MinVersion)
+
+	// renewed certificates are served without restarting
+	writeTestCert(t, files, "second", then.Add(time.Second))
+	require.Equal("second", certName(t, r.current()))
+
+	// the previous certificates are kept if the new ones can't be loaded
+	require.NoError(ioutil.WriteFile(files.Key, []byte("garbage"), 0600))
+	require.Equal("second", certName(t, r.current()))
+}
@@ -0,0 +1,465 @@
+// +build linux,cgo
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bblfsh/bblfshd/runtime"
+
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// defaultUpdateInterval is the time between two checks of the updates of the
+// drivers if the update configuration doesn't set it.
+const defaultUpdateInterval = time.Hour
+
+var (
+	// ErrInvalidUpdateConfig is returned if the update configuration file
+	// cannot be parsed or describes an invalid policy.
+	ErrInvalidUpdateConfig = errors.NewKind("invalid update configuration %s: %s")
+	// ErrNoUpdateConfig is returned when reloading the update configuration
+	// if the daemon was not started with a configuration file.
+	ErrNoUpdateConfig = errors.NewKind("no update configuration file was loaded")
+)
+
+// UpdatePolicy is what is done when the image reference of an installed
+// driver points to a new image.
+type UpdatePolicy string
+
+const (
+	// UpdateNone doesn't check the updates of the driver.
+	UpdateNone UpdatePolicy = "none"
+	// UpdateNotify only reports the updates available, in the driver list
+	// and in the metrics.
+	UpdateNotify UpdatePolicy = "notify"
+	// UpdateAuto installs the updates as soon as they are found.
+	UpdateAuto UpdatePolicy = "auto"
+	// UpdateWindow installs the updates found during the maintenance window
+	// of the driver, and only reports the other ones.
+	UpdateWindow UpdatePolicy = "window"
+)
+
+// MaintenanceWindow is a daily period of time, in the local time of the
+// daemon, written as "HH:MM-HH:MM". A window ending before its start spans
+// midnight.
+type MaintenanceWindow struct {
+	// Start and End are the offsets of the window from midnight.
+	Start, End time.Duration
+}
+
+// ParseMaintenanceWindow parses a window written as "HH:MM-HH:MM".
+func ParseMaintenanceWindow(s string) (MaintenanceWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return MaintenanceWindow{}, fmt.Errorf("invalid window %q: expected HH:MM-HH:MM", s)
+	}
+
+	var bounds [2]time.Duration
+	for i, p := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(p))
+		if err != nil {
+			return MaintenanceWindow{}, fmt.Errorf("invalid window %q: %s", s, err)
+		}
+		bounds[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	return MaintenanceWindow{Start: bounds[0], End: bounds[1]}, nil
+}
+
+// Contains returns true if t is within the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	y, m, d := t.Date()
+	offset := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// UpdatePolicyConfig describes the update policy of a driver. Fields left
+// unset take the value of the default policy.
+type UpdatePolicyConfig struct {
+	// Policy is what is done with the updates of the driver.
+	Policy UpdatePolicy `yaml:"policy"`
+	// Window is the maintenance window of the "window" policy, written as
+	// "HH:MM-HH:MM".
+	Window string `yaml:"window"`
+
+	window MaintenanceWindow
+}
+
+// merge returns the configuration with the unset fields taken from def.
+func (c UpdatePolicyConfig) merge(def UpdatePolicyConfig) UpdatePolicyConfig {
+	if c.Policy == "" {
+		c.Policy = def.Policy
+	}
+	if c.Window == "" {
+		c.Window = def.Window
+	}
+	return c
+}
+
+// parse validates the configuration and parses its maintenance window.
+func (c *UpdatePolicyConfig) parse() error {
+	switch c.Policy {
+	case UpdateNone, UpdateNotify, UpdateAuto:
+	case UpdateWindow:
+		if c.Window == "" {
+			return fmt.Errorf("window policy without a window")
+		}
+	default:
+		return fmt.Errorf("unknown policy %q", c.Policy)
+	}
+
+	if c.Window == "" {
+		return nil
+	}
+
+	var err error
+	c.window, err = ParseMaintenanceWindow(c.Window)
+	return err
+}
+
+// Apply returns true if an update found at the given time is installed.
+func (c UpdatePolicyConfig) Apply(t time.Time) bool {
+	switch c.Policy {
+	case UpdateAuto:
+		return true
+	case UpdateWindow:
+		return c.window.Contains(t)
+	}
+	return false
+}
+
+// UpdateConfig is the configuration of the updates of the installed drivers,
+// loaded from a YAML file:
+//
+//   interval: 30m
+//   default:
+//     policy: notify
+//   languages:
+//     go:
+//       policy: auto
+//     java:
+//       policy: window
+//       window: 02:00-04:00
+//
+// The image references of the installed drivers are checked at each interval,
+// and an update is found when a reference points to an image with a different
+// digest than the installed one. The interval should be shorter than the
+// maintenance windows, so each window has at least one check. Languages
+// without a configuration use the default policy, which only notifies the
+// updates if not set.
+type UpdateConfig struct {
+	// Interval is the time between two checks of the updates.
+	Interval time.Duration `yaml:"interval"`
+	// Default is the policy of the languages not listed in Languages.
+	Default UpdatePolicyConfig `yaml:"default"`
+	// Languages are the policies of each language.
+	Languages map[string]UpdatePolicyConfig `yaml:"languages"`
+}
+
+// LoadUpdateConfig reads and validates the update configuration file.
+func LoadUpdateConfig(path string) (*UpdateConfig, error) {
+	var c UpdateConfig
+	if err := readConfig(path, &c, ErrInvalidUpdateConfig); err != nil {
+		return nil, err
+	}
+
+	if c.Interval < 0 {
+		return nil, ErrInvalidUpdateConfig.New(path, "interval must not be negative")
+	} else if c.Interval == 0 {
+		c.Interval = defaultUpdateInterval
+	}
+
+	c.Default = c.Default.merge(UpdatePolicyConfig{Policy: UpdateNotify})
+	if err := c.Default.parse(); err != nil {
+		return nil, ErrInvalidUpdateConfig.New(path, err)
+	}
+
+	langs := make(map[string]UpdatePolicyConfig, len(c.Languages))
+	for lang, pc := range c.Languages {
+		pc = pc.merge(c.Default)
+		if err := pc.parse(); err != nil {
+			return nil, ErrInvalidUpdateConfig.New(path, fmt.Errorf("%s: %s", lang, err))
+		}
+		langs[strings.ToLower(lang)] = pc
+	}
+	c.Languages = langs
+	return &c, nil
+}
+
+// Policy returns the update policy for the language. A nil configuration
+// doesn't check the updates.
+func (c *UpdateConfig) Policy(language string) UpdatePolicyConfig {
+	if c == nil {
+		return UpdatePolicyConfig{Policy: UpdateNone}
+	}
+	if pc, ok := c.Languages[strings.ToLower(language)]; ok {
+		return pc
+	}
+	return c.Default
+}
+
+// UpdateStatus is the result of the last update check of an installed driver.
+type UpdateStatus struct {
+	// Policy applied to the updates of the driver.
+	Policy UpdatePolicy
+	// Checked is when the image reference was checked.
+	Checked time.Time
+	// Latest is the digest of the image the reference points to, empty if the
+	// check failed.
+	Latest string
+	// Installed is the digest of the installed driver that was checked.
+	Installed string
+	// Available is true if the reference points to a different image than the
+	// installed one, and it was not installed.
+	Available bool
+	// Error is the error of the check or of the update.
+	Error string
+}
+
+// updateKey returns the key of the update status of the driver installed from
+// the image reference.
+func updateKey(language, reference string) string {
+	return language + " " + reference
+}
+
+// UpdateStatus returns the result of the last update check of the driver
+// installed from the image reference, or nil if it was never checked. Only
+// one of the versions installed from a reference is checked, the one whose
+// digest is the Installed one.
+func (d *Daemon) UpdateStatus(language, reference string) *UpdateStatus {
+	d.updateMu.Lock()
+	defer d.updateMu.Unlock()
+	return d.updates[updateKey(language, reference)]
+}
+
+// LoadUpdateConfig loads the update configuration file. The updates are
+// checked by WatchUpdates.
+func (d *Daemon) LoadUpdateConfig(path string) error {
+	c, err := LoadUpdateConfig(path)
+	if err != nil {
+		return err
+	}
+
+	d.configMu.Lock()
+	d.update = c
+	d.configMu.Unlock()
+	d.updateFile.set(path)
+	return nil
+}
+
+// ReloadUpdateConfig reads again the update configuration file loaded by
+// LoadUpdateConfig. The previous configuration is kept if the file is not
+// valid.
+func (d *Daemon) ReloadUpdateConfig() error {
+	return d.updateFile.reload(ErrNoUpdateConfig, d.LoadUpdateConfig)
+}
+
+// updateConfig returns the update configuration, nil if none was loaded.
+func (d *Daemon) updateConfig() *UpdateConfig {
+	d.configMu.RLock()
+	defer d.configMu.RUnlock()
+	return d.update
+}
+
+// WatchUpdates checks the updates of the installed drivers at the interval of
+// the update configuration, until the context is cancelled or the daemon
+// starts shutting down. It returns right away if no configuration was loaded.
+func (d *Daemon) WatchUpdates(ctx context.Context) {
+	for {
+		c := d.updateConfig()
+		if c == nil || atomic.LoadInt32(&d.stopping) != 0 {
+			return
+		}
+
+		if err := d.CheckUpdates(ctx); err != nil {
+			log.Errorf(err, "error checking driver updates")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.Interval):
+		}
+	}
+}
+
+// CheckUpdates compares the digest of the image each installed driver was
+// installed from with the one its image reference points to now, and applies
+// the updates found according to the update policy of its language. Of the
+// versions installed from the same reference, only the one returned by
+// updateCandidates is checked.
+func (d *Daemon) CheckUpdates(ctx context.Context) error {
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		return ErrRuntime.Wrap(err)
+	}
+
+	c := d.updateConfig()
+	candidates := updateCandidates(list)
+	keys := make(map[string]bool, len(candidates))
+	for _, dr := range list {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		language := dr.Manifest.Language
+		key := updateKey(language, dr.Reference)
+		if candidates[key] != dr {
+			continue
+		}
+
+		policy := c.Policy(language)
+		if policy.Policy == UpdateNone {
+			continue
+		}
+
+		st := d.checkUpdate(ctx, dr, policy)
+		keys[key] = true
+
+		d.updateMu.Lock()
+		d.updates[key] = st
+		d.updateMu.Unlock()
+	}
+
+	// forget the removed drivers, and the ones not checked anymore
+	d.updateMu.Lock()
+	for key := range d.updates {
+		if !keys[key] {
+			delete(d.updates, key)
+			driverUpdatesAvailable.DeleteLabelValues(strings.SplitN(key, " ", 2)...)
+		}
+	}
+	d.updateMu.Unlock()
+	return nil
+}
+
+// updateCandidates returns the installed driver checked for updates for each
+// language and image reference: the current version if it was installed from
+// the reference, and otherwise the most recent build. The older versions
+// installed from a reference are kept for the requests asking for them, and
+// are never updated.
+func updateCandidates(list []*runtime.DriverImageStatus) map[string]*runtime.DriverImageStatus {
+	out := make(map[string]*runtime.DriverImageStatus)
+	for _, dr := range list {
+		key := updateKey(dr.Manifest.Language, dr.Reference)
+		cur, ok := out[key]
+		switch {
+		case !ok, dr.Current:
+		case cur.Current, !dr.Manifest.Build.After(cur.Manifest.Build):
+			continue
+		}
+		out[key] = dr
+	}
+	return out
+}
+
+// checkUpdate checks if the image reference of an installed driver points to
+// a new image, and installs it if the policy allows it.
+func (d *Daemon) checkUpdate(ctx context.Context, dr *runtime.DriverImageStatus, policy UpdatePolicyConfig) *UpdateStatus {
+	language := dr.Manifest.Language
+	now := time.Now()
+	st := &UpdateStatus{Policy: policy.Policy, Checked: now, Installed: dr.Digest.String()}
+	driverUpdateChecks.WithLabelValues(language).Inc()
+	available := driverUpdatesAvailable.WithLabelValues(language, dr.Reference)
+
+	var di runtime.Digest
+	img, err := runtime.NewDriverImageWithAuth(dr.Reference, d.runtime.RegistryAuth, nil)
+	if err == nil {
+		di, err = img.Digest()
+	}
+	if err != nil {
+		driverUpdateCheckErrors.WithLabelValues(language).Inc()
+		log.Errorf(err, "error checking the updates of driver %s (%s)", language, dr.Reference)
+		st.Error = err.Error()
+		return st
+	}
+
+	st.Latest = di.String()
+	st.Available = st.Latest != dr.Digest.String()
+	if !st.Available || !policy.Apply(now) {
+		if st.Available {
+			available.Set(1)
+			log.Infof("driver %s has an update available: %s", language, dr.Reference)
+		} else {
+			available.Set(0)
+		}
+		return st
+	}
+
+	if err := d.updateDriver(ctx, dr, img); err != nil {
+		driverUpdateErrors.WithLabelValues(language).Inc()
+		available.Set(1)
+		log.Errorf(err, "error updating driver %s (%s)", language, dr.Reference)
+		st.Error = err.Error()
+		return st
+	}
+
+	driverUpdates.WithLabelValues(language).Inc()
+	available.Set(0)
+	st.Installed, st.Available = st.Latest, false
+	return st
+}
+
+// updateDriver installs the new image the reference of an installed driver
+// points to. An image of the same version replaces the installed one as an
+// upgrade (see upgradeDriver). An image of a new version is installed next to
+// it, and made the current version if the installed one was. The installed
+// version is kept, so the requests asking for it are still served.
+func (d *Daemon) updateDriver(ctx context.Context, dr *runtime.DriverImageStatus, img runtime.DriverImage) error {
+	language := dr.Manifest.Language
+	sd, err := d.runtime.StageDriver(img)
+	if runtime.ErrImageVerification.Is(err) {
+		return ErrImageVerification.Wrap(err)
+	} else if err != nil {
+		return err
+	}
+
+	version := sd.Status.Manifest.Version
+	if version == dr.Manifest.Version {
+		old, err := runtime.NewInstalledDriver(dr)
+		if err != nil {
+			d.discardDriver(sd)
+			return ErrRuntime.Wrap(err)
+		}
+		return d.upgradeDriver(ctx, language, old, sd)
+	}
+
+	list, err := d.runtime.ListDrivers()
+	if err != nil {
+		d.discardDriver(sd)
+		return ErrRuntime.Wrap(err)
+	}
+	if driverWithVersion(language, version, list) != nil {
+		d.discardDriver(sd)
+		return ErrAlreadyInstalled.New(language, dr.Reference)
+	}
+
+	if err := d.validateDriver(ctx, language, sd); err != nil {
+		d.discardDriver(sd)
+		return ErrDriverValidation.Wrap(err, sd.Name())
+	}
+
+	id, err := d.runtime.CommitDriver(sd)
+	if err != nil {
+		d.discardDriver(sd)
+		return err
+	}
+
+	if dr.Current {
+		if err := d.setCurrent(id); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("driver %s updated %q (version %s -> %s)", language, img.Name(), dr.Manifest.Version, version)
+	return nil
+}
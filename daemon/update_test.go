@@ -0,0 +1,132 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bblfsh/bblfshd/runtime"
+
+	"github.com/bblfsh/sdk/v3/driver/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUpdateConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "bblfshd-update")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "update.yml", `
+interval: 30m
+languages:
+  Go:
+    policy: auto
+  java:
+    policy: window
+    window: 22:30-02:00
+  python:
+    policy: none
+`)
+	c, err := LoadUpdateConfig(path)
+	require.NoError(err)
+	require.Equal(30*time.Minute, c.Interval)
+
+	night := time.Date(2019, 1, 1, 23, 0, 0, 0, time.Local)
+	noon := time.Date(2019, 1, 1, 12, 0, 0, 0, time.Local)
+
+	require.Equal(UpdateNotify, c.Policy("ruby").Policy)
+	require.False(c.Policy("ruby").Apply(night))
+
+	require.Equal(UpdateAuto, c.Policy("go").Policy)
+	require.True(c.Policy("go").Apply(noon))
+
+	java := c.Policy("java")
+	require.Equal(UpdateWindow, java.Policy)
+	require.True(java.Apply(night))
+	require.False(java.Apply(noon))
+
+	require.Equal(UpdateNone, c.Policy("python").Policy)
+
+	var none *UpdateConfig
+	require.Equal(UpdateNone, none.Policy("go").Policy)
+}
+
+func TestLoadUpdateConfig_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bblfshd-update")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, data := range []string{
+		"interval: -1m",
+		"default: {policy: always}",
+		"languages: {java: {policy: window}}",
+		"languages: {java: {policy: window, window: 25:00-02:00}}",
+		"languages: {java: {policy: auto, when: never}}",
+	} {
+		_, err := LoadUpdateConfig(writeConfig(t, dir, "update.yml", data))
+		require.True(t, ErrInvalidUpdateConfig.Is(err), "%q: %v", data, err)
+	}
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	require := require.New(t)
+
+	at := func(hour, min int) time.Time {
+		return time.Date(2019, 1, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	w, err := ParseMaintenanceWindow("02:00-04:30")
+	require.NoError(err)
+	require.Equal(MaintenanceWindow{Start: 2 * time.Hour, End: 4*time.Hour + 30*time.Minute}, w)
+	require.True(w.Contains(at(2, 0)))
+	require.True(w.Contains(at(4, 29)))
+	require.False(w.Contains(at(4, 30)))
+	require.False(w.Contains(at(1, 59)))
+
+	// windows spanning midnight
+	w, err = ParseMaintenanceWindow("23:00-01:00")
+	require.NoError(err)
+	require.True(w.Contains(at(23, 30)))
+	require.True(w.Contains(at(0, 30)))
+	require.False(w.Contains(at(1, 0)))
+	require.False(w.Contains(at(12, 0)))
+
+	for _, s := range []string{"", "02:00", "2am-4am", "02:00-04:00-06:00"} {
+		_, err := ParseMaintenanceWindow(s)
+		require.Error(err, s)
+	}
+}
+
+func TestUpdateCandidates(t *testing.T) {
+	require := require.New(t)
+
+	build := func(day int) time.Time {
+		return time.Date(2019, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	installed := func(lang, ref, version string, day int, current bool) *runtime.DriverImageStatus {
+		return &runtime.DriverImageStatus{
+			Reference: ref,
+			Manifest:  &manifest.Manifest{Language: lang, Version: version, Build: build(day)},
+			Current:   current,
+		}
+	}
+
+	list := []*runtime.DriverImageStatus{
+		installed("go", "docker://bblfsh/go-driver:latest", "v2.6.0", 1, false),
+		installed("go", "docker://bblfsh/go-driver:latest", "v2.7.0", 2, true),
+		installed("go", "docker://bblfsh/go-driver:latest", "v2.5.0", 3, false),
+		installed("java", "docker://bblfsh/java-driver:latest", "v2.5.0", 1, false),
+		installed("java", "docker://bblfsh/java-driver:latest", "v2.6.0", 2, false),
+		installed("java", "docker://bblfsh/java-driver:v2.4.0", "v2.4.0", 3, true),
+	}
+
+	// the current version, or else the most recent build
+	require.Equal(map[string]*runtime.DriverImageStatus{
+		updateKey("go", "docker://bblfsh/go-driver:latest"):     list[1],
+		updateKey("java", "docker://bblfsh/java-driver:latest"): list[4],
+		updateKey("java", "docker://bblfsh/java-driver:v2.4.0"): list[5],
+	}, updateCandidates(list))
+}
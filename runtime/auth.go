@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"os"
+
+	"github.com/containers/image/docker/reference"
+	"github.com/containers/image/types"
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var ErrCredentialHelper = errors.NewKind("credential helper %s failed for %s")
+
+// RegistryAuth is the source of the credentials used to pull the driver images
+// from their registries. The zero value uses the default locations of the
+// docker and containers authentication files.
+type RegistryAuth struct {
+	// AuthFile is the path of a docker config.json, or of a containers
+	// auth.json, holding the credentials of each registry.
+	AuthFile string
+	// Helper is the path of a docker credential helper, a program run as
+	// `<helper> get` with the registry host on its standard input that prints
+	// the credentials of the registry. The AuthFile is used for the
+	// registries it has no credentials for.
+	Helper string
+}
+
+// Validate checks that the files of the configuration exist.
+func (a *RegistryAuth) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	for _, path := range []string{a.AuthFile, a.Helper} {
+		if path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// systemContext returns the context used to pull the image of ref. The given
+// credentials, if any, are used instead of the ones configured in a.
+func (a *RegistryAuth) systemContext(ref types.ImageReference, creds *types.DockerAuthConfig) (*types.SystemContext, error) {
+	if a == nil && creds == nil {
+		return nil, nil
+	}
+
+	sys := &types.SystemContext{DockerAuthConfig: creds}
+	if a == nil {
+		return sys, nil
+	}
+
+	sys.AuthFilePath = a.AuthFile
+	if creds != nil || a.Helper == "" {
+		return sys, nil
+	}
+
+	// only images in a registry have a docker reference
+	named := ref.DockerReference()
+	if named == nil {
+		return sys, nil
+	}
+
+	var err error
+	sys.DockerAuthConfig, err = a.helperCredentials(reference.Domain(named))
+	return sys, err
+}
+
+// helperCredentials returns the credentials of a registry printed by the
+// credential helper, or nil if it has none.
+func (a *RegistryAuth) helperCredentials(registry string) (*types.DockerAuthConfig, error) {
+	creds, err := client.Get(client.NewShellProgramFunc(a.Helper), registry)
+	if credentials.IsErrCredentialsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, ErrCredentialHelper.Wrap(err, a.Helper, registry)
+	}
+
+	return &types.DockerAuthConfig{
+		Username: creds.Username,
+		Password: creds.Secret,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureHelper is a docker credential helper with the credentials of
+// registry.example.com.
+const fixtureHelper = `#!/bin/sh
+[ "$1" = "get" ] || exit 1
+read registry
+if [ "$registry" = "registry.example.com" ]; then
+	echo '{"ServerURL": "registry.example.com", "Username": "bblfsh", "Secret": "secret"}'
+else
+	echo "credentials not found in native keychain"
+	exit 1
+fi
+`
+
+func TestRegistryAuthSystemContext(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-registry-auth")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	helper := filepath.Join(dir, "docker-credential-fixture")
+	require.NoError(ioutil.WriteFile(helper, []byte(fixtureHelper), 0755))
+
+	auth := &RegistryAuth{AuthFile: filepath.Join(dir, "config.json"), Helper: helper}
+	require.Error(auth.Validate())
+	require.NoError(ioutil.WriteFile(auth.AuthFile, []byte(`{"auths": {}}`), 0644))
+	require.NoError(auth.Validate())
+
+	private, err := ParseImageName("docker://registry.example.com/bblfsh/go-driver:latest")
+	require.NoError(err)
+	public, err := ParseImageName("docker://bblfsh/go-driver:latest")
+	require.NoError(err)
+
+	sys, err := auth.systemContext(private, nil)
+	require.NoError(err)
+	require.Equal(auth.AuthFile, sys.AuthFilePath)
+	require.Equal(&types.DockerAuthConfig{Username: "bblfsh", Password: "secret"}, sys.DockerAuthConfig)
+
+	// the authentication file is used for the registries unknown to the helper
+	sys, err = auth.systemContext(public, nil)
+	require.NoError(err)
+	require.Equal(auth.AuthFile, sys.AuthFilePath)
+	require.Nil(sys.DockerAuthConfig)
+
+	// the credentials of the request take precedence
+	creds := &types.DockerAuthConfig{Username: "user", Password: "password"}
+	sys, err = auth.systemContext(private, creds)
+	require.NoError(err)
+	require.Equal(creds, sys.DockerAuthConfig)
+
+	var none *RegistryAuth
+	sys, err = none.systemContext(private, nil)
+	require.NoError(err)
+	require.Nil(sys)
+}
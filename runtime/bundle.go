@@ -0,0 +1,407 @@
+package runtime
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/image/types"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var ErrInvalidBundle = errors.NewKind("invalid driver bundle %s: %s")
+
+const (
+	// bundleHeaderName is the name of the first entry of a driver bundle,
+	// holding the bundleHeader.
+	bundleHeaderName = "bundle.json"
+	// bundleRootFSName is the name of the entry of a driver bundle holding
+	// the root filesystem of the image, as an uncompressed tarball.
+	bundleRootFSName = "rootfs.tar"
+)
+
+// bundleHeader describes the driver image stored in a bundle.
+type bundleHeader struct {
+	// Language of the driver.
+	Language string `json:"language"`
+	// Version of the driver.
+	Version string `json:"version"`
+	// Digest of the installed image the bundle was written from. It's not
+	// trusted, the bundle is installed with the digest of its root filesystem.
+	Digest string `json:"digest"`
+	// Config of the image, including its original reference.
+	Config *ImageConfig `json:"config"`
+}
+
+// Export writes an installed driver image as a bundle, a self-contained
+// gzip-compressed tarball that can be installed without access to the
+// registry the image was pulled from (see OpenDriverBundle). The bundle holds
+// the image configuration and the root filesystem of the image, including the
+// driver manifest.
+func (s *storage) Export(d *InstalledDriver, w io.Writer) error {
+	root, err := s.RootFS(d)
+	if err != nil {
+		return err
+	}
+
+	config, err := ReadImageConfig(root)
+	if err != nil {
+		return err
+	}
+
+	// the root filesystem is written as a whole, without the layer cache, and
+	// it's not verified again when the bundle is installed
+	config.Layers = nil
+	config.VerifiedDigest = ""
+
+	hdr, err := json.Marshal(&bundleHeader{
+		Language: d.Status.Manifest.Language,
+		Version:  d.Status.Manifest.Version,
+		Digest:   d.Status.Digest.String(),
+		Config:   config,
+	})
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    bundleHeaderName,
+		Mode:    0644,
+		Size:    int64(len(hdr)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tw.Write(hdr); err != nil {
+		return err
+	}
+
+	// the size of the entry is needed before writing it, so the root
+	// filesystem is written to a temporary file first
+	dir, err := s.tempPath()
+	if err != nil {
+		return err
+	}
+
+	defer s.removeTemp(dir)
+	tmp, err := os.Create(filepath.Join(dir, bundleRootFSName))
+	if err != nil {
+		return err
+	}
+
+	defer tmp.Close()
+	if err := tarDir(tmp, root); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    bundleRootFSName,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tw, tmp); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// tarDir writes the content of a directory as a tarball. Symlinks are written
+// after the rest of the entries, so their targets exist when extracted.
+func tarDir(w io.Writer, root string) error {
+	tw := tar.NewWriter(w)
+
+	var links []string
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || path == root {
+			return err
+		}
+
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			links = append(links, path)
+			return nil
+		case fi.IsDir(), fi.Mode().IsRegular():
+			return tarEntry(tw, root, path, fi)
+		default:
+			// devices, sockets and pipes are not needed by the drivers
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, path := range links {
+		fi, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+
+		if err := tarEntry(tw, root, path, fi); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+func tarEntry(tw *tar.Writer, root, path string, fi os.FileInfo) error {
+	var link string
+	if fi.Mode()&os.ModeSymlink != 0 {
+		var err error
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return err
+	}
+
+	hdr.Name = filepath.ToSlash(rel)
+	if fi.IsDir() {
+		hdr.Name += "/"
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	if !fi.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// DriverBundle is a DriverImage stored in a bundle file written by
+// Runtime.ExportDriver. Once installed, it is listed under the name and the
+// reference of the image the bundle was written from, and the digest of its
+// root filesystem.
+type DriverBundle struct {
+	path   string
+	header *bundleHeader
+	digest Digest
+	image  DriverImage
+	// release is called by Close, for the bundles received by the storage.
+	release func() error
+}
+
+// OpenDriverBundle reads the header of the bundle at the given path, and
+// computes the digest of its root filesystem.
+func OpenDriverBundle(path string) (*DriverBundle, error) {
+	b := &DriverBundle{path: path}
+	err := b.read(func(tr *tar.Reader) error {
+		next, err := tr.Next()
+		if err != nil {
+			return err
+		}
+
+		if next.Name != bundleHeaderName {
+			return ErrInvalidBundle.New(path, "missing "+bundleHeaderName)
+		}
+
+		if err := json.NewDecoder(tr).Decode(&b.header); err != nil {
+			return err
+		}
+
+		for {
+			next, err := tr.Next()
+			if err == io.EOF {
+				return ErrInvalidBundle.New(path, "missing "+bundleRootFSName)
+			} else if err != nil {
+				return err
+			}
+
+			if next.Name != bundleRootFSName {
+				continue
+			}
+
+			h := sha256.New()
+			if _, err := io.Copy(h, tr); err != nil {
+				return err
+			}
+
+			b.digest = Digest(h.Sum(nil))
+			return nil
+		}
+	})
+	if err != nil {
+		if ErrInvalidBundle.Is(err) {
+			return nil, err
+		}
+
+		return nil, ErrInvalidBundle.Wrap(err, path, err.Error())
+	}
+
+	if b.header == nil || b.header.Config == nil {
+		return nil, ErrInvalidBundle.New(path, "incomplete "+bundleHeaderName)
+	}
+
+	b.image, err = NewDriverImage(b.header.Config.ImageRef)
+	if err != nil {
+		return nil, ErrInvalidBundle.Wrap(err, path, err.Error())
+	}
+
+	return b, nil
+}
+
+// ReadBundle writes a bundle read from r to a temporary directory of the
+// storage, and opens it. The directory is removed when the bundle is closed.
+func (s *storage) ReadBundle(r io.Reader) (*DriverBundle, error) {
+	dir, err := s.tempPath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.readBundle(r, filepath.Join(dir, "bundle.tar.gz"))
+	if err != nil {
+		_ = s.removeTemp(dir)
+		return nil, err
+	}
+
+	b.release = func() error { return s.removeTemp(dir) }
+	return b, nil
+}
+
+func (s *storage) readBundle(r io.Reader, path string) (*DriverBundle, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenDriverBundle(path)
+}
+
+// Close removes the bundle file if it was received by Runtime.ReadDriverBundle.
+// The bundles opened with OpenDriverBundle are left untouched.
+func (b *DriverBundle) Close() error {
+	if b.release == nil {
+		return nil
+	}
+
+	return b.release()
+}
+
+// read calls fn with a reader of the tarball of the bundle.
+func (b *DriverBundle) read(fn func(tr *tar.Reader) error) error {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	defer gz.Close()
+	return fn(tar.NewReader(gz))
+}
+
+// Language returns the language of the driver in the bundle.
+func (b *DriverBundle) Language() string {
+	return b.header.Language
+}
+
+// Reference returns the reference of the image the bundle was written from.
+func (b *DriverBundle) Reference() string {
+	return b.header.Config.ImageRef
+}
+
+// Name returns the name of the image the bundle was written from.
+func (b *DriverBundle) Name() string {
+	return b.image.Name()
+}
+
+// Digest returns the digest of the root filesystem stored in the bundle. The
+// digest of the image the bundle was written from is not used, since nothing
+// in the bundle can prove it.
+func (b *DriverBundle) Digest() (Digest, error) {
+	return b.digest, nil
+}
+
+// Inspect returns the information of the image stored in the bundle.
+func (b *DriverBundle) Inspect() (*types.ImageInspectInfo, error) {
+	c := b.header.Config
+	info := &types.ImageInspectInfo{
+		Architecture: c.Architecture,
+		Os:           c.OS,
+		Labels:       c.Config.Labels,
+		Created:      c.Created,
+	}
+
+	return info, nil
+}
+
+// WriteTo writes the image to disk at the given path.
+func (b *DriverBundle) WriteTo(path string) error {
+	err := b.read(func(tr *tar.Reader) error {
+		for {
+			next, err := tr.Next()
+			if err == io.EOF {
+				return ErrInvalidBundle.New(b.path, "missing "+bundleRootFSName)
+			} else if err != nil {
+				return err
+			}
+
+			if next.Name == bundleRootFSName {
+				return untar(path, tr)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return WriteImageConfig(b.header.Config, path)
+}
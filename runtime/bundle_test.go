@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+func TestStorageExport(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-export")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	const ref = "docker://bblfsh/go-driver:latest"
+	d := &FixtureDriverImage{ref, &manifest.Manifest{Language: "Go", Version: "1.0"}}
+
+	src := newStorage(filepath.Join(dir, "src"), filepath.Join(dir, "tmp"))
+	status, err := src.Install(d, false)
+	require.NoError(err)
+
+	// files and symlinks of the root filesystem are kept
+	root := status.path
+	err = ioutil.WriteFile(filepath.Join(root, "bin"), []byte("driver"), 0755)
+	require.NoError(err)
+	err = os.Symlink("bin", filepath.Join(root, "link"))
+	require.NoError(err)
+
+	id, err := NewInstalledDriver(status)
+	require.NoError(err)
+
+	path := filepath.Join(dir, "go.tar.gz")
+	f, err := os.Create(path)
+	require.NoError(err)
+	err = src.Export(id, f)
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	b, err := OpenDriverBundle(path)
+	require.NoError(err)
+	require.Equal("Go", b.Language())
+	require.Equal(ref, b.Reference())
+	require.Equal("bblfsh/go-driver:latest", b.Name())
+
+	// the digest of the root filesystem is used, not the one of the header
+	var rootfs bytes.Buffer
+	require.NoError(tarDir(&rootfs, root))
+	sum := sha256.Sum256(rootfs.Bytes())
+
+	di, err := b.Digest()
+	require.NoError(err)
+	require.Equal(Digest(sum[:]), di)
+
+	dst := newStorage(filepath.Join(dir, "dst"), filepath.Join(dir, "tmp"))
+	imported, err := dst.Install(b, false)
+	require.NoError(err)
+	require.Equal(di, imported.Digest)
+	require.Equal(ref, imported.Reference)
+	require.Equal("1.0", imported.Manifest.Version)
+
+	data, err := ioutil.ReadFile(filepath.Join(imported.path, "bin"))
+	require.NoError(err)
+	require.Equal("driver", string(data))
+
+	link, err := os.Readlink(filepath.Join(imported.path, "link"))
+	require.NoError(err)
+	require.Equal("bin", link)
+}
+
+func TestOpenDriverBundle_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-bundle-invalid")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "bundle.tar.gz")
+	err = ioutil.WriteFile(path, []byte("not a bundle"), 0644)
+	require.NoError(err)
+
+	_, err = OpenDriverBundle(path)
+	require.True(ErrInvalidBundle.Is(err), "%v", err)
+}
+
+func TestStorageReadBundle(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-read-bundle")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	const ref = "docker://bblfsh/go-driver:latest"
+	d := &FixtureDriverImage{ref, &manifest.Manifest{Language: "Go", Version: "1.0"}}
+
+	src := newStorage(filepath.Join(dir, "src"), filepath.Join(dir, "tmp"))
+	status, err := src.Install(d, false)
+	require.NoError(err)
+
+	id, err := NewInstalledDriver(status)
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	require.NoError(src.Export(id, &buf))
+
+	dst := newStorage(filepath.Join(dir, "dst"), filepath.Join(dir, "tmp"))
+	b, err := dst.ReadBundle(&buf)
+	require.NoError(err)
+	require.Equal("Go", b.Language())
+
+	// the received bundle is kept by GC until it's closed
+	stats, err := dst.GC()
+	require.NoError(err)
+	require.Equal(0, stats.Temporary)
+	_, err = os.Stat(b.path)
+	require.NoError(err)
+
+	imported, err := dst.Install(b, false)
+	require.NoError(err)
+	require.Equal("1.0", imported.Manifest.Version)
+
+	require.NoError(b.Close())
+	_, err = os.Stat(b.path)
+	require.True(os.IsNotExist(err))
+
+	_, err = dst.ReadBundle(bytes.NewReader([]byte("not a bundle")))
+	require.True(ErrInvalidBundle.Is(err), "%v", err)
+	entries, err := ioutil.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(err)
+	require.Len(entries, 0)
+}
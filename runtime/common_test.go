@@ -1,11 +1,16 @@
 package runtime
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/containers/image/types"
+	"github.com/opencontainers/go-digest"
 	"gopkg.in/bblfsh/sdk.v1/manifest"
 	"gopkg.in/bblfsh/sdk.v1/sdk/driver"
 )
@@ -67,3 +72,84 @@ func (d *FixtureDriverImage) WriteTo(path string) error {
 	defer w.Close()
 	return d.M.Encode(w)
 }
+
+// FixtureLayer is the content of a layer of a FixtureLayeredImage, by path.
+// Paths ending in a slash are directories, and contents starting with "->"
+// are symlinks.
+type FixtureLayer map[string]string
+
+// FixtureLayeredImage is a FixtureDriverImage written layer by layer, the
+// driver manifest is written to the last layer.
+type FixtureLayeredImage struct {
+	FixtureDriverImage
+	L []FixtureLayer
+}
+
+func (d *FixtureLayeredImage) Digest() (Digest, error) {
+	layers, _ := d.Layers()
+	return ComputeDigest(layers...), nil
+}
+
+func (d *FixtureLayeredImage) Layers() ([]string, error) {
+	var layers []string
+	for i := range d.L {
+		layers = append(layers, d.layerDigest(i))
+	}
+
+	return layers, nil
+}
+
+func (d *FixtureLayeredImage) layerDigest(i int) string {
+	var content []string
+	for path, data := range d.L[i] {
+		content = append(content, path+"="+data)
+	}
+
+	sort.Strings(content)
+	if i == len(d.L)-1 {
+		content = append(content, d.N)
+	}
+
+	return digest.FromString(strings.Join(content, "\n")).String()
+}
+
+func (d *FixtureLayeredImage) WriteLayer(layer, path string) error {
+	for i := range d.L {
+		if d.layerDigest(i) != layer {
+			continue
+		}
+
+		for name, data := range d.L[i] {
+			p := filepath.Join(path, name)
+			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+				return err
+			}
+
+			var err error
+			switch {
+			case strings.HasSuffix(name, "/"):
+				err = os.MkdirAll(p, 0755)
+			case strings.HasPrefix(data, "->"):
+				err = os.Symlink(strings.TrimPrefix(data, "->"), p)
+			default:
+				err = ioutil.WriteFile(p, []byte(data), 0644)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if i == len(d.L)-1 {
+			return d.FixtureDriverImage.WriteTo(path)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("layer %s not found", layer)
+}
+
+func (d *FixtureLayeredImage) Config() (*ImageConfig, error) {
+	return &ImageConfig{ImageRef: d.N}, nil
+}
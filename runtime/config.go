@@ -13,6 +13,12 @@ const configExt = ".json"
 type ImageConfig struct {
 	// ImageRef is the original image reference used to retrieve the image.
 	ImageRef string `json:"image_ref"`
+	// Layers are the digests of the layers of the layer cache the root
+	// filesystem was assembled from, empty if it was written as a whole.
+	Layers []string `json:"layers,omitempty"`
+	// VerifiedDigest is the digest of the manifest of the image, if it was
+	// verified against an ImagePolicy before being installed.
+	VerifiedDigest string `json:"verified_digest,omitempty"`
 	v1.Image
 }
 
@@ -27,6 +27,9 @@ type Container interface {
 	Signal(sig os.Signal) error
 	// Returns the current config of the container.
 	Config() configs.Config
+	// NotifyOOM returns a channel signaling when the cgroup of the container
+	// kills a process because it ran out of memory.
+	NotifyOOM() (<-chan struct{}, error)
 	Command
 }
 
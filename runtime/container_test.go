@@ -180,3 +180,28 @@ func (s *ContainerSuite) TestContainer_Env() {
 	require.NoError(err)
 	require.Equal("PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin\nHOME=/root\n", out.String())
 }
+
+func TestContainerLimits_Apply(t *testing.T) {
+	require := require.New(t)
+
+	cfg := ContainerConfigFactory("foo")
+	ContainerLimits{
+		Memory:    512 << 20,
+		CPUs:      1.5,
+		Pids:      64,
+		TmpfsSize: 1 << 20,
+	}.Apply(cfg)
+
+	r := cfg.Cgroups.Resources
+	require.Equal(int64(512<<20), r.Memory)
+	require.Equal(int64(512<<20), r.MemorySwap)
+	require.Equal(uint64(100000), r.CpuPeriod)
+	require.Equal(int64(150000), r.CpuQuota)
+	require.Equal(int64(64), r.PidsLimit)
+
+	for _, m := range cfg.Mounts {
+		if m.Destination == "/dev" {
+			require.Equal("mode=755,size=1048576", m.Data)
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"strings"
 
 	"github.com/containers/image/image"
+	"github.com/containers/image/manifest"
 	"github.com/containers/image/types"
+	"github.com/opencontainers/go-digest"
 )
 
 // DriverImage represents a docker image of a driver
@@ -15,22 +17,54 @@ type DriverImage interface {
 	WriteTo(path string) error
 }
 
+// layeredImage is a DriverImage that can be written one layer at a time, so
+// the storage can share the layers between the installed images.
+type layeredImage interface {
+	DriverImage
+	// Layers returns the digests of the layers of the image, from the base
+	// one.
+	Layers() ([]string, error)
+	// WriteLayer writes a layer to disk at the given path, keeping its
+	// whiteout files.
+	WriteLayer(layer, path string) error
+	// Config returns the configuration of the image.
+	Config() (*ImageConfig, error)
+}
+
 type driverImage struct {
 	imageRef string
 	ref      types.ImageReference
+	sys      *types.SystemContext
+	progress ProgressFunc
+	// verified is the digest of the manifest checked by ImagePolicy.Verify,
+	// empty if the image was not verified.
+	verified digest.Digest
 }
 
 // NewDriverImage returns a new DriverImage from an image reference.
 // For Docker use `docker://bblfsh/rust-driver:latest`.
 func NewDriverImage(imageRef string) (DriverImage, error) {
+	return NewDriverImageWithAuth(imageRef, nil, nil)
+}
+
+// NewDriverImageWithAuth returns a new DriverImage from an image reference,
+// pulled with the credentials of its registry given by auth. If creds is not
+// nil, they are used instead.
+func NewDriverImageWithAuth(imageRef string, auth *RegistryAuth, creds *types.DockerAuthConfig) (DriverImage, error) {
 	ref, err := ParseImageName(imageRef)
 	if err != nil {
 		return nil, err
 	}
 
+	sys, err := auth.systemContext(ref, creds)
+	if err != nil {
+		return nil, err
+	}
+
 	return &driverImage{
 		imageRef: imageRef,
 		ref:      ref,
+		sys:      sys,
 	}, nil
 }
 
@@ -73,27 +107,90 @@ func (d *driverImage) WriteTo(path string) error {
 	}
 
 	defer img.Close()
-	if err := UnpackImage(img, path); err != nil {
+	if err := UnpackImage(img, d.sys, path); err != nil {
 		return err
 	}
 
-	config, err := img.OCIConfig()
+	config, err := d.Config()
 	if err != nil {
 		return err
 	}
 
-	return WriteImageConfig(&ImageConfig{
-		Image:    *config,
-		ImageRef: d.imageRef,
-	}, path)
+	return WriteImageConfig(config, path)
+}
+
+// Layers returns the digests of the layers of the image, from the base one.
+func (d *driverImage) Layers() ([]string, error) {
+	img, err := d.image()
+	if err != nil {
+		return nil, err
+	}
+
+	defer img.Close()
+	var layers []string
+	for _, info := range img.LayerInfos() {
+		layers = append(layers, info.Digest.String())
+	}
+
+	return layers, nil
+}
+
+// WriteLayer writes a layer of the image to disk at the given path.
+func (d *driverImage) WriteLayer(layer, path string) error {
+	img, err := d.image()
+	if err != nil {
+		return err
+	}
+
+	defer img.Close()
+	return UnpackLayer(img, d.sys, layer, path, d.progress)
+}
+
+// Config returns the configuration of the image.
+func (d *driverImage) Config() (*ImageConfig, error) {
+	img, err := d.image()
+	if err != nil {
+		return nil, err
+	}
+
+	defer img.Close()
+	config, err := img.OCIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageConfig{
+		Image:          *config,
+		ImageRef:       d.imageRef,
+		VerifiedDigest: d.verified.String(),
+	}, nil
 }
 
 func (d *driverImage) image() (types.Image, error) {
-	raw, err := d.ref.NewImageSource(nil)
+	raw, err := d.ref.NewImageSource(d.sys)
 	if err != nil {
 		return nil, err
 	}
 
 	unparsedImage := image.UnparsedFromSource(raw)
-	return image.FromUnparsedImage(unparsedImage)
+	img, err := image.FromUnparsedImage(unparsedImage)
+	if err != nil || d.verified == "" {
+		return img, err
+	}
+
+	// a verified image is only used while the manifest is the verified one
+	blob, _, err := img.Manifest()
+	if err == nil {
+		var di digest.Digest
+		if di, err = manifest.Digest(blob); err == nil && di != d.verified {
+			err = ErrImageVerification.New(d.imageRef, "the manifest changed since it was verified")
+		}
+	}
+
+	if err != nil {
+		img.Close()
+		return nil, err
+	}
+
+	return img, nil
 }
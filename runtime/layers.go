@@ -0,0 +1,347 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// layersDir is the directory inside of the storage path holding the
+	// layer cache, with a directory for each unpacked layer.
+	layersDir = ".layers"
+
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+// writeImage writes the root filesystem of an image at path. The layers of
+// a layeredImage are unpacked to the layer cache, if they aren't already, and
+// the root filesystem is assembled from them with hard links. The files of the
+// root filesystems are therefore shared between images and must not be
+// modified in place: containers mount them read-only (see Runtime.Container).
+func (s *storage) writeImage(d DriverImage, path string) error {
+	l, ok := d.(layeredImage)
+	if !ok {
+		return d.WriteTo(path)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	layers, err := l.Layers()
+	if err != nil {
+		return err
+	}
+
+	dirs := make([]string, len(layers))
+	for i, layer := range layers {
+		if dirs[i], err = s.cacheLayer(l, layer); err != nil {
+			return err
+		}
+	}
+
+	reportProgress(d, Progress{Stage: Unpacking})
+	for _, dir := range dirs {
+		if err := linkLayer(dir, path); err != nil {
+			return err
+		}
+	}
+
+	config, err := l.Config()
+	if err != nil {
+		return err
+	}
+
+	config.Layers = layers
+	return WriteImageConfig(config, path)
+}
+
+// cacheLayer returns the path of a layer in the layer cache, unpacking it
+// first if needed.
+func (s *storage) cacheLayer(d layeredImage, layer string) (string, error) {
+	path, err := s.layerPath(layer)
+	if err != nil {
+		return "", err
+	}
+
+	exists, err := pathExists(path)
+	if err != nil || exists {
+		return path, err
+	}
+
+	tmp, err := s.tempPath()
+	if err != nil {
+		return "", err
+	}
+
+	defer s.removeTemp(tmp)
+	if err := d.WriteLayer(layer, tmp); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		// the layer was cached by a concurrent install of another image
+		if exists, _ := pathExists(path); exists {
+			return path, nil
+		}
+
+		return "", err
+	}
+
+	return path, nil
+}
+
+// layerPath returns the path of a layer in the layer cache.
+func (s *storage) layerPath(layer string) (string, error) {
+	d, err := digest.Parse(layer)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.path, layersDir, d.Algorithm().String(), d.Encoded()), nil
+}
+
+// linkLayer applies a layer of the layer cache over a root filesystem. The
+// whiteout files remove the paths of the previous layers, and the files of
+// the layer are hard linked, or copied if they can't be linked, like when the
+// layer cache is in another filesystem.
+func linkLayer(layer, root string) error {
+	return filepath.Walk(layer, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || path == layer {
+			return err
+		}
+
+		rel, err := filepath.Rel(layer, path)
+		if err != nil {
+			return err
+		}
+
+		target, err := scopedPath(root, rel)
+		if err != nil {
+			return err
+		}
+
+		name := fi.Name()
+		switch {
+		case name == whiteoutOpaque:
+			// applied along with its directory
+			return nil
+		case strings.HasPrefix(name, whiteoutPrefix):
+			dir, name := filepath.Split(target)
+			return os.RemoveAll(filepath.Join(dir, strings.TrimPrefix(name, whiteoutPrefix)))
+		case fi.IsDir():
+			return linkDir(path, target, fi)
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+
+			return os.Symlink(link, target)
+		default:
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+
+			if err := os.Link(path, target); err == nil {
+				return nil
+			}
+
+			return copyFile(path, target, fi.Mode())
+		}
+	})
+}
+
+// linkDir creates the directory of a layer in the root filesystem. The content
+// of the previous layers is removed if the directory is opaque.
+func linkDir(path, target string, fi os.FileInfo) error {
+	opaque, err := pathExists(filepath.Join(path, whiteoutOpaque))
+	if err != nil {
+		return err
+	}
+
+	current, err := os.Lstat(target)
+	switch {
+	case err == nil && current.IsDir():
+		if opaque {
+			if err := removeContent(target); err != nil {
+				return err
+			}
+		}
+
+		return os.Chmod(target, fi.Mode())
+	case err == nil:
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	if err := os.Mkdir(target, fi.Mode()); err != nil {
+		return err
+	}
+
+	return os.Chmod(target, fi.Mode())
+}
+
+func removeContent(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := os.RemoveAll(filepath.Join(dir, f.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(source, target string, mode os.FileMode) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+	return writeFile(target, f, mode)
+}
+
+// GCStats is the result of a garbage collection of the storage.
+type GCStats struct {
+	// Layers is the number of unused layers removed from the layer cache.
+	Layers int
+	// Temporary is the number of orphaned temporary directories removed.
+	Temporary int
+	// Size is the disk space released, in bytes.
+	Size int64
+}
+
+// GC removes the layers of the layer cache not used by any installed image,
+// including the rollback generations, and the temporary directories left
+// behind by interrupted installations. Images being installed are not
+// affected.
+func (s *storage) GC() (*GCStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := &GCStats{}
+	files, err := ioutil.ReadDir(s.temp)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, f := range files {
+		path := filepath.Join(s.temp, f.Name())
+		if s.isTempActive(strings.TrimSuffix(path, configExt)) {
+			continue
+		}
+
+		size, err := removeWithSize(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if f.IsDir() {
+			stats.Temporary++
+		}
+
+		stats.Size += size
+	}
+
+	used, err := s.usedLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := filepath.Glob(filepath.Join(s.path, layersDir, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range layers {
+		algorithm, encoded := filepath.Base(filepath.Dir(path)), filepath.Base(path)
+		if used[algorithm+":"+encoded] {
+			continue
+		}
+
+		size, err := removeWithSize(path)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Layers++
+		stats.Size += size
+	}
+
+	return stats, nil
+}
+
+// usedLayers returns the layers used by the installed images, their rollback
+// generations and the staged images.
+func (s *storage) usedLayers() (map[string]bool, error) {
+	var configs []string
+	for _, pattern := range []string{
+		filepath.Join(s.path, "*", "*"+configExt),
+		filepath.Join(s.path, "*", rollbackDir, "*"+configExt),
+		filepath.Join(s.temp, "*"+configExt),
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, matches...)
+	}
+
+	used := make(map[string]bool)
+	for _, c := range configs {
+		config, err := ReadImageConfig(strings.TrimSuffix(c, configExt))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, layer := range config.Layers {
+			used[layer] = true
+		}
+	}
+
+	return used, nil
+}
+
+// removeWithSize removes a file or a directory, and returns the size of the
+// regular files removed.
+func removeWithSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if fi.Mode().IsRegular() {
+			size += fi.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, os.RemoveAll(path)
+}
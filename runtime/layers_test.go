@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+var fixtureBaseLayer = FixtureLayer{
+	"etc/":        "",
+	"etc/os":      "base",
+	"usr/lib/":    "",
+	"usr/lib/lib": "library",
+	"lib":         "->usr/lib",
+	"tmp/":        "",
+	"tmp/old":     "old",
+}
+
+func TestStorageInstall_SharedLayers(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-layers")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	goImage := &FixtureLayeredImage{
+		FixtureDriverImage{"//go", &manifest.Manifest{Language: "Go"}},
+		[]FixtureLayer{fixtureBaseLayer, {"bin/": "", "bin/driver": "go"}},
+	}
+	pyImage := &FixtureLayeredImage{
+		FixtureDriverImage{"//python", &manifest.Manifest{Language: "Python"}},
+		[]FixtureLayer{fixtureBaseLayer, {
+			"bin/":             "",
+			"bin/driver":       "python",
+			"etc/.wh.os":       "",
+			"tmp/":             "",
+			"tmp/.wh..wh..opq": "",
+			"tmp/new":          "new",
+		}},
+	}
+
+	goStatus, err := s.Install(goImage, false)
+	require.NoError(err)
+	pyStatus, err := s.Install(pyImage, false)
+	require.NoError(err)
+
+	layers, err := filepath.Glob(filepath.Join(s.path, layersDir, "*", "*"))
+	require.NoError(err)
+	require.Len(layers, 3)
+
+	// the files of the shared layer are the same ones
+	goLib, err := os.Stat(filepath.Join(goStatus.path, "usr/lib/lib"))
+	require.NoError(err)
+	pyLib, err := os.Stat(filepath.Join(pyStatus.path, "usr/lib/lib"))
+	require.NoError(err)
+	require.True(os.SameFile(goLib, pyLib))
+
+	for path, content := range map[string]string{
+		"bin/driver": "python",
+		"lib/lib":    "library",
+		"tmp/new":    "new",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(pyStatus.path, path))
+		require.NoError(err)
+		require.Equal(content, string(data))
+	}
+
+	// whiteouts remove the files of the previous layers
+	for _, path := range []string{"etc/os", "tmp/old", "etc/.wh.os", "tmp/.wh..wh..opq"} {
+		_, err := os.Lstat(filepath.Join(pyStatus.path, path))
+		require.True(os.IsNotExist(err), path)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(goStatus.path, "etc/os"))
+	require.NoError(err)
+	require.Equal("base", string(data))
+
+	config, err := ReadImageConfig(pyStatus.path)
+	require.NoError(err)
+	require.Len(config.Layers, 2)
+}
+
+func TestStorageGC(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-gc")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	goImage := &FixtureLayeredImage{
+		FixtureDriverImage{"//go", &manifest.Manifest{Language: "Go"}},
+		[]FixtureLayer{fixtureBaseLayer, {"bin/": "", "bin/driver": "go"}},
+	}
+	pyImage := &FixtureLayeredImage{
+		FixtureDriverImage{"//python", &manifest.Manifest{Language: "Python"}},
+		[]FixtureLayer{fixtureBaseLayer, {"bin/": "", "bin/driver": "python"}},
+	}
+	rbImage := &FixtureLayeredImage{
+		FixtureDriverImage{"//ruby", &manifest.Manifest{Language: "Ruby"}},
+		[]FixtureLayer{{"bin/": "", "bin/driver": "ruby"}},
+	}
+
+	_, err = s.Install(goImage, false)
+	require.NoError(err)
+	_, err = s.Install(pyImage, false)
+	require.NoError(err)
+
+	// staged images keep their temporary directory and their layers
+	sd, err := s.Stage(rbImage)
+	require.NoError(err)
+
+	orphan := filepath.Join(s.temp, "image-orphan")
+	require.NoError(os.MkdirAll(orphan, 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(orphan, "file"), []byte("orphan"), 0644))
+
+	require.NoError(s.Remove(pyImage))
+
+	stats, err := s.GC()
+	require.NoError(err)
+	require.Equal(1, stats.Layers)
+	require.Equal(1, stats.Temporary)
+	require.True(stats.Size > 0)
+
+	exists, err := pathExists(orphan)
+	require.NoError(err)
+	require.False(exists)
+
+	for _, d := range []*FixtureLayeredImage{goImage, rbImage} {
+		layers, err := d.Layers()
+		require.NoError(err)
+
+		for _, layer := range layers {
+			path, err := s.layerPath(layer)
+			require.NoError(err)
+
+			exists, err := pathExists(path)
+			require.NoError(err)
+			require.True(exists, layer)
+		}
+	}
+
+	_, err = s.Commit(sd)
+	require.NoError(err)
+
+	status, err := s.Status(goImage)
+	require.NoError(err)
+	data, err := ioutil.ReadFile(filepath.Join(status.path, "bin/driver"))
+	require.NoError(err)
+	require.Equal("go", string(data))
+
+	stats, err = s.GC()
+	require.NoError(err)
+	require.Equal(&GCStats{}, stats)
+}
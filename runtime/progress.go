@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"io"
+	"time"
+)
+
+// progressInterval is the minimal time between two progress events of the
+// download of a layer.
+const progressInterval = 200 * time.Millisecond
+
+// Stage is a stage of the installation of a driver image reported as
+// Progress.
+type Stage string
+
+const (
+	// Downloading is the stage of the download of each layer of the image.
+	Downloading Stage = "downloading"
+	// Unpacking is the stage of the assembly of the root filesystem of the
+	// image, once its layers are downloaded.
+	Unpacking Stage = "unpacking"
+)
+
+// Progress is an event of the installation of a driver image.
+type Progress struct {
+	Stage Stage
+	// Layer is the digest of the layer being downloaded.
+	Layer string
+	// Current is the number of bytes of the layer downloaded.
+	Current int64
+	// Total is the size of the layer, or -1 if unknown.
+	Total int64
+}
+
+// ProgressFunc is called with the events of the installation of a driver
+// image.
+type ProgressFunc func(Progress)
+
+// WithProgress returns d reporting the events of its installation to fn. Only
+// the images pulled from a transport report them; any other DriverImage is
+// returned as is.
+func WithProgress(d DriverImage, fn ProgressFunc) DriverImage {
+	di, ok := d.(*driverImage)
+	if !ok {
+		return d
+	}
+
+	c := *di
+	c.progress = fn
+	return &c
+}
+
+// reportProgress reports an event to the ProgressFunc of d, if any.
+func reportProgress(d DriverImage, p Progress) {
+	if di, ok := d.(*driverImage); ok && di.progress != nil {
+		di.progress(p)
+	}
+}
+
+// progressReader reports the bytes read of a layer, at most once per
+// progressInterval.
+type progressReader struct {
+	r        io.Reader
+	fn       ProgressFunc
+	p        Progress
+	last     time.Time
+	reported int64
+}
+
+func newProgressReader(r io.Reader, layer string, size int64, fn ProgressFunc) *progressReader {
+	pr := &progressReader{
+		r:  r,
+		fn: fn,
+		p:  Progress{Stage: Downloading, Layer: layer, Total: size},
+	}
+
+	pr.report()
+	return pr
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.p.Current += int64(n)
+	if time.Since(r.last) >= progressInterval {
+		r.report()
+	}
+
+	return n, err
+}
+
+// finish reports the bytes read since the last event, if any.
+func (r *progressReader) finish() {
+	if r.p.Current != r.reported {
+		r.report()
+	}
+}
+
+func (r *progressReader) report() {
+	r.fn(r.p)
+	r.last = time.Now()
+	r.reported = r.p.Current
+}
@@ -0,0 +1,32 @@
+package runtime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReader(t *testing.T) {
+	require := require.New(t)
+
+	var events []Progress
+	data := bytes.Repeat([]byte("x"), 1024)
+	pr := newProgressReader(bytes.NewReader(data), "sha256:foo", int64(len(data)), func(p Progress) {
+		events = append(events, p)
+	})
+
+	_, err := ioutil.ReadAll(pr)
+	require.NoError(err)
+	pr.finish()
+
+	require.True(len(events) >= 2)
+	require.Equal(Progress{Stage: Downloading, Layer: "sha256:foo", Total: 1024}, events[0])
+	require.Equal(Progress{Stage: Downloading, Layer: "sha256:foo", Current: 1024, Total: 1024}, events[len(events)-1])
+
+	// nothing is reported when there are no new bytes read
+	n := len(events)
+	pr.finish()
+	require.Len(events, n)
+}
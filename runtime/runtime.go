@@ -3,6 +3,8 @@
 package runtime
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -25,6 +27,12 @@ type ConfigFactory func(containerID string) *configs.Config
 type Runtime struct {
 	ContainerConfigFactory ConfigFactory
 	Root                   string
+	// ImagePolicy is the verification required to the images before they are
+	// installed, if any.
+	ImagePolicy *ImagePolicy
+	// RegistryAuth is the source of the credentials used to pull the images
+	// from their registries.
+	RegistryAuth *RegistryAuth
 
 	s *storage
 	f libcontainer.Factory
@@ -55,24 +63,92 @@ func (r *Runtime) Init() error {
 }
 
 // InstallDriver installs a DriverImage extracting his content to the storage,
-// only one version per image can be stored, update is required to overwrite a
-// previous image if already exists otherwise, Install fails if an previous
-// image already exists.
+// several versions of the same image can be installed side by side. update is
+// required to overwrite this version of the image if already exists,
+// otherwise Install does nothing. The image is verified against the
+// ImagePolicy first, if any.
 func (r *Runtime) InstallDriver(d DriverImage, update bool) (*DriverImageStatus, error) {
+	if err := r.ImagePolicy.Verify(d); err != nil {
+		return nil, err
+	}
+
 	return r.s.Install(d, update)
 }
 
-// RemoveDriver removes a given DriverImage from the image storage.
+// StageDriver extracts a DriverImage to a temporary location of the storage,
+// without replacing any installed image. Containers can be created from the
+// returned StagedDriver to validate the image before installing it with
+// CommitDriver or UpgradeDriver. As InstallDriver, the image is verified
+// against the ImagePolicy first.
+func (r *Runtime) StageDriver(d DriverImage) (*StagedDriver, error) {
+	if err := r.ImagePolicy.Verify(d); err != nil {
+		return nil, err
+	}
+
+	return r.s.Stage(d)
+}
+
+// DiscardDriver removes a StagedDriver from the storage.
+func (r *Runtime) DiscardDriver(sd *StagedDriver) error {
+	return r.s.Discard(sd)
+}
+
+// CommitDriver installs a StagedDriver next to the other installed versions.
+func (r *Runtime) CommitDriver(sd *StagedDriver) (*InstalledDriver, error) {
+	return r.s.Commit(sd)
+}
+
+// UpgradeDriver atomically replaces an installed version with a StagedDriver.
+// The old version is kept as a rollback generation, until the next upgrade or
+// removal of the image.
+func (r *Runtime) UpgradeDriver(sd *StagedDriver, old *InstalledDriver) (*InstalledDriver, error) {
+	return r.s.Upgrade(sd, old)
+}
+
+// RollbackDriver removes the given version and restores the rollback
+// generation of the old version, left by a previous call to UpgradeDriver.
+func (r *Runtime) RollbackDriver(d, old *InstalledDriver) error {
+	return r.s.Rollback(d, old)
+}
+
+// SetCurrentDriver makes the given version the current one for the language
+// of the driver.
+func (r *Runtime) SetCurrentDriver(d *InstalledDriver) error {
+	return r.s.SetCurrent(d)
+}
+
+// RemoveDriver removes a given DriverImage from the image storage. If d is an
+// InstalledDriver only that version is removed.
 func (r *Runtime) RemoveDriver(d DriverImage) error {
 	return r.s.Remove(d)
 }
 
-// ListDrivers lists all the driver images installed on the storage.
+// ExportDriver writes an installed version of a driver as a bundle, which can
+// be installed in another runtime with ReadDriverBundle and InstallDriver.
+func (r *Runtime) ExportDriver(d *InstalledDriver, w io.Writer) error {
+	return r.s.Export(d, w)
+}
+
+// ReadDriverBundle receives a bundle written by ExportDriver from r, to be
+// installed with InstallDriver. The bundle must be closed once installed.
+func (r *Runtime) ReadDriverBundle(rd io.Reader) (*DriverBundle, error) {
+	return r.s.ReadBundle(rd)
+}
+
+// GC removes the layers of the storage not used by any installed driver, and
+// the temporary files left behind by interrupted installations.
+func (r *Runtime) GC() (*GCStats, error) {
+	return r.s.GC()
+}
+
+// ListDrivers lists all the versions of the driver images installed on the
+// storage.
 func (r *Runtime) ListDrivers() ([]*DriverImageStatus, error) {
 	return r.s.List()
 }
 
-// Container returns a container for the given DriverImage and Process
+// Container returns a container for the given DriverImage and Process. The
+// root filesystem of the container is read-only.
 func (r *Runtime) Container(id string, d DriverImage, p *Process, f ConfigFactory) (Container, error) {
 	if f == nil {
 		f = r.ContainerConfigFactory
@@ -86,6 +162,10 @@ func (r *Runtime) Container(id string, d DriverImage, p *Process, f ConfigFactor
 		return nil, err
 	}
 
+	// the files of the root filesystem are hard links to the layer cache,
+	// shared with the other images, so only the mounts are writable
+	cfg.Readonlyfs = true
+
 	imgConfig, err := ReadImageConfig(cfg.Rootfs)
 	if err != nil {
 		return nil, err
@@ -182,6 +262,58 @@ func ContainerConfigFactory(containerID string) *configs.Config {
 	}
 }
 
+// cpuPeriod is the CFS period used to enforce the CPU limits, in microseconds.
+const cpuPeriod = 100000
+
+// ContainerLimits are the resource limits of a container. Zero values mean
+// no limit.
+type ContainerLimits struct {
+	// Memory is the limit of memory usage in bytes, including swap. Processes
+	// of the container are killed by the cgroup if it is exceeded.
+	Memory int64
+	// CPUs is the number of CPUs the container may use, enforced with a CFS
+	// quota.
+	CPUs float64
+	// Pids is the maximal number of processes in the container.
+	Pids int64
+	// TmpfsSize is the maximal size in bytes of each tmpfs mounted in the
+	// container. The directories the container may write to must be tmpfs
+	// mounts for their size to be limited.
+	TmpfsSize int64
+}
+
+// Apply sets the limits in the configuration of a container.
+func (l ContainerLimits) Apply(cfg *configs.Config) {
+	if cfg.Cgroups.Resources == nil {
+		cfg.Cgroups.Resources = &configs.Resources{}
+	}
+
+	r := cfg.Cgroups.Resources
+	if l.Memory > 0 {
+		r.Memory = l.Memory
+		r.MemorySwap = l.Memory
+	}
+	if l.CPUs > 0 {
+		r.CpuPeriod = cpuPeriod
+		r.CpuQuota = int64(l.CPUs * cpuPeriod)
+	}
+	if l.Pids > 0 {
+		r.PidsLimit = l.Pids
+	}
+	if l.TmpfsSize > 0 {
+		for _, m := range cfg.Mounts {
+			if m.Device != "tmpfs" {
+				continue
+			}
+			opt := fmt.Sprintf("size=%d", l.TmpfsSize)
+			if m.Data != "" {
+				opt = m.Data + "," + opt
+			}
+			m.Data = opt
+		}
+	}
+}
+
 // Bootstrap perform the init process of a container. This function should be
 // called at the init function of the application.
 //
@@ -1,9 +1,13 @@
 package runtime
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/bblfsh/sdk/v3/driver"
 	"github.com/bblfsh/sdk/v3/driver/manifest"
@@ -11,46 +15,118 @@ import (
 )
 
 var (
-	ErrDirtyDriverStorage = errors.NewKind("dirty driver storage")
 	ErrDriverNotInstalled = errors.NewKind("driver not installed")
 	ErrMalformedDriver    = errors.NewKind("malformed driver, missing manifest.toml")
+	ErrNoRollback         = errors.NewKind("no rollback generation for driver: %s")
+)
+
+const (
+	// rollbackDir is the directory inside of an image base path where the
+	// previous generation of an upgraded version is kept.
+	rollbackDir = ".rollback"
+	// currentDir is the directory inside of the storage path holding the
+	// current version pointer of each language.
+	currentDir = ".current"
 )
 
 // storage represents the DriverImage storage, taking care of filesystem
 // image operations, such as install, update, remove, etc.
+//
+// Several versions of the same image can be installed side by side, each one
+// in a directory named after its digest. For every language one of the
+// installed versions is the current one, used by default.
+//
+// The layers of the images are unpacked once to a layer cache, and shared by
+// the root filesystems of all the images using them (see writeImage).
 type storage struct {
 	path string
 	temp string
+
+	// mu is held for reading while images are written, and for writing while
+	// the unused layers are removed.
+	mu sync.RWMutex
+	// tempMu guards active, the temporary directories in use.
+	tempMu sync.Mutex
+	active map[string]bool
 }
 
 func newStorage(path, temp string) *storage {
-	return &storage{path: path, temp: temp}
+	return &storage{path: path, temp: temp, active: make(map[string]bool)}
 }
 
 // Install installs a DriverImage extracting his content to the filesystem,
-// only one version per image can be stored, update is required to overwrite a
-// previous image if already exists otherwise, Install fails if an previous
-// image already exists.
+// the other installed versions of the image are kept. If this version of the
+// image is already installed, update is required to overwrite it, otherwise
+// Install does nothing and returns a nil status.
 func (s *storage) Install(d DriverImage, update bool) (*DriverImageStatus, error) {
-	current, err := s.RootFS(d)
-	if err != nil && !ErrDriverNotInstalled.Is(err) {
+	di, err := d.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := pathExists(s.rootFSPath(d, di))
+	if err != nil {
 		return nil, err
 	}
 
-	exists := current != ""
 	if exists && !update {
 		return nil, nil
 	}
 
-	di, err := d.Digest()
+	sd, err := s.Stage(d)
 	if err != nil {
 		return nil, err
 	}
 
-	if exists {
-		if err := s.Remove(d); err != nil {
-			return nil, err
-		}
+	id, err := s.Commit(sd)
+	if err != nil {
+		_ = s.Discard(sd)
+		return nil, err
+	}
+
+	return id.Status, nil
+}
+
+// StagedDriver is a DriverImage unpacked to the temporary directory of the
+// storage. It is not listed as installed, but containers can be created from
+// it, so the image can be validated before replacing an installed one.
+type StagedDriver struct {
+	DriverImage
+	// Status of the unpacked image.
+	Status *DriverImageStatus
+
+	path   string
+	digest Digest
+}
+
+// InstalledDriver is a version of a DriverImage installed in the storage.
+// Containers created from it always use this version, even when other
+// versions of the same image are installed.
+type InstalledDriver struct {
+	DriverImage
+	// Status of the installed version.
+	Status *DriverImageStatus
+
+	path string
+}
+
+// NewInstalledDriver returns the InstalledDriver for a DriverImageStatus
+// returned by the storage.
+func NewInstalledDriver(status *DriverImageStatus) (*InstalledDriver, error) {
+	d, err := NewDriverImage(status.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstalledDriver{DriverImage: d, Status: status, path: status.path}, nil
+}
+
+// Stage extracts the content of a DriverImage to a temporary directory and
+// checks that it contains a driver manifest.
+func (s *storage) Stage(d DriverImage) (*StagedDriver, error) {
+	di, err := d.Digest()
+	if err != nil {
+		return nil, err
 	}
 
 	tmp, err := s.tempPath()
@@ -58,27 +134,282 @@ func (s *storage) Install(d DriverImage, update bool) (*DriverImageStatus, error
 		return nil, err
 	}
 
-	if err := d.WriteTo(tmp); err != nil {
+	if err := s.writeImage(d, tmp); err != nil {
+		_ = s.removeTemp(tmp)
 		return nil, err
 	}
 
 	m, err := newDriverImageStatus(tmp)
 	if err != nil {
+		_ = s.removeTemp(tmp)
 		if os.IsNotExist(err) {
 			return nil, ErrMalformedDriver.New()
 		}
 
 		return nil, err
 	}
-	return m, s.moveImage(tmp, d, di)
+
+	m.Digest = di
+	return &StagedDriver{DriverImage: d, Status: m, path: tmp, digest: di}, nil
+}
+
+// Discard removes a staged image from the temporary directory.
+func (s *storage) Discard(sd *StagedDriver) error {
+	return s.removeTemp(sd.path)
+}
+
+// Commit installs a staged image next to the other installed versions of the
+// image, replacing the same version if already installed. It becomes the
+// current version of its language if there was none.
+func (s *storage) Commit(sd *StagedDriver) (*InstalledDriver, error) {
+	root := s.rootFSPath(sd, sd.digest)
+	if err := removeImage(root); err != nil {
+		return nil, err
+	}
+
+	if err := s.moveImage(sd.path, sd, sd.digest); err != nil {
+		return nil, err
+	}
+
+	s.releaseTemp(sd.path)
+
+	status := *sd.Status
+	status.path = root
+	id := &InstalledDriver{DriverImage: sd.DriverImage, Status: &status, path: root}
+
+	current, err := s.current(status.Manifest.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == "" {
+		return id, s.SetCurrent(id)
+	}
+
+	return id, nil
+}
+
+// Upgrade replaces an installed version with a staged image. The root
+// filesystem of the old version is kept as its rollback generation, replacing
+// any previous rollback generation of the image. If the old version was the
+// current one, the new one takes its place. If the staged image cannot be
+// installed, the old version is restored.
+func (s *storage) Upgrade(sd *StagedDriver, old *InstalledDriver) (*InstalledDriver, error) {
+	wasCurrent, err := s.isCurrent(old)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.retire(old); err != nil {
+		return nil, err
+	}
+
+	id, err := s.Commit(sd)
+	if err == nil && wasCurrent {
+		err = s.SetCurrent(id)
+	}
+
+	if err != nil {
+		if rerr := s.restore(old, s.rootFSPath(sd, sd.digest)); rerr != nil {
+			return nil, fmt.Errorf("%s, and the previous version cannot be restored: %s", err, rerr)
+		}
+
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// Rollback removes an installed version and restores the rollback generation
+// of the old version in its place.
+func (s *storage) Rollback(d, old *InstalledDriver) error {
+	source := filepath.Join(s.rollbackPath(old), old.Status.Digest.String())
+	exists, err := pathExists(source)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ErrNoRollback.New(old.Name())
+	}
+
+	wasCurrent, err := s.isCurrent(d)
+	if err != nil {
+		return err
+	}
+
+	if err := s.restore(old, d.path); err != nil {
+		return err
+	}
+
+	if wasCurrent {
+		return s.SetCurrent(old)
+	}
+
+	return nil
+}
+
+// retire moves the root filesystem of an installed version to the rollback
+// location of the image.
+func (s *storage) retire(d *InstalledDriver) error {
+	if _, err := s.RootFS(d); err != nil {
+		return err
+	}
+
+	rollback := s.rollbackPath(d)
+	if err := os.RemoveAll(rollback); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(rollback, 0755); err != nil {
+		return err
+	}
+
+	target := filepath.Join(rollback, filepath.Base(d.path))
+	if err := os.Rename(d.path+configExt, target+configExt); err != nil {
+		return err
+	}
+
+	if err := os.Rename(d.path, target); err != nil {
+		_ = os.Rename(target+configExt, d.path+configExt)
+		return err
+	}
+
+	return nil
+}
+
+// restore removes the image installed at root, and moves back the rollback
+// generation of a retired version in its place.
+func (s *storage) restore(d *InstalledDriver, root string) error {
+	if err := removeImage(root); err != nil {
+		return err
+	}
+
+	source := filepath.Join(s.rollbackPath(d), filepath.Base(d.path))
+	if err := os.Rename(source+configExt, d.path+configExt); err != nil {
+		return err
+	}
+
+	if err := os.Rename(source, d.path); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(s.rollbackPath(d))
+}
+
+// SetCurrent makes an installed version the current one for its language.
+func (s *storage) SetCurrent(d *InstalledDriver) error {
+	if _, err := s.RootFS(d); err != nil {
+		return err
+	}
+
+	language := d.Status.Manifest.Language
+	if language == "" {
+		return nil
+	}
+
+	rel, err := filepath.Rel(s.path, d.path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(s.path, currentDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, ".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteString(rel)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), s.currentPath(language))
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+
+	return err
+}
+
+// current returns the root filesystem of the current version for the
+// language. An empty path is returned if there is no pointer or the version
+// it points to is not installed anymore.
+func (s *storage) current(language string) (string, error) {
+	if language == "" {
+		return "", nil
+	}
+
+	rel, err := ioutil.ReadFile(s.currentPath(language))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	root := filepath.Join(s.path, string(rel))
+	exists, err := pathExists(root + configExt)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	return root, nil
 }
 
+func (s *storage) isCurrent(d *InstalledDriver) (bool, error) {
+	current, err := s.current(d.Status.Manifest.Language)
+	if err != nil {
+		return false, err
+	}
+
+	return current == d.path, nil
+}
+
+// tempPath creates a temporary directory, which is in use until it's released
+// with releaseTemp or removed with removeTemp.
 func (s *storage) tempPath() (string, error) {
 	if err := os.MkdirAll(s.temp, 0755); err != nil {
 		return "", err
 	}
 
-	return ioutil.TempDir(s.temp, "image")
+	s.tempMu.Lock()
+	defer s.tempMu.Unlock()
+
+	path, err := ioutil.TempDir(s.temp, "image")
+	if err != nil {
+		return "", err
+	}
+
+	s.active[path] = true
+	return path, nil
+}
+
+func (s *storage) releaseTemp(path string) {
+	s.tempMu.Lock()
+	defer s.tempMu.Unlock()
+
+	delete(s.active, path)
+}
+
+func (s *storage) removeTemp(path string) error {
+	defer s.releaseTemp(path)
+	return removeImage(path)
+}
+
+func (s *storage) isTempActive(path string) bool {
+	s.tempMu.Lock()
+	defer s.tempMu.Unlock()
+
+	return s.active[path]
 }
 
 func (s *storage) moveImage(source string, d DriverImage, di Digest) error {
@@ -95,8 +426,27 @@ func (s *storage) moveImage(source string, d DriverImage, di Digest) error {
 	return os.Rename(source, root)
 }
 
-// RootFS returns the path in the host filesystem to an installed image.
+// RootFS returns the path in the host filesystem to an installed image, or to
+// the temporary directory of a StagedDriver. If several versions of the image
+// are installed, the most recently installed one is returned, unless d is an
+// InstalledDriver.
 func (s *storage) RootFS(d DriverImage) (string, error) {
+	switch d := d.(type) {
+	case *StagedDriver:
+		return d.path, nil
+	case *InstalledDriver:
+		exists, err := pathExists(d.path + configExt)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return "", ErrDriverNotInstalled.New()
+		}
+
+		return d.path, nil
+	}
+
 	return s.rootFSFromBase(s.basePath(d))
 }
 
@@ -106,14 +456,31 @@ func (s *storage) rootFSFromBase(path string) (string, error) {
 		return "", err
 	}
 
-	switch len(dirs) {
-	case 1:
-		return dirs[0], nil
-	case 0:
+	var (
+		root   string
+		latest time.Time
+	)
+
+	for _, dir := range dirs {
+		fi, err := os.Stat(dir + configExt)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return "", err
+		}
+
+		if root == "" || fi.ModTime().After(latest) {
+			root, latest = dir, fi.ModTime()
+		}
+	}
+
+	if root == "" {
 		return "", ErrDriverNotInstalled.New()
-	default:
-		return "", ErrDirtyDriverStorage.New()
 	}
+
+	return root, nil
 }
 
 // Status returns the current status in the storage for a given DriverImage, nil
@@ -124,16 +491,44 @@ func (s *storage) Status(d DriverImage) (*DriverImageStatus, error) {
 		return nil, err
 	}
 
-	return newDriverImageStatus(path)
+	status, err := newDriverImageStatus(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.current(status.Manifest.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Current = current == path
+	return status, nil
 }
 
-// Remove removes a given DriverImage from the filesystem.
+// Remove removes a given DriverImage from the filesystem. If d is an
+// InstalledDriver only that version is removed, otherwise all the versions of
+// the image are removed. The rollback generation is removed along with the
+// last version of the image.
 func (s *storage) Remove(d DriverImage) error {
 	path, err := s.RootFS(d)
 	if err != nil {
 		return err
 	}
 
+	if _, ok := d.(*InstalledDriver); ok {
+		if err := removeImage(path); err != nil {
+			return err
+		}
+
+		if _, err := s.rootFSFromBase(s.basePath(d)); !ErrDriverNotInstalled.Is(err) {
+			return err
+		}
+	}
+
+	return os.RemoveAll(s.basePath(d))
+}
+
+func removeImage(path string) error {
 	if err := os.RemoveAll(path + configExt); err != nil {
 		return err
 	}
@@ -141,7 +536,8 @@ func (s *storage) Remove(d DriverImage) error {
 	return os.RemoveAll(path)
 }
 
-// List lists all the driver images installed on disk.
+// List lists all the driver images installed on disk, including all the
+// installed versions of each image.
 func (s *storage) List() ([]*DriverImageStatus, error) {
 	config, err := filepath.Glob(filepath.Join(s.path, "*/*"+configExt))
 	if err != nil {
@@ -159,7 +555,46 @@ func (s *storage) List() ([]*DriverImageStatus, error) {
 		list = append(list, status)
 	}
 
-	return list, nil
+	return list, s.markCurrent(list)
+}
+
+// markCurrent flags the current version of each language in the list. For
+// languages without a valid pointer, the most recently installed version is
+// the current one.
+func (s *storage) markCurrent(list []*DriverImageStatus) error {
+	found := make(map[string]bool)
+	latest := make(map[string]*DriverImageStatus)
+	installed := make(map[string]time.Time)
+	for _, status := range list {
+		language := status.Manifest.Language
+		current, err := s.current(language)
+		if err != nil {
+			return err
+		}
+
+		if current == status.path {
+			status.Current = true
+			found[language] = true
+			continue
+		}
+
+		fi, err := os.Stat(status.path + configExt)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := latest[language]; !ok || fi.ModTime().After(installed[language]) {
+			latest[language], installed[language] = status, fi.ModTime()
+		}
+	}
+
+	for language, status := range latest {
+		if !found[language] {
+			status.Current = true
+		}
+	}
+
+	return nil
 }
 
 func (s *storage) rootFSPath(d DriverImage, di Digest) string {
@@ -170,8 +605,19 @@ func (s *storage) basePath(d DriverImage) string {
 	return filepath.Join(s.path, ComputeDigest(d.Name()).String())
 }
 
+func (s *storage) rollbackPath(d DriverImage) string {
+	return filepath.Join(s.basePath(d), rollbackDir)
+}
+
+func (s *storage) currentPath(language string) string {
+	return filepath.Join(s.path, currentDir, strings.ToLower(language))
+}
+
 func (s *storage) basePathExists(d DriverImage) (bool, error) {
-	path := s.basePath(d)
+	return pathExists(s.basePath(d))
+}
+
+func pathExists(path string) (bool, error) {
 	_, err := os.Stat(path)
 	if err == nil {
 		return true, nil
@@ -196,7 +642,8 @@ func getDirs(path string) ([]string, error) {
 
 	var dirs []string
 	for _, f := range files {
-		if !f.IsDir() {
+		// hidden directories, like the rollback one, are not image roots
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") {
 			continue
 		}
 
@@ -222,6 +669,7 @@ func newDriverImageStatus(path string) (*DriverImageStatus, error) {
 		Reference: config.ImageRef,
 		Digest:    NewDigest(digest),
 		Manifest:  manifest,
+		path:      path,
 	}, nil
 }
 
@@ -230,4 +678,9 @@ type DriverImageStatus struct {
 	Reference string
 	Digest    Digest
 	Manifest  *manifest.Manifest
+	// Current is true if this is the version used by default for the
+	// language of the driver.
+	Current bool
+
+	path string
 }
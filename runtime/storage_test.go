@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -46,7 +47,7 @@ func TestStorageStatus(t *testing.T) {
 	require.Equal("//foo", status.Reference)
 }
 
-func TestStorageStatus_Dirty(t *testing.T) {
+func TestStorageStatus_Versions(t *testing.T) {
 	require := require.New(t)
 
 	dir, err := ioutil.TempDir("", "runtime-storage-status")
@@ -59,15 +60,17 @@ func TestStorageStatus_Dirty(t *testing.T) {
 	_, err = s.Install(d, false)
 	require.NoError(err)
 
+	// directories without an image config are not installed versions
 	err = os.MkdirAll(filepath.Join(dir, "images",
 		ComputeDigest("//foo").String(),
 		ComputeDigest("bar").String(),
 	), 0777)
 	require.NoError(err)
 
-	di, err := s.Status(d)
-	require.True(ErrDirtyDriverStorage.Is(err))
-	require.Nil(di)
+	status, err := s.Status(d)
+	require.NoError(err)
+	require.Equal(ComputeDigest("//foo"), status.Digest)
+	require.True(status.Current)
 }
 
 func TestStorageStatus_NotInstalled(t *testing.T) {
@@ -146,3 +149,234 @@ func TestStorageList(t *testing.T) {
 		require.True(len(status.Reference) > 0)
 	}
 }
+
+func TestStorageInstall_Versions(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-versions")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	v1 := &fixtureVersion{&FixtureDriverImage{"//foo", &manifest.Manifest{Language: "Go", Version: "1.0"}}, "1"}
+	v2 := &fixtureVersion{&FixtureDriverImage{"//foo", &manifest.Manifest{Language: "Go", Version: "2.0"}}, "2"}
+
+	_, err = s.Install(v1, false)
+	require.NoError(err)
+	_, err = s.Install(v2, false)
+	require.NoError(err)
+
+	list, err := s.List()
+	require.NoError(err)
+	require.Len(list, 2)
+	require.Equal("1.0", currentVersion(list))
+
+	installed := make(map[string]*InstalledDriver)
+	for _, status := range list {
+		installed[status.Manifest.Version] = &InstalledDriver{
+			DriverImage: v1, Status: status, path: status.path,
+		}
+	}
+
+	err = s.SetCurrent(installed["2.0"])
+	require.NoError(err)
+
+	list, err = s.List()
+	require.NoError(err)
+	require.Equal("2.0", currentVersion(list))
+
+	err = s.Remove(installed["2.0"])
+	require.NoError(err)
+
+	list, err = s.List()
+	require.NoError(err)
+	require.Len(list, 1)
+	require.Equal("1.0", currentVersion(list))
+
+	err = s.Remove(v1)
+	require.NoError(err)
+
+	_, err = os.Stat(s.basePath(v1))
+	require.True(os.IsNotExist(err))
+}
+
+func TestStorageUpgrade(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-upgrade")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	old := &FixtureDriverImage{"//foo", &manifest.Manifest{Language: "Go"}}
+	_, err = s.Install(old, false)
+	require.NoError(err)
+
+	oldID, err := installedFixture(s, old)
+	require.NoError(err)
+
+	d := &FixtureDriverImage{"//foo:v2", &manifest.Manifest{Language: "Go"}}
+	sd, err := s.Stage(d)
+	require.NoError(err)
+	require.Equal("Go", sd.Status.Manifest.Language)
+
+	path, err := s.RootFS(sd)
+	require.NoError(err)
+	require.True(strings.HasPrefix(path, filepath.Join(dir, "tmp")))
+
+	list, err := s.List()
+	require.NoError(err)
+	require.Len(list, 1)
+
+	id, err := s.Upgrade(sd, oldID)
+	require.NoError(err)
+
+	_, err = s.Status(old)
+	require.True(ErrDriverNotInstalled.Is(err))
+
+	status, err := s.Status(d)
+	require.NoError(err)
+	require.Equal("//foo:v2", status.Reference)
+	require.True(status.Current)
+
+	list, err = s.List()
+	require.NoError(err)
+	require.Len(list, 1)
+
+	err = s.Rollback(id, oldID)
+	require.NoError(err)
+
+	_, err = s.Status(d)
+	require.True(ErrDriverNotInstalled.Is(err))
+
+	status, err = s.Status(old)
+	require.NoError(err)
+	require.Equal("//foo", status.Reference)
+	require.True(status.Current)
+
+	err = s.Rollback(id, oldID)
+	require.True(ErrNoRollback.Is(err))
+}
+
+func TestStorageUpgrade_SameImage(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-upgrade-same")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	d := &FixtureDriverImage{"//foo", &manifest.Manifest{Language: "Go"}}
+	_, err = s.Install(d, false)
+	require.NoError(err)
+
+	old, err := installedFixture(s, d)
+	require.NoError(err)
+
+	sd, err := s.Stage(d)
+	require.NoError(err)
+
+	_, err = s.Upgrade(sd, old)
+	require.NoError(err)
+
+	status, err := s.Status(d)
+	require.NoError(err)
+	require.Equal("//foo", status.Reference)
+
+	err = s.Remove(d)
+	require.NoError(err)
+
+	_, err = os.Stat(s.rollbackPath(d))
+	require.True(os.IsNotExist(err))
+}
+
+func TestStorageUpgrade_CommitFails(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-upgrade-fails")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	old := &FixtureDriverImage{"//foo", &manifest.Manifest{Language: "Go"}}
+	_, err = s.Install(old, false)
+	require.NoError(err)
+
+	oldID, err := installedFixture(s, old)
+	require.NoError(err)
+
+	d := &FixtureDriverImage{"//foo:v2", &manifest.Manifest{Language: "Go"}}
+	sd, err := s.Stage(d)
+	require.NoError(err)
+
+	// the staged image cannot be moved in place
+	require.NoError(os.Remove(sd.path + configExt))
+
+	_, err = s.Upgrade(sd, oldID)
+	require.Error(err)
+
+	status, err := s.Status(old)
+	require.NoError(err)
+	require.Equal("//foo", status.Reference)
+	require.True(status.Current)
+
+	_, err = os.Stat(s.rollbackPath(old))
+	require.True(os.IsNotExist(err))
+
+	require.NoError(s.Discard(sd))
+	tmp, err := ioutil.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(err)
+	require.Len(tmp, 0)
+}
+
+func TestStorageStage_Malformed(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-storage-stage-malformed")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := newStorage(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"))
+
+	sd, err := s.Stage(&FixtureDriverImage{"//foo", nil})
+	require.True(ErrMalformedDriver.Is(err))
+	require.Nil(sd)
+
+	tmp, err := ioutil.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(err)
+	require.Len(tmp, 0)
+}
+
+// fixtureVersion is a FixtureDriverImage with its own digest, representing
+// one of several versions of the same image.
+type fixtureVersion struct {
+	*FixtureDriverImage
+	V string
+}
+
+func (d *fixtureVersion) Digest() (Digest, error) {
+	return ComputeDigest(d.N, d.V), nil
+}
+
+func installedFixture(s *storage, d DriverImage) (*InstalledDriver, error) {
+	status, err := s.Status(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstalledDriver{DriverImage: d, Status: status, path: status.path}, nil
+}
+
+func currentVersion(list []*DriverImageStatus) string {
+	for _, status := range list {
+		if status.Current {
+			return status.Manifest.Version
+		}
+	}
+
+	return ""
+}
@@ -2,64 +2,203 @@ package runtime
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/containers/image/types"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
-func UnpackImage(src types.Image, target string) error {
+// UnpackImage extracts the layers of an image to the target directory, pulled
+// with the given context. The decompressor of each layer is chosen from its
+// media type.
+func UnpackImage(src types.Image, sys *types.SystemContext, target string) error {
 	ref := src.Reference()
-	unpackLayer, err := getLayerUnpacker(ref)
+	raw, err := ref.NewImageSource(sys)
 	if err != nil {
 		return err
 	}
 
-	raw, err := ref.NewImageSource(nil)
-	if err != nil {
-		return err
+	defer raw.Close()
+	for _, layer := range src.LayerInfos() {
+		if err := unpackLayer(raw, layer, target, untar, nil); err != nil {
+			return err
+		}
 	}
 
-	for _, layer := range src.LayerInfos() {
-		rc, _, err := raw.GetBlob(layer)
+	return nil
+}
+
+// UnpackLayer extracts a single layer of an image to the target directory.
+// Unlike UnpackImage, the whiteout files of the layer are kept, so it can be
+// applied later over the previous layers. The progress of the download is
+// reported to progress, if not nil.
+func UnpackLayer(src types.Image, sys *types.SystemContext, layer string, target string, progress ProgressFunc) error {
+	for _, info := range src.LayerInfos() {
+		if info.Digest.String() != layer {
+			continue
+		}
+
+		raw, err := src.Reference().NewImageSource(sys)
 		if err != nil {
 			return err
 		}
 
-		if err := unpackLayer(target, rc); err != nil {
+		defer raw.Close()
+		return unpackLayer(raw, info, target, untarLayer, progress)
+	}
+
+	return fmt.Errorf("layer %s not found in image %s", layer, src.Reference().StringWithinTransport())
+}
+
+func unpackLayer(raw types.ImageSource, layer types.BlobInfo, target string, extract func(string, io.Reader) error, progress ProgressFunc) error {
+	rc, size, err := raw.GetBlob(layer)
+	if err != nil {
+		return err
+	}
+
+	defer rc.Close()
+	var (
+		blob     io.Reader = rc
+		verifier digest.Verifier
+		pr       *progressReader
+	)
+
+	if progress != nil {
+		pr = newProgressReader(rc, layer.Digest.String(), size, progress)
+		blob = pr
+	}
+
+	// the content of the layer is checked against its digest, the one in the
+	// manifest of the image
+	if layer.Digest.Validate() == nil {
+		verifier = layer.Digest.Verifier()
+		blob = io.TeeReader(blob, verifier)
+	}
+
+	r, err := decompressLayer(layer.MediaType, blob)
+	if err != nil {
+		return errors.Wrapf(err, "error decompressing layer %s", layer.Digest)
+	}
+
+	defer r.Close()
+	if err := extract(target, r); err != nil {
+		return err
+	}
+
+	if verifier != nil {
+		// the end of the blob, like the padding of the tarball, may not be
+		// read when extracting it
+		if _, err := io.Copy(ioutil.Discard, blob); err != nil {
 			return err
 		}
+
+		if !verifier.Verified() {
+			return fmt.Errorf("layer %s doesn't match its digest", layer.Digest)
+		}
+	}
+
+	if pr != nil {
+		pr.finish()
 	}
 
 	return nil
 }
 
-func getLayerUnpacker(ref types.ImageReference) (func(string, io.Reader) error, error) {
-	transport := ref.Transport().Name()
-	switch transport {
-	case "docker-daemon":
-		return untar, nil
-	case "docker":
-		return untarGzip, nil
+// compression is a compression algorithm of the image layers.
+type compression int
+
+const (
+	compressionUnknown compression = iota
+	compressionNone
+	compressionGzip
+	compressionZstd
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b, 0x08}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// layerCompression returns the compression of a layer with the given media
+// type, or compressionUnknown if the media type doesn't tell it.
+func layerCompression(mediaType string) compression {
+	switch mediaType {
+	case imgspecv1.MediaTypeImageLayer,
+		imgspecv1.MediaTypeImageLayerNonDistributable:
+		return compressionNone
+	case imgspecv1.MediaTypeImageLayerGzip,
+		imgspecv1.MediaTypeImageLayerNonDistributableGzip:
+		return compressionGzip
+	case mediaTypeImageLayerZstd,
+		mediaTypeImageLayerNonDistributableZstd:
+		return compressionZstd
+	}
+
+	// docker images only have gzip layers, but the docker-daemon and
+	// docker-archive transports label their uncompressed layers as such
+	return compressionUnknown
+}
+
+// OCI media types of the zstd compressed layers, not defined by the version of
+// the image-spec in use.
+const (
+	mediaTypeImageLayerZstd                 = "application/vnd.oci.image.layer.v1.tar+zstd"
+	mediaTypeImageLayerNonDistributableZstd = "application/vnd.oci.image.layer.nondistributable.v1.tar+zstd"
+)
+
+// detectCompression returns the compression of a layer from its first bytes.
+func detectCompression(header []byte) compression {
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return compressionGzip
+	case bytes.HasPrefix(header, zstdMagic):
+		return compressionZstd
 	default:
-		return nil, fmt.Errorf("unsupported transport: %s", transport)
+		return compressionNone
 	}
 }
 
-func untarGzip(dest string, r io.Reader) error {
-	gz, err := gzip.NewReader(r)
-	if err != nil {
-		return errors.Wrap(err, "error creating gzip reader")
+// decompressLayer returns the uncompressed tarball of a layer. The
+// compression is chosen from the media type of the layer, and detected from
+// its content when the media type is unknown or not specific.
+func decompressLayer(mediaType string, r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	c := layerCompression(mediaType)
+	if c == compressionUnknown {
+		header, err := br.Peek(len(zstdMagic))
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		c = detectCompression(header)
 	}
-	defer gz.Close()
 
-	return untar(dest, gz)
+	switch c {
+	case compressionGzip:
+		return gzip.NewReader(br)
+	case compressionZstd:
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+
+		return zr.IOReadCloser(), nil
+	default:
+		return ioutil.NopCloser(br), nil
+	}
 }
 
 func untar(dest string, r io.Reader) error {
@@ -166,7 +305,7 @@ loop:
 				return fmt.Errorf("invalid symlink %q -> %q", path, hdr.Linkname)
 			}
 
-			err := os.Symlink(hdr.Linkname, path)
+			err = os.Symlink(hdr.Linkname, path)
 			if err != nil {
 				if os.IsExist(err) {
 					if err := os.Remove(path); err != nil {
@@ -200,3 +339,117 @@ loop:
 	}
 	return nil
 }
+
+// untarLayer extracts a layer to dest as it is: unlike untar, the whiteout
+// files are written instead of applied. The paths of the entries are resolved
+// within dest, following the symlinks of the layer.
+func untarLayer(dest string, r io.Reader) error {
+	var dirs []*tar.Header
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "error advancing tar stream")
+		}
+
+		path, err := scopedPath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		if path == dest {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		info := hdr.FileInfo()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, info.Mode()); err != nil {
+				return errors.Wrap(err, "error creating directory")
+			}
+
+			dirs = append(dirs, hdr)
+		case tar.TypeReg, tar.TypeRegA:
+			if err := writeFile(path, tr, info.Mode()); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			target, err := scopedPath(dest, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+
+			if err := os.Link(target, path); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	// directory mtimes are set at the end, since writing their content
+	// modifies them
+	for _, hdr := range dirs {
+		path, err := scopedPath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := os.Chtimes(path, time.Now().UTC(), hdr.ModTime); err != nil {
+			return errors.Wrap(err, "error changing time")
+		}
+	}
+
+	return nil
+}
+
+// scopedPath returns the path of name inside root. Symlinks in the directory
+// of name are followed as if root was the root of the filesystem, but not a
+// symlink in the last element.
+func scopedPath(root, name string) (string, error) {
+	name = filepath.Clean(string(os.PathSeparator) + name)
+	if name == string(os.PathSeparator) {
+		return root, nil
+	}
+
+	dir, err := securejoin.SecureJoin(root, filepath.Dir(name))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filepath.Base(name)), nil
+}
+
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return errors.Wrap(err, "unable to open file")
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Wrap(err, "unable to copy")
+	}
+
+	return f.Close()
+}
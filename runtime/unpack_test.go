@@ -0,0 +1,217 @@
+package runtime
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	imgspec "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// layerTar returns an uncompressed layer with a single file.
+func layerTar(t *testing.T, name, content string) []byte {
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})
+	require.NoError(t, err)
+	_, err = tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func compressGzip(t *testing.T, data []byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func compressZstd(t *testing.T, data []byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	w, err := zstd.NewWriter(buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDecompressLayer(t *testing.T) {
+	layer := layerTar(t, "bin", "driver")
+	gz, zst := compressGzip(t, layer), compressZstd(t, layer)
+
+	for _, c := range []struct {
+		mediaType string
+		data      []byte
+	}{
+		{imgspecv1.MediaTypeImageLayer, layer},
+		{imgspecv1.MediaTypeImageLayerGzip, gz},
+		{mediaTypeImageLayerZstd, zst},
+		// docker media types are not reliable, the compression is detected
+		{"application/vnd.docker.image.rootfs.diff.tar.gzip", gz},
+		{"application/vnd.docker.image.rootfs.diff.tar.gzip", layer},
+		{"", zst},
+		{"", layer},
+	} {
+		r, err := decompressLayer(c.mediaType, bytes.NewReader(c.data))
+		require.NoError(t, err, c.mediaType)
+
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err, c.mediaType)
+		require.NoError(t, r.Close())
+		require.Equal(t, layer, data, c.mediaType)
+	}
+}
+
+type ociLayer struct {
+	mediaType string
+	data      []byte
+}
+
+// writeOCILayout writes an OCI image layout at path holding an image tagged
+// as latest, and returns the digest of its manifest.
+func writeOCILayout(t *testing.T, path string, layers ...ociLayer) digest.Digest {
+	require := require.New(t)
+
+	writeBlob := func(data []byte) imgspecv1.Descriptor {
+		d := digest.FromBytes(data)
+		path := filepath.Join(path, "blobs", d.Algorithm().String(), d.Encoded())
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, data, 0644))
+		return imgspecv1.Descriptor{Digest: d, Size: int64(len(data))}
+	}
+	writeJSON := func(v interface{}) imgspecv1.Descriptor {
+		data, err := json.Marshal(v)
+		require.NoError(err)
+		return writeBlob(data)
+	}
+
+	var descs []imgspecv1.Descriptor
+	for _, l := range layers {
+		desc := writeBlob(l.data)
+		desc.MediaType = l.mediaType
+		descs = append(descs, desc)
+	}
+
+	config := writeJSON(imgspecv1.Image{OS: "linux", Architecture: "amd64"})
+	config.MediaType = imgspecv1.MediaTypeImageConfig
+	manifest := writeJSON(imgspecv1.Manifest{
+		Versioned: imgspec.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    descs,
+	})
+	manifest.MediaType = imgspecv1.MediaTypeImageManifest
+	manifest.Annotations = map[string]string{imgspecv1.AnnotationRefName: "latest"}
+
+	index, err := json.Marshal(imgspecv1.Index{
+		Versioned: imgspec.Versioned{SchemaVersion: 2},
+		Manifests: []imgspecv1.Descriptor{manifest},
+	})
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(filepath.Join(path, "index.json"), index, 0644))
+	oci, err := json.Marshal(imgspecv1.ImageLayout{Version: imgspecv1.ImageLayoutVersion})
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(filepath.Join(path, imgspecv1.ImageLayoutFile), oci, 0644))
+
+	return manifest.Digest
+}
+
+func TestDriverImageWriteTo_OCILayout(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "core-driver-oci")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// one layer of each compression
+	layout := filepath.Join(dir, "layout")
+	writeOCILayout(t, layout,
+		ociLayer{imgspecv1.MediaTypeImageLayer, layerTar(t, "a", "uncompressed")},
+		ociLayer{imgspecv1.MediaTypeImageLayerGzip, compressGzip(t, layerTar(t, "b", "gzip"))},
+		ociLayer{mediaTypeImageLayerZstd, compressZstd(t, layerTar(t, "c", "zstd"))},
+	)
+
+	d, err := NewDriverImage("oci:" + layout + ":latest")
+	require.NoError(err)
+
+	root := filepath.Join(dir, "root")
+	err = d.WriteTo(root)
+	require.NoError(err)
+
+	for name, content := range map[string]string{"a": "uncompressed", "b": "gzip", "c": "zstd"} {
+		data, err := ioutil.ReadFile(filepath.Join(root, name))
+		require.NoError(err)
+		require.Equal(content, string(data))
+	}
+}
+
+func TestDriverImageWriteTo_CorruptedLayer(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "core-driver-corrupted")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	layout := filepath.Join(dir, "layout")
+	data := layerTar(t, "a", "original")
+	writeOCILayout(t, layout, ociLayer{imgspecv1.MediaTypeImageLayer, data})
+
+	// the blob is replaced by a different layer, under the same digest
+	d := digest.FromBytes(data)
+	path := filepath.Join(layout, "blobs", d.Algorithm().String(), d.Encoded())
+	require.NoError(ioutil.WriteFile(path, layerTar(t, "a", "modified"), 0644))
+
+	di, err := NewDriverImage("oci:" + layout + ":latest")
+	require.NoError(err)
+
+	err = di.WriteTo(filepath.Join(dir, "root"))
+	require.Error(err)
+	require.Contains(err.Error(), "doesn't match its digest")
+}
+
+func TestUntarLayer(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "core-untar-layer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "etc/.wh.os", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "escape", Typeflag: tar.TypeSymlink, Linkname: "/"},
+		{Name: "escape/file", Typeflag: tar.TypeReg, Mode: 0644},
+	} {
+		require.NoError(tw.WriteHeader(hdr))
+	}
+	require.NoError(tw.Close())
+
+	err = untarLayer(dir, buf)
+	require.NoError(err)
+
+	// whiteouts are kept, and symlinks are followed within the layer
+	for _, path := range []string{"etc/.wh.os", "file"} {
+		fi, err := os.Lstat(filepath.Join(dir, path))
+		require.NoError(err, path)
+		require.True(fi.Mode().IsRegular(), path)
+	}
+}
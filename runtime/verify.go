@@ -0,0 +1,138 @@
+package runtime
+
+import (
+	"os"
+
+	"github.com/containers/image/docker/reference"
+	"github.com/containers/image/image"
+	"github.com/containers/image/manifest"
+	"github.com/containers/image/signature"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var ErrImageVerification = errors.NewKind("image %s failed verification: %s")
+
+// ImagePolicy is the verification required to the driver images before they
+// are installed. The zero value requires no verification.
+type ImagePolicy struct {
+	// RequireDigest requires the images to be referenced by the digest of
+	// their manifest, like `docker://bblfsh/go-driver@sha256:...`.
+	RequireDigest bool
+	// PolicyPath is the path of a containers-policy.json(5) file, the
+	// signatures of the images are checked against it.
+	PolicyPath string
+	// Keyring is the path of a GPG keyring, the images must be signed by one of
+	// its keys. It's ignored if PolicyPath is set.
+	Keyring string
+}
+
+// Enabled returns true if the policy requires any verification.
+func (p *ImagePolicy) Enabled() bool {
+	return p != nil && (p.RequireDigest || p.PolicyPath != "" || p.Keyring != "")
+}
+
+// Validate checks that the signature policy of p can be loaded.
+func (p *ImagePolicy) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+
+	// the keyring is only read when an image is verified
+	if p.PolicyPath == "" && p.Keyring != "" {
+		if _, err := os.Stat(p.Keyring); err != nil {
+			return err
+		}
+	}
+
+	_, err := p.signaturePolicy()
+	return err
+}
+
+// signaturePolicy returns the policy the signatures of the images are checked
+// against, or nil if signatures are not required.
+func (p *ImagePolicy) signaturePolicy() (*signature.Policy, error) {
+	switch {
+	case p.PolicyPath != "":
+		return signature.NewPolicyFromFile(p.PolicyPath)
+	case p.Keyring != "":
+		req, err := signature.NewPRSignedByKeyPath(
+			signature.SBKeyTypeGPGKeys, p.Keyring,
+			signature.NewPRMMatchRepoDigestOrExact(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &signature.Policy{Default: signature.PolicyRequirements{req}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Verify checks a driver image against the policy. Only images pulled from a
+// transport can be verified; any other DriverImage, like a DriverBundle, fails
+// the verification if the policy is enabled.
+func (p *ImagePolicy) Verify(d DriverImage) error {
+	if !p.Enabled() {
+		return nil
+	}
+
+	di, ok := d.(*driverImage)
+	if !ok {
+		return ErrImageVerification.New(d.Name(), "only images pulled from a transport can be verified")
+	}
+
+	return di.verify(p)
+}
+
+// verify checks the image against the policy. The digest of the verified
+// manifest is kept, so the image is not written if it changes afterwards, and
+// it's stored in the ImageConfig of the image once installed.
+func (d *driverImage) verify(p *ImagePolicy) error {
+	canonical, pinned := d.ref.DockerReference().(reference.Canonical)
+	if p.RequireDigest && !pinned {
+		return ErrImageVerification.New(d.imageRef, "the image is not referenced by digest")
+	}
+
+	raw, err := d.ref.NewImageSource(d.sys)
+	if err != nil {
+		return err
+	}
+
+	defer raw.Close()
+	unparsed := image.UnparsedFromSource(raw)
+
+	policy, err := p.signaturePolicy()
+	if err != nil {
+		return err
+	}
+
+	if policy != nil {
+		pc, err := signature.NewPolicyContext(policy)
+		if err != nil {
+			return err
+		}
+
+		defer pc.Destroy()
+		if _, err := pc.IsRunningImageAllowed(unparsed); err != nil {
+			return ErrImageVerification.Wrap(err, d.imageRef, err.Error())
+		}
+	}
+
+	blob, _, err := unparsed.Manifest()
+	if err != nil {
+		return err
+	}
+
+	digest, err := manifest.Digest(blob)
+	if err != nil {
+		return err
+	}
+
+	if pinned && canonical.Digest() != digest {
+		return ErrImageVerification.New(d.imageRef, "the manifest doesn't match the digest "+canonical.Digest().String())
+	}
+
+	d.verified = digest
+	return nil
+}
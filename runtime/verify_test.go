@@ -0,0 +1,101 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+func TestImagePolicyValidate(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-policy-validate")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	var p *ImagePolicy
+	require.False(p.Enabled())
+	require.NoError(p.Validate())
+
+	path := filepath.Join(dir, "policy.json")
+	require.NoError(ioutil.WriteFile(path, []byte(`{"default": []}`), 0644))
+
+	p = &ImagePolicy{PolicyPath: path}
+	require.True(p.Enabled())
+	require.Error(p.Validate())
+
+	p = &ImagePolicy{Keyring: filepath.Join(dir, "missing.gpg")}
+	require.Error(p.Validate())
+}
+
+func TestImagePolicyVerify_Bundle(t *testing.T) {
+	require := require.New(t)
+
+	d := &FixtureDriverImage{"//go", &manifest.Manifest{Language: "Go"}}
+
+	var p *ImagePolicy
+	require.NoError(p.Verify(d))
+
+	p = &ImagePolicy{RequireDigest: true}
+	err := p.Verify(d)
+	require.True(ErrImageVerification.Is(err), "%v", err)
+}
+
+func TestImagePolicyVerify_RequireDigest(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-policy-digest")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	layout := filepath.Join(dir, "layout")
+	writeOCILayout(t, layout, ociLayer{imgspecv1.MediaTypeImageLayer, layerTar(t, "a", "a")})
+
+	d, err := NewDriverImage("oci:" + layout + ":latest")
+	require.NoError(err)
+
+	p := &ImagePolicy{RequireDigest: true}
+	err = p.Verify(d)
+	require.True(ErrImageVerification.Is(err), "%v", err)
+}
+
+func TestImagePolicyVerify_Policy(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "runtime-policy")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	layout := filepath.Join(dir, "layout")
+	di := writeOCILayout(t, layout, ociLayer{imgspecv1.MediaTypeImageLayer, layerTar(t, "a", "a")})
+
+	reject := filepath.Join(dir, "reject.json")
+	err = ioutil.WriteFile(reject, []byte(`{"default": [{"type": "reject"}]}`), 0644)
+	require.NoError(err)
+	accept := filepath.Join(dir, "accept.json")
+	err = ioutil.WriteFile(accept, []byte(`{"default": [{"type": "insecureAcceptAnything"}]}`), 0644)
+	require.NoError(err)
+
+	d, err := NewDriverImage("oci:" + layout + ":latest")
+	require.NoError(err)
+
+	p := &ImagePolicy{PolicyPath: reject}
+	err = p.Verify(d)
+	require.True(ErrImageVerification.Is(err), "%v", err)
+
+	p = &ImagePolicy{PolicyPath: accept}
+	require.NoError(p.Verify(d))
+
+	// the verified digest is stored along with the image
+	root := filepath.Join(dir, "root")
+	require.NoError(d.WriteTo(root))
+
+	config, err := ReadImageConfig(root)
+	require.NoError(err)
+	require.Equal(di.String(), config.VerifiedDigest)
+}